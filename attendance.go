@@ -0,0 +1,90 @@
+package dinny
+
+import "time"
+
+// Attendance sources describe how an attendance was recorded.
+const (
+	AttendanceSourceReaction = "reaction"
+	AttendanceSourceButton   = "button"
+	AttendanceSourceManual   = "manual"
+)
+
+// Attendance represents a member having eaten a specific meal.
+type Attendance struct {
+	ID        int64     `json:"id"`
+	MealID    int64     `json:"mealID"`
+	MemberID  int64     `json:"memberID"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// LateRSVP represents a member trying to join or leave a meal after its RSVPs closed.
+// Late RSVPs are kept apart from the attendances and never change who's eating.
+type LateRSVP struct {
+	ID        int64     `json:"id"`
+	MealID    int64     `json:"mealID"`
+	MemberID  int64     `json:"memberID"`
+	Eating    bool      `json:"eating"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MaxGuests is the most guests a member may bring to a meal.
+const MaxGuests = 5
+
+// Guests represents the guests, who aren't members of dinner rotation, that a member brings to a meal.
+// Guests count towards the headcount of the meal but are kept in their own ledger rather than the host's meals eaten.
+type Guests struct {
+	MealID    int64     `json:"mealID"`
+	MemberID  int64     `json:"memberID"`
+	Count     int64     `json:"count"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CountGuests returns the total number of guests.
+func CountGuests(guests []*Guests) int64 {
+	var n int64
+	for _, g := range guests {
+		n += g.Count
+	}
+	return n
+}
+
+// AttendanceService represents a service for managing the attendance ledger.
+// The ledger is the source of truth for Member.MealsEaten, which is kept in sync whenever an attendance is created or deleted.
+type AttendanceService interface {
+	// FindAttendance retrieves the attendance of a member at a meal.
+	// Returns ErrNotFound if attendance does not exist.
+	FindAttendance(mealID int64, memberID int64) (*Attendance, error)
+
+	// ListAttendancesByMeal retrieves every attendance recorded for a meal.
+	ListAttendancesByMeal(mealID int64) ([]*Attendance, error)
+
+	// ListAttendancesByMember retrieves every attendance recorded for a member.
+	ListAttendancesByMember(memberID int64) ([]*Attendance, error)
+
+	// CreateAttendance records a member as eating a meal and increments the member's meals eaten.
+	// Recording an attendance that already exists is a no-op.
+	// Returns ErrMealClosed if the meal has been closed out.
+	CreateAttendance(a *Attendance) error
+
+	// DeleteAttendance removes a member's attendance at a meal and decrements the member's meals eaten.
+	// Deleting an attendance that doesn't exist is a no-op.
+	// Returns ErrMealClosed if the meal has been closed out or the attendance was counted towards a season which has been closed.
+	DeleteAttendance(mealID int64, memberID int64) error
+
+	// ListLateRSVPsByMeal retrieves every late RSVP recorded for a meal.
+	ListLateRSVPsByMeal(mealID int64) ([]*LateRSVP, error)
+
+	// CreateLateRSVP records a member trying to join or leave a meal after its RSVPs closed. Sets the ID of l on success.
+	CreateLateRSVP(l *LateRSVP) error
+
+	// ListGuestsByMeal retrieves the guests every member brings to a meal.
+	ListGuestsByMeal(mealID int64) ([]*Guests, error)
+
+	// ListGuestsByMember retrieves the guests a member brought to every meal.
+	ListGuestsByMember(memberID int64) ([]*Guests, error)
+
+	// SetGuests sets the number of guests a member brings to a meal, replacing the previous number. Setting 0 removes the guests.
+	// Returns ErrMealClosed if the meal has been closed out.
+	SetGuests(mealID int64, memberID int64, count int64) error
+}
@@ -0,0 +1,53 @@
+package dinny
+
+// Availability represents a date range in which a member can't cook or isn't eating, e.g. because they are travelling.
+type Availability struct {
+	ID         int64  `json:"id"`
+	MemberID   int64  `json:"memberID"`
+	From       Date   `json:"from"`
+	To         Date   `json:"to"`
+	CannotCook bool   `json:"cannotCook"`
+	NotEating  bool   `json:"notEating"`
+	Note       string `json:"note"`
+}
+
+// Covers reports whether date lies within the availability's date range, inclusive.
+func (a *Availability) Covers(date Date) bool {
+	return !date.Before(a.From) && !a.To.Before(date)
+}
+
+// CookingConflict returns the availability preventing the member from cooking on date, or nil if the member can cook.
+func CookingConflict(availabilities []*Availability, memberID int64, date Date) *Availability {
+	for _, a := range availabilities {
+		if a.MemberID == memberID && a.CannotCook && a.Covers(date) {
+			return a
+		}
+	}
+	return nil
+}
+
+// EatingConflict returns the availability marking the member as not eating on date, or nil if the member may be eating.
+func EatingConflict(availabilities []*Availability, memberID int64, date Date) *Availability {
+	for _, a := range availabilities {
+		if a.MemberID == memberID && a.NotEating && a.Covers(date) {
+			return a
+		}
+	}
+	return nil
+}
+
+// AvailabilityService represents a service for managing member availability.
+type AvailabilityService interface {
+	// ListAvailabilities retrieves the availabilities overlapping the range between from and to, inclusive.
+	ListAvailabilities(from Date, to Date) ([]*Availability, error)
+
+	// ListAvailabilitiesByMember retrieves every availability of a member.
+	ListAvailabilitiesByMember(memberID int64) ([]*Availability, error)
+
+	// CreateAvailability creates a new availability. Sets the ID of a on success.
+	CreateAvailability(a *Availability) error
+
+	// DeleteAvailability permanently deletes an availability.
+	// Returns ErrNotFound if availability does not exist.
+	DeleteAvailability(id int64) error
+}
@@ -0,0 +1,35 @@
+package dinny
+
+import "testing"
+
+// TestEatingConflict ensures only availabilities marking the member as not eating on the date conflict.
+func TestEatingConflict(t *testing.T) {
+	from, to := Date{Year: 2023, Month: 1, Day: 30}, Date{Year: 2023, Month: 2, Day: 2}
+	availabilities := []*Availability{
+		{ID: 1, MemberID: 1, From: from, To: to, CannotCook: true},
+		{ID: 2, MemberID: 2, From: from, To: to, NotEating: true},
+	}
+	tests := []struct {
+		name     string
+		memberID int64
+		date     Date
+		want     int64
+	}{
+		{"not eating", 2, Date{Year: 2023, Month: 2, Day: 1}, 2},
+		{"last day", 2, to, 2},
+		{"after", 2, Date{Year: 2023, Month: 2, Day: 3}, 0},
+		{"only cannot cook", 1, Date{Year: 2023, Month: 2, Day: 1}, 0},
+		{"other member", 3, Date{Year: 2023, Month: 2, Day: 1}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got int64
+			if a := EatingConflict(availabilities, tt.memberID, tt.date); a != nil {
+				got = a.ID
+			}
+			if got != tt.want {
+				t.Errorf("EatingConflict() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,9 +6,9 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ddritzenhoff/dinny"
@@ -22,6 +22,11 @@ var thursdaySlackUID string
 var fridaySlackUID string
 var saturdaySlackUID string
 var sundaySlackUID string
+var autoAssign bool
+var dryRun bool
+var autoDays int
+var minDaysBetween int
+var forceAssign bool
 
 // AssignCooksCommand is a command to assign cooks.
 type AssignCooksCommand struct {
@@ -31,13 +36,18 @@ type AssignCooksCommand struct {
 // Run executes the assign_cooks command.
 func (c *AssignCooksCommand) Run(ctx context.Context, args []string) error {
 	fs := flag.NewFlagSet("", flag.ContinueOnError)
-	fs.StringVar(&mondaySlackUID, "monday", "", "set cook for monday <slackUID>")
-	fs.StringVar(&tuesdaySlackUID, "tuesday", "", "set cook for tuesday <slackUID>")
-	fs.StringVar(&wednesdaySlackUID, "wednesday", "", "set cook for wednesday <slackUID>")
-	fs.StringVar(&thursdaySlackUID, "thursday", "", "set cook for thursday <slackUID>")
-	fs.StringVar(&fridaySlackUID, "friday", "", "set cook for friday <slackUID>")
-	fs.StringVar(&saturdaySlackUID, "saturday", "", "set cook for saturday <slackUID>")
-	fs.StringVar(&sundaySlackUID, "sunday", "", "set cook for sunday <slackUID>")
+	fs.StringVar(&mondaySlackUID, "monday", "", "set cooks for monday <slackUID>[,<slackUID>...]")
+	fs.StringVar(&tuesdaySlackUID, "tuesday", "", "set cooks for tuesday <slackUID>[,<slackUID>...]")
+	fs.StringVar(&wednesdaySlackUID, "wednesday", "", "set cooks for wednesday <slackUID>[,<slackUID>...]")
+	fs.StringVar(&thursdaySlackUID, "thursday", "", "set cooks for thursday <slackUID>[,<slackUID>...]")
+	fs.StringVar(&fridaySlackUID, "friday", "", "set cooks for friday <slackUID>[,<slackUID>...]")
+	fs.StringVar(&saturdaySlackUID, "saturday", "", "set cooks for saturday <slackUID>[,<slackUID>...]")
+	fs.StringVar(&sundaySlackUID, "sunday", "", "set cooks for sunday <slackUID>[,<slackUID>...]")
+	fs.BoolVar(&forceAssign, "force", false, "assign cooks even if they marked themselves as away")
+	fs.BoolVar(&autoAssign, "auto", false, "assign the cooks with the worst ratios automatically")
+	fs.BoolVar(&dryRun, "dry-run", false, "only print the cooks -auto would assign")
+	fs.IntVar(&autoDays, "days", 7, "number of days -auto assigns cooks for")
+	fs.IntVar(&minDaysBetween, "min-days-between", 6, "minimum number of days between two meals cooked by the same member with -auto")
 	attachConfigFlags(fs, &c.ConfigPath)
 	fs.Usage = c.usage
 	err := fs.Parse(args)
@@ -56,8 +66,14 @@ func (c *AssignCooksCommand) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("Run ReadConfigFile: %w", err)
 	}
 
+	if autoAssign {
+		return c.runAuto(config)
+	} else if dryRun {
+		return fmt.Errorf("Run: -dry-run requires -auto")
+	}
+
 	now := time.Now()
-	var request rest.AssignCooksRequest
+	request := rest.AssignCooksRequest{Force: forceAssign}
 
 	if sundaySlackUID != "" {
 		request.CookAssignments = append(request.CookAssignments, buildCookAssignment(now, time.Sunday, sundaySlackUID))
@@ -85,28 +101,70 @@ func (c *AssignCooksCommand) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("Run: didn't specify any days, so nothing happened")
 	}
 
-	buf, err := json.Marshal(request)
+	err = assignCooks(config, request)
 	if err != nil {
-		return fmt.Errorf("Run json.Marshal: %w", err)
+		return fmt.Errorf("Run: %w", err)
+	}
+	return nil
+}
+
+// runAuto asks the server to propose cooks and assigns them unless -dry-run is set.
+func (c *AssignCooksCommand) runAuto(config Config) error {
+	buf, err := json.Marshal(rest.ProposeScheduleRequest{
+		Days:           autoDays,
+		MinDaysBetween: minDaysBetween,
+	})
+	if err != nil {
+		return fmt.Errorf("runAuto json.Marshal: %w", err)
+	}
+	body, err := doRequest(config, http.MethodPost, "/cmd/propose-schedule", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("runAuto: %w", err)
+	}
+	var proposal rest.AssignCooksRequest
+	if err := json.Unmarshal(body, &proposal); err != nil {
+		return fmt.Errorf("runAuto json.Unmarshal: %w", err)
 	}
-	responseBody := bytes.NewBuffer(buf)
 
-	url := fmt.Sprintf("%s/cmd/assign-cooks", config.URL)
-	resp, err := http.Post(url, "application/json", responseBody)
+	request := rest.AssignCooksRequest{Force: forceAssign}
+	for _, assignment := range proposal.CookAssignments {
+		cook := assignment.CookSlackUID
+		if cook == "" {
+			cook = "NOT SET (nobody available)"
+		} else {
+			request.CookAssignments = append(request.CookAssignments, assignment)
+		}
+		fmt.Printf("%s\t%s\n", assignment.Date, cook)
+	}
+	if dryRun || len(request.CookAssignments) == 0 {
+		return nil
+	}
+
+	err = assignCooks(config, request)
 	if err != nil {
-		return fmt.Errorf("Run http.Post: %w", err)
+		return fmt.Errorf("runAuto: %w", err)
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// assignCooks sends the cook assignments to the server and prints any warnings.
+func assignCooks(config Config, request rest.AssignCooksRequest) error {
+	buf, err := json.Marshal(request)
 	if err != nil {
-		return fmt.Errorf("Run io.ReadAll: %w", err)
+		return fmt.Errorf("assignCooks json.Marshal: %w", err)
 	}
-	b, err := prettyPrint(body)
+	body, err := doRequest(config, http.MethodPut, "/cmd/assign-cooks", bytes.NewBuffer(buf))
 	if err != nil {
-		return fmt.Errorf("Run prettyPrint: %w", err)
+		return fmt.Errorf("assignCooks: %w", err)
+	}
+	var resp rest.AssignCooksResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("assignCooks json.Unmarshal: %w", err)
 	}
-	fmt.Println(string(b))
+	for _, warning := range resp.Warnings {
+		fmt.Printf("warning: %s\n", warning)
+	}
+	fmt.Println("success")
 	return nil
 }
 
@@ -116,16 +174,21 @@ func getDayDifference(now time.Weekday, then time.Weekday) int {
 	return (int(then) - int(now) + 7) % 7
 }
 
-// buildCookAssignment creates a pb.CookAssignment.
-func buildCookAssignment(now time.Time, then time.Weekday, cookSlackUID string) rest.CookAssignment {
+// buildCookAssignment creates a rest.CookAssignment from comma separated Slack UIDs, the first being the lead cook.
+func buildCookAssignment(now time.Time, then time.Weekday, cookSlackUIDs string) rest.CookAssignment {
 	year, month, day := now.AddDate(0, 0, getDayDifference(now.Weekday(), then)).Date()
+	cooks := strings.Split(cookSlackUIDs, ",")
+	for ii, cook := range cooks {
+		cooks[ii] = strings.TrimSpace(cook)
+	}
 	return rest.CookAssignment{
 		Date: dinny.Date{
 			Year:  year,
 			Month: month,
 			Day:   day,
 		},
-		CookSlackUID: cookSlackUID,
+		CookSlackUID:    cooks[0],
+		CoCookSlackUIDs: cooks[1:],
 	}
 }
 
@@ -134,26 +197,39 @@ func (c *AssignCooksCommand) usage() {
 	fmt.Println(`
 Assign cooks for the next week starting with the current day.
 If today were Monday, and a cook were to be assigned with the -monday flag, that cook would be set to cook today.
+Members cooking together are separated by commas, e.g. -monday U123,U456. They split the credit for the meal.
 
 Usage:
 
 		dinny assign_cooks -monday <slackUID> -tuesday <slackUID> -wednesday <slackUID> -thursday <slackUID> -friday <slackUID> -saturday <slackUID> -sunday <slackUID>
+		dinny assign_cooks -force ...
+		dinny assign_cooks -auto [-dry-run] [-days <int>] [-min-days-between <int>]
 
 Arguments:
 
-		-monday <slackUID>
-			Set the cook for the upcoming Monday
-		-tuesday <slackUID>
-			Set the cook for the upcoming Tuesday
-		-wednesday <slackUID>
-			Set the cook for the upcoming Wednesday
-		-thursday <slackUID>
-			Set the cook for the upcoming Thursday
-		-friday <slackUID>
-			Set the cook for the upcoming Friday
-		-saturday <slackUID>
-			Set the cook for the upcoming Saturday
-		-sunday <slackUID>
-			Set the cook for the upcoming Sunday
+		-monday <slackUID>[,<slackUID>...]
+			Set the cooks for the upcoming Monday, the first being the lead cook
+		-tuesday <slackUID>[,<slackUID>...]
+			Set the cooks for the upcoming Tuesday, the first being the lead cook
+		-wednesday <slackUID>[,<slackUID>...]
+			Set the cooks for the upcoming Wednesday, the first being the lead cook
+		-thursday <slackUID>[,<slackUID>...]
+			Set the cooks for the upcoming Thursday, the first being the lead cook
+		-friday <slackUID>[,<slackUID>...]
+			Set the cooks for the upcoming Friday, the first being the lead cook
+		-saturday <slackUID>[,<slackUID>...]
+			Set the cooks for the upcoming Saturday, the first being the lead cook
+		-sunday <slackUID>[,<slackUID>...]
+			Set the cooks for the upcoming Sunday, the first being the lead cook
+		-force
+			Assign cooks even if they marked themselves as away
+		-auto
+			Assign the members with the worst meals eaten to meals cooked ratios to the days without a cook
+		-dry-run
+			Only print the cooks -auto would assign
+		-days <int>
+			Number of days starting today -auto assigns cooks for (default 7)
+		-min-days-between <int>
+			Minimum number of days between two meals cooked by the same member (default 6)
 	`[1:])
 }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var awayFrom string
+var awayTo string
+var awayMember string
+var awayCannotCook bool
+var awayNotEating bool
+var awayNote string
+var awayList bool
+var awayDelete int64
+
+// AwayCommand is a command to mark a member as unable to cook or not eating for a range of days.
+type AwayCommand struct {
+	ConfigPath string
+}
+
+// Run executes the away command.
+func (c *AwayCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&awayFrom, "from", "", "first day away <YYYY-MM-DD>")
+	fs.StringVar(&awayTo, "to", "", "last day away <YYYY-MM-DD>")
+	fs.StringVar(&awayMember, "member", "", "mark another member as away <slackUID> (leaders only)")
+	fs.BoolVar(&awayCannotCook, "cannot-cook", true, "the member can't cook")
+	fs.BoolVar(&awayNotEating, "not-eating", true, "the member isn't eating")
+	fs.StringVar(&awayNote, "note", "", "reason for being away")
+	fs.BoolVar(&awayList, "list", false, "list who is away within the next 30 days")
+	fs.Int64Var(&awayDelete, "delete", 0, "delete the availability with the given id")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	var body []byte
+	switch {
+	case awayList:
+		body, err = doRequest(config, http.MethodGet, "/cmd/availabilities", nil)
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	case awayDelete > 0:
+		_, err = doRequest(config, http.MethodDelete, fmt.Sprintf("/cmd/availabilities/%d", awayDelete), nil)
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+		fmt.Println("success")
+		return nil
+	default:
+		from, err := dinny.ParseDate(awayFrom)
+		if err != nil {
+			return fmt.Errorf("Run -from: %w", err)
+		}
+		to, err := dinny.ParseDate(awayTo)
+		if err != nil {
+			return fmt.Errorf("Run -to: %w", err)
+		}
+		buf, err := json.Marshal(rest.CreateAvailabilityRequest{
+			MemberSlackUID: awayMember,
+			From:           from,
+			To:             to,
+			CannotCook:     awayCannotCook,
+			NotEating:      awayNotEating,
+			Note:           awayNote,
+		})
+		if err != nil {
+			return fmt.Errorf("Run json.Marshal: %w", err)
+		}
+		body, err = doRequest(config, http.MethodPost, "/cmd/availabilities", bytes.NewBuffer(buf))
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	}
+
+	b, err := prettyPrint(body)
+	if err != nil {
+		return fmt.Errorf("Run prettyPrint: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// usage prints usage information for away to STDOUT.
+func (c *AwayCommand) usage() {
+	fmt.Println(`
+Mark yourself, or as a leader another member, as away for a range of days.
+Cooks who are away won't be assigned automatically and manual assignments are refused unless forced.
+Members who aren't eating are listed in the headcount sent to the cooks, and flagged if they RSVP anyway.
+
+Usage:
+
+		dinny away -from <YYYY-MM-DD> -to <YYYY-MM-DD> [-member <slackUID>] [-cannot-cook=false] [-not-eating=false] [-note <note>]
+		dinny away -list
+		dinny away -delete <id>
+
+Arguments:
+
+		-from <YYYY-MM-DD>
+			The first day away
+		-to <YYYY-MM-DD>
+			The last day away
+		-member <slackUID>
+			Mark another member as away (leaders only)
+		-cannot-cook
+			The member can't cook (default true)
+		-not-eating
+			The member isn't eating (default true)
+		-note <note>
+			The reason for being away
+		-list
+			List who is away within the next 30 days
+		-delete <id>
+			Delete an availability
+`[1:])
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// BalancesCommand is a command to list how much each member is owed or owes for groceries.
+type BalancesCommand struct {
+	ConfigPath string
+}
+
+// Run executes the balances command.
+func (c *BalancesCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	body, err := doRequest(config, http.MethodGet, "/cmd/balances", nil)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	var balances []*dinny.Balance
+	if err := json.Unmarshal(body, &balances); err != nil {
+		return fmt.Errorf("Run json.Unmarshal: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "MEMBER\tSLACK UID\tBALANCE")
+	for _, b := range balances {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", b.FullName, b.SlackUID, dinny.FormatCents(b.Cents))
+	}
+	return tw.Flush()
+}
+
+// usage prints usage information for balances to STDOUT.
+func (c *BalancesCommand) usage() {
+	fmt.Println(`
+List how much each member is owed for groceries. A negative balance is owed by the member.
+Settle up with 'dinny settle_up'.
+
+Usage:
+
+		dinny balances
+`[1:])
+}
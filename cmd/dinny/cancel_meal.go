@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var (
+	cancelMealDate   string
+	cancelMealReason string
+)
+
+// CancelMealCommand is a command to cancel a scheduled meal.
+type CancelMealCommand struct {
+	ConfigPath string
+}
+
+// Run executes the cancel_meal command.
+func (c *CancelMealCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&cancelMealDate, "date", "", "date of the meal to cancel <YYYY-MM-DD>")
+	fs.StringVar(&cancelMealReason, "reason", "", "reason shared with the channel")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	if cancelMealDate == "" {
+		return fmt.Errorf("Run: -date is required")
+	}
+	date, err := dinny.ParseDate(cancelMealDate)
+	if err != nil {
+		return fmt.Errorf("Run -date: %w", err)
+	}
+	buf, err := json.Marshal(rest.CancelMealRequest{Date: date, Reason: cancelMealReason})
+	if err != nil {
+		return fmt.Errorf("Run json.Marshal: %w", err)
+	}
+	_, err = doRequest(config, http.MethodPost, "/cmd/cancel-meal", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	fmt.Println("success")
+	return nil
+}
+
+// usage prints usage information for cancel_meal to STDOUT.
+func (c *CancelMealCommand) usage() {
+	fmt.Println(`
+Cancel a scheduled meal. Its eaters are uncredited and the channel is notified.
+
+Usage:
+
+		dinny cancel_meal -date <YYYY-MM-DD> [-reason <text>]
+
+Arguments:
+
+		-date <YYYY-MM-DD>
+			The date of the meal to cancel
+
+		-reason <text>
+			Why the meal is cancelled, shared with the channel
+`[1:])
+}
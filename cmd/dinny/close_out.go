@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var closeOutDate string
+
+// CloseOutCommand is a command to close out a meal as cooked and credit its cook.
+type CloseOutCommand struct {
+	ConfigPath string
+}
+
+// Run executes the close_out command.
+func (c *CloseOutCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&closeOutDate, "date", "", "date of the meal to close out <YYYY-MM-DD> (default yesterday)")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	var request rest.CloseOutRequest
+	if closeOutDate != "" {
+		request.Date, err = dinny.ParseDate(closeOutDate)
+		if err != nil {
+			return fmt.Errorf("Run -date: %w", err)
+		}
+	}
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("Run json.Marshal: %w", err)
+	}
+	_, err = doRequest(config, http.MethodPost, "/cmd/close-out", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	fmt.Println("success")
+	return nil
+}
+
+// usage prints usage information for close_out to STDOUT.
+func (c *CloseOutCommand) usage() {
+	fmt.Println(`
+Close out a meal as cooked, crediting its cooks with a meal cooked and freezing who ate.
+Closing out the same meal again does nothing.
+
+Usage:
+
+		dinny close_out [-date <YYYY-MM-DD>]
+
+Arguments:
+
+		-date <YYYY-MM-DD>
+			The date of the meal to close out (default yesterday)
+`[1:])
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var dietMember string
+var dietRestrictions string
+var dietAllergies string
+var dietList bool
+
+// DietCommand is a command to set the dietary restrictions and allergies of a member.
+type DietCommand struct {
+	ConfigPath string
+}
+
+// Run executes the diet command.
+func (c *DietCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&dietMember, "member", "", "set the dietary profile of another member <slackUID> (leaders only)")
+	fs.StringVar(&dietRestrictions, "restrictions", "", fmt.Sprintf("comma separated dietary restrictions (%s)", strings.Join(dinny.DietRestrictions, ", ")))
+	fs.StringVar(&dietAllergies, "allergies", "", "allergies which aren't covered by the restrictions")
+	fs.BoolVar(&dietList, "list", false, "list the dietary profiles of every member")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	if dietList {
+		body, err := doRequest(config, http.MethodGet, "/cmd/diets", nil)
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+		b, err := prettyPrint(body)
+		if err != nil {
+			return fmt.Errorf("Run prettyPrint: %w", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	restrictions, err := dinny.ParseDietRestrictions(dietRestrictions)
+	if err != nil {
+		return fmt.Errorf("Run -restrictions: %w", err)
+	}
+	buf, err := json.Marshal(rest.SetDietRequest{
+		MemberSlackUID: dietMember,
+		Restrictions:   restrictions,
+		Allergies:      dietAllergies,
+	})
+	if err != nil {
+		return fmt.Errorf("Run json.Marshal: %w", err)
+	}
+	_, err = doRequest(config, http.MethodPut, "/cmd/diet", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	fmt.Println("success")
+	return nil
+}
+
+// usage prints usage information for diet to STDOUT.
+func (c *DietCommand) usage() {
+	fmt.Printf(`
+Set your, or as a leader another member's, dietary restrictions and allergies. Replaces the previous profile.
+Cooks are messaged when eaters have restrictions their menu may not cover.
+
+Usage:
+
+		dinny diet [-member <slackUID>] [-restrictions <restriction,...>] [-allergies <text>]
+		dinny diet -list
+
+Arguments:
+
+		-member <slackUID>
+			Set the dietary profile of another member (leaders only)
+		-restrictions <restriction,...>
+			Comma separated dietary restrictions, any of %s
+		-allergies <text>
+			Allergies which aren't covered by the restrictions
+		-list
+			List the dietary profiles of every member
+`[1:], strings.Join(dinny.DietRestrictions, ", "))
+}
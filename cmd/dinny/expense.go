@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var expenseDate string
+var expenseAmount string
+var expenseMember string
+var expenseNote string
+var expenseList bool
+var expenseDelete int64
+
+// ExpenseCommand is a command to log the grocery cost of a meal, which is split across the meal's eaters.
+type ExpenseCommand struct {
+	ConfigPath string
+}
+
+// Run executes the expense command.
+func (c *ExpenseCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&expenseDate, "date", "", "date of the meal <YYYY-MM-DD>")
+	fs.StringVar(&expenseAmount, "amount", "", "amount paid, e.g. 23.50")
+	fs.StringVar(&expenseMember, "member", "", "log the expense of another member <slackUID> (leaders only)")
+	fs.StringVar(&expenseNote, "note", "", "what was bought")
+	fs.BoolVar(&expenseList, "list", false, "list the expenses logged for the meal")
+	fs.Int64Var(&expenseDelete, "delete", 0, "delete the expense with the given id")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	if expenseDelete > 0 {
+		_, err = doRequest(config, http.MethodDelete, fmt.Sprintf("/cmd/expenses/%d", expenseDelete), nil)
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+		fmt.Println("success")
+		return nil
+	}
+
+	date, err := dinny.ParseDate(expenseDate)
+	if err != nil {
+		return fmt.Errorf("Run -date: %w", err)
+	}
+
+	var body []byte
+	if expenseList {
+		body, err = doRequest(config, http.MethodGet, "/cmd/expenses?date="+url.QueryEscape(date.String()), nil)
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	} else {
+		cents, err := dinny.ParseCents(expenseAmount)
+		if err != nil {
+			return fmt.Errorf("Run -amount: %w", err)
+		}
+		buf, err := json.Marshal(rest.CreateExpenseRequest{
+			MemberSlackUID: expenseMember,
+			Date:           date,
+			AmountCents:    cents,
+			Note:           expenseNote,
+		})
+		if err != nil {
+			return fmt.Errorf("Run json.Marshal: %w", err)
+		}
+		body, err = doRequest(config, http.MethodPost, "/cmd/expenses", bytes.NewBuffer(buf))
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	}
+
+	b, err := prettyPrint(body)
+	if err != nil {
+		return fmt.Errorf("Run prettyPrint: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// usage prints usage information for expense to STDOUT.
+func (c *ExpenseCommand) usage() {
+	fmt.Println(`
+Log a grocery cost you, or as a leader another member, paid for a meal. Only the cooks of the meal and leaders may
+log its costs. The cost is split across the meal's eaters by portion, counting their guests, and shows up in 'dinny balances'.
+
+Usage:
+
+		dinny expense -date <YYYY-MM-DD> -amount <amount> [-member <slackUID>] [-note <note>]
+		dinny expense -date <YYYY-MM-DD> -list
+		dinny expense -delete <id>
+
+Arguments:
+
+		-date <YYYY-MM-DD>
+			The date of the meal
+		-amount <amount>
+			The amount paid, e.g. 23.50
+		-member <slackUID>
+			Log the expense of another member (leaders only)
+		-note <note>
+			What was bought
+		-list
+			List the expenses logged for the meal
+		-delete <id>
+			Delete an expense logged by mistake
+`[1:])
+}
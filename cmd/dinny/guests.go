@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var guestsDate string
+var guestsMember string
+var guestsCount int64
+var guestsList bool
+
+// GuestsCommand is a command to set the number of guests a member brings to a meal.
+type GuestsCommand struct {
+	ConfigPath string
+}
+
+// Run executes the guests command.
+func (c *GuestsCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&guestsDate, "date", "", "date of the meal <YYYY-MM-DD>")
+	fs.StringVar(&guestsMember, "member", "", "set the guests of another member <slackUID> (leaders only)")
+	fs.Int64Var(&guestsCount, "count", 0, fmt.Sprintf("number of guests, 0 to %d", dinny.MaxGuests))
+	fs.BoolVar(&guestsList, "list", false, "list the guests brought to the meal")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	date, err := dinny.ParseDate(guestsDate)
+	if err != nil {
+		return fmt.Errorf("Run -date: %w", err)
+	}
+
+	if guestsList {
+		body, err := doRequest(config, http.MethodGet, "/cmd/guests?date="+url.QueryEscape(date.String()), nil)
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+		b, err := prettyPrint(body)
+		if err != nil {
+			return fmt.Errorf("Run prettyPrint: %w", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	buf, err := json.Marshal(rest.SetGuestsRequest{
+		MemberSlackUID: guestsMember,
+		Date:           date,
+		Count:          guestsCount,
+	})
+	if err != nil {
+		return fmt.Errorf("Run json.Marshal: %w", err)
+	}
+	_, err = doRequest(config, http.MethodPut, "/cmd/guests", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	fmt.Println("success")
+	return nil
+}
+
+// usage prints usage information for guests to STDOUT.
+func (c *GuestsCommand) usage() {
+	fmt.Printf(`
+Set the number of guests you, or as a leader another member, bring to a meal. Replaces the previous number.
+Guests count towards the headcount but not towards the host's meals eaten.
+
+Usage:
+
+		dinny guests -date <YYYY-MM-DD> -count <n> [-member <slackUID>]
+		dinny guests -date <YYYY-MM-DD> -list
+
+Arguments:
+
+		-date <YYYY-MM-DD>
+			The date of the meal
+		-count <n>
+			The number of guests, 0 to %d
+		-member <slackUID>
+			Set the guests of another member (leaders only)
+		-list
+			List the guests brought to the meal
+`[1:], dinny.MaxGuests)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var headcountDate string
+
+// HeadcountCommand is a command to send the cook of a meal who is eating.
+type HeadcountCommand struct {
+	ConfigPath string
+}
+
+// Run executes the headcount command.
+func (c *HeadcountCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&headcountDate, "date", "", "date of the meal <YYYY-MM-DD> (default today)")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	var request rest.HeadcountRequest
+	if headcountDate != "" {
+		request.Date, err = dinny.ParseDate(headcountDate)
+		if err != nil {
+			return fmt.Errorf("Run -date: %w", err)
+		}
+	}
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("Run json.Marshal: %w", err)
+	}
+	_, err = doRequest(config, http.MethodPost, "/cmd/headcount", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	fmt.Println("success")
+	return nil
+}
+
+// usage prints usage information for headcount to STDOUT.
+func (c *HeadcountCommand) usage() {
+	fmt.Println(`
+Send the cook of a meal a direct message with who is eating. Later changes are sent to the cook as they happen.
+The headcount of a meal is only ever sent once.
+
+Usage:
+
+		dinny headcount [-date <YYYY-MM-DD>]
+
+Arguments:
+
+		-date <YYYY-MM-DD>
+			The date of the meal (default today)
+`[1:])
+}
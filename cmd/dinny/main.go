@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -41,12 +43,44 @@ func Run(ctx context.Context, args []string) error {
 		return flag.ErrHelp
 	case "assign_cooks":
 		return (&AssignCooksCommand{}).Run(ctx, args)
+	case "away":
+		return (&AwayCommand{}).Run(ctx, args)
+	case "balances":
+		return (&BalancesCommand{}).Run(ctx, args)
+	case "cancel_meal":
+		return (&CancelMealCommand{}).Run(ctx, args)
+	case "close_out":
+		return (&CloseOutCommand{}).Run(ctx, args)
+	case "diet":
+		return (&DietCommand{}).Run(ctx, args)
 	case "eating_tomorrow":
 		return (&EatingTomorrowCommand{}).Run(ctx, args)
+	case "guests":
+		return (&GuestsCommand{}).Run(ctx, args)
+	case "expense":
+		return (&ExpenseCommand{}).Run(ctx, args)
+	case "headcount":
+		return (&HeadcountCommand{}).Run(ctx, args)
 	case "members":
 		return (&MembersCommand{}).Run(ctx, args)
+	case "menu":
+		return (&MenuCommand{}).Run(ctx, args)
 	case "ping":
 		return (&PingCommand{}).Run(ctx, args)
+	case "rsvp_deadline":
+		return (&RSVPDeadlineCommand{}).Run(ctx, args)
+	case "schedule":
+		return (&ScheduleCommand{}).Run(ctx, args)
+	case "season":
+		return (&SeasonCommand{}).Run(ctx, args)
+	case "settle_up":
+		return (&SettleUpCommand{}).Run(ctx, args)
+	case "stats":
+		return (&StatsCommand{}).Run(ctx, args)
+	case "swap_cooks":
+		return (&SwapCooksCommand{}).Run(ctx, args)
+	case "token":
+		return (&TokenCommand{}).Run(ctx, args)
 	case "upcoming_cooks":
 		return (&UpcomingCooksCommand{}).Run(ctx, args)
 	case "weekly_update":
@@ -67,9 +101,25 @@ Usage:
 The commands are:
 
 		assign_cooks		assign cooks for the next week
+		away			mark a member as unable to cook or not eating for a range of days
+		balances		list how much each member is owed or owes for groceries
+		cancel_meal		cancel a scheduled meal and notify the channel
+		close_out		close out a meal as cooked and credit its cook
+		diet			set the dietary restrictions and allergies of a member
 		eating_tomorrow		send a 'who's eating tomorrow' message within slack
+		expense			log the grocery cost of a meal to split it across its eaters
+		guests			set the number of guests a member brings to a meal
+		headcount		send the cook a direct message with who is eating
 		members			list the current members of dinner rotation
+		menu			set the menu and dietary tags of a meal
 		ping			ping the dinny service to check health
+		rsvp_deadline		override when reactions to a meal's 'who's eating' message stop counting
+		schedule		list when the scheduled jobs last ran and will run next
+		season			close the season in progress and list past seasons
+		settle_up		record a payment from one member to another
+		stats			list each member's stats such as streaks and co-diners over a range of days
+		swap_cooks		swap the cooks of two scheduled meals
+		token			create, revoke, and list api tokens (leaders only)
 		upcoming_cooks		list the upcoming cooks for the next week
 		weekly_update		send a message into slack with each member's meals eaten to meals cooked ratio
 `[1:])
@@ -79,6 +129,9 @@ The commands are:
 type Config struct {
 	// URL represents the base url of the server.
 	URL string `toml:"url"`
+
+	// Token represents the api token used to authenticate against the server.
+	Token string `toml:"token"`
 }
 
 func DefaultConfig() Config {
@@ -125,3 +178,31 @@ func prettyPrint(b []byte) ([]byte, error) {
 	err := json.Indent(&out, b, "", "  ")
 	return out.Bytes(), err
 }
+
+// doRequest sends a request authenticated with the configured token to the server and returns the response body.
+// Returns an error if the server doesn't respond with a 2xx status.
+func doRequest(config Config, method string, path string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequest(method, config.URL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("doRequest http.NewRequest: %w", err)
+	}
+	if config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+config.Token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("doRequest http.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("doRequest io.ReadAll: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("doRequest %s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(b)))
+	}
+	return b, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 )
@@ -35,15 +34,9 @@ func (c *MembersCommand) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("Run ReadConfigFile: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/cmd/members", config.URL)
-	resp, err := http.Get(url)
+	body, err := doRequest(config, http.MethodGet, "/cmd/members", nil)
 	if err != nil {
-		return fmt.Errorf("Run http.Get: %w", err)
-	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("Run io.ReadAll: %w", err)
+		return fmt.Errorf("Run: %w", err)
 	}
 	b, err := prettyPrint(body)
 	if err != nil {
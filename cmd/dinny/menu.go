@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var (
+	menuDate        string
+	menuTitle       string
+	menuDescription string
+	menuTags        string
+)
+
+// MenuCommand is a command to set the menu of a meal.
+type MenuCommand struct {
+	ConfigPath string
+}
+
+// Run executes the menu command.
+func (c *MenuCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&menuDate, "date", "", "date of the meal <YYYY-MM-DD>")
+	fs.StringVar(&menuTitle, "title", "", "title of the menu")
+	fs.StringVar(&menuDescription, "description", "", "description of the menu")
+	fs.StringVar(&menuTags, "tags", "", fmt.Sprintf("comma separated dietary tags (%s)", strings.Join(dinny.MealTags, ", ")))
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	if menuDate == "" || menuTitle == "" {
+		return fmt.Errorf("Run: -date and -title are required")
+	}
+	request := rest.SetMenuRequest{Title: menuTitle, Description: menuDescription}
+	request.Date, err = dinny.ParseDate(menuDate)
+	if err != nil {
+		return fmt.Errorf("Run -date: %w", err)
+	}
+	request.Tags, err = dinny.ParseMealTags(menuTags)
+	if err != nil {
+		return fmt.Errorf("Run -tags: %w", err)
+	}
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("Run json.Marshal: %w", err)
+	}
+	_, err = doRequest(config, http.MethodPut, "/cmd/menu", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	fmt.Println("success")
+	return nil
+}
+
+// usage prints usage information for menu to STDOUT.
+func (c *MenuCommand) usage() {
+	fmt.Printf(`
+Set the menu of a meal. The menu is shown in the 'who's eating tomorrow' message.
+Only the cooks of the meal and leaders may set its menu.
+
+Usage:
+
+		dinny menu -date <YYYY-MM-DD> -title <title> [-description <text>] [-tags <tag,...>]
+
+Arguments:
+
+		-date <YYYY-MM-DD>
+			The date of the meal
+
+		-title <title>
+			The title of the menu
+
+		-description <text>
+			A description of the menu
+
+		-tags <tag,...>
+			Comma separated dietary tags, any of %s
+`[1:], strings.Join(dinny.MealTags, ", "))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var (
+	rsvpDeadlineDate string
+	rsvpDeadlineAt   string
+)
+
+// RSVPDeadlineCommand is a command to override the RSVP deadline of a meal.
+type RSVPDeadlineCommand struct {
+	ConfigPath string
+}
+
+// Run executes the rsvp_deadline command.
+func (c *RSVPDeadlineCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&rsvpDeadlineDate, "date", "", "date of the meal <YYYY-MM-DD>")
+	fs.StringVar(&rsvpDeadlineAt, "at", "", "local time after which reactions no longer count <YYYY-MM-DD HH:MM>")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	if rsvpDeadlineDate == "" || rsvpDeadlineAt == "" {
+		return fmt.Errorf("Run: -date and -at are required")
+	}
+	var request rest.SetRSVPDeadlineRequest
+	request.Date, err = dinny.ParseDate(rsvpDeadlineDate)
+	if err != nil {
+		return fmt.Errorf("Run -date: %w", err)
+	}
+	request.Deadline, err = time.ParseInLocation("2006-01-02 15:04", rsvpDeadlineAt, time.Local)
+	if err != nil {
+		return fmt.Errorf("Run -at: %w", err)
+	}
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("Run json.Marshal: %w", err)
+	}
+	_, err = doRequest(config, http.MethodPut, "/cmd/rsvp-deadline", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	fmt.Println("success")
+	return nil
+}
+
+// usage prints usage information for rsvp_deadline to STDOUT.
+func (c *RSVPDeadlineCommand) usage() {
+	fmt.Println(`
+Override the RSVP deadline of a meal, after which reactions to the 'who's eating' message no longer count.
+Only the cooks of the meal and leaders may set its RSVP deadline.
+
+Usage:
+
+		dinny rsvp_deadline -date <YYYY-MM-DD> -at <YYYY-MM-DD HH:MM>
+
+Arguments:
+
+		-date <YYYY-MM-DD>
+			The date of the meal
+
+		-at <YYYY-MM-DD HH:MM>
+			The local time after which reactions no longer count
+`[1:])
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// ScheduleCommand is a command to list when the scheduled jobs last ran and will run next.
+type ScheduleCommand struct {
+	ConfigPath string
+}
+
+// Run executes the schedule command.
+func (c *ScheduleCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	body, err := doRequest(config, http.MethodGet, "/cmd/schedule", nil)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	b, err := prettyPrint(body)
+	if err != nil {
+		return fmt.Errorf("Run prettyPrint: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// usage prints usage information for schedule to STDOUT.
+func (c *ScheduleCommand) usage() {
+	fmt.Println(`
+List when the scheduled jobs last ran and will run next.
+
+Usage:
+
+		dinny schedule
+`[1:])
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var seasonClose bool
+var seasonName string
+var seasonID int64
+
+// SeasonCommand is a command to close the season in progress and list the archived counters of past seasons.
+type SeasonCommand struct {
+	ConfigPath string
+}
+
+// Run executes the season command.
+func (c *SeasonCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.BoolVar(&seasonClose, "close", false, "close the season in progress (leaders only)")
+	fs.StringVar(&seasonName, "name", "", "name of the season to close")
+	fs.Int64Var(&seasonID, "id", 0, "list the members of the season with the given id")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	switch {
+	case seasonClose:
+		return c.close(config)
+	case seasonID != 0:
+		return c.show(config)
+	}
+
+	body, err := doRequest(config, http.MethodGet, "/cmd/seasons", nil)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	var seasons []*dinny.Season
+	if err := json.Unmarshal(body, &seasons); err != nil {
+		return fmt.Errorf("Run json.Unmarshal: %w", err)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tFROM\tTO")
+	for _, s := range seasons {
+		from := s.From.String()
+		if s.From == (dinny.Date{}) {
+			from = "-"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", s.ID, s.Name, from, s.To)
+	}
+	return tw.Flush()
+}
+
+// close closes the season in progress.
+func (c *SeasonCommand) close(config Config) error {
+	if seasonName == "" {
+		return fmt.Errorf("close: -name is required")
+	}
+	req := rest.CloseSeasonRequest{Name: seasonName}
+	buf, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("close json.Marshal: %w", err)
+	}
+	body, err := doRequest(config, http.MethodPost, "/cmd/seasons", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	var season dinny.Season
+	if err := json.Unmarshal(body, &season); err != nil {
+		return fmt.Errorf("close json.Unmarshal: %w", err)
+	}
+	fmt.Printf("closed season %d %q ending %s\n", season.ID, season.Name, season.To)
+	return nil
+}
+
+// show lists the archived counters of the members of a season.
+func (c *SeasonCommand) show(config Config) error {
+	body, err := doRequest(config, http.MethodGet, "/cmd/seasons/"+strconv.FormatInt(seasonID, 10), nil)
+	if err != nil {
+		return fmt.Errorf("show: %w", err)
+	}
+	var season dinny.Season
+	if err := json.Unmarshal(body, &season); err != nil {
+		return fmt.Errorf("show json.Unmarshal: %w", err)
+	}
+	fmt.Printf("%s, ending %s\n\n", season.Name, season.To)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "MEMBER\tSLACK UID\tEATEN\tCOOKED\tCREDITS\tRATIO")
+	for _, m := range season.Members {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%g\t%.2f\t%s\n", m.FullName, m.SlackUID, m.MealsEaten, m.MealsCooked, m.CookingCredits, formatRatio(m.Ratio()))
+	}
+	return tw.Flush()
+}
+
+// usage prints usage information for season to STDOUT.
+func (c *SeasonCommand) usage() {
+	fmt.Println(`
+List the closed seasons, list the counters members ended a season with, or close the season in progress.
+Closing a season archives the meals eaten, meals cooked and cooking credits of every member and resets them,
+so that ratios reflect the meals of the new season. The season in progress ends today.
+
+Usage:
+
+		dinny season
+		dinny season -id <int>
+		dinny season -close -name <name>
+
+Arguments:
+
+		-id <int>
+			List the members of the season with their counters at its end
+		-close
+			Close the season in progress (leaders only)
+		-name <name>
+			Name of the season to close, e.g. "Spring 2023"
+`[1:])
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var settleUpFrom string
+var settleUpTo string
+var settleUpAmount string
+var settleUpNote string
+
+// SettleUpCommand is a command to record a payment from one member to another.
+type SettleUpCommand struct {
+	ConfigPath string
+}
+
+// Run executes the settle_up command.
+func (c *SettleUpCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&settleUpTo, "to", "", "member who was paid <slackUID>")
+	fs.StringVar(&settleUpAmount, "amount", "", "amount paid, e.g. 23.50")
+	fs.StringVar(&settleUpFrom, "from", "", "record the payment of another member <slackUID> (leaders only)")
+	fs.StringVar(&settleUpNote, "note", "", "note about the payment")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if settleUpTo == "" {
+		c.usage()
+		return flag.ErrHelp
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	cents, err := dinny.ParseCents(settleUpAmount)
+	if err != nil {
+		return fmt.Errorf("Run -amount: %w", err)
+	}
+	buf, err := json.Marshal(rest.CreatePaymentRequest{
+		FromSlackUID: settleUpFrom,
+		ToSlackUID:   settleUpTo,
+		AmountCents:  cents,
+		Note:         settleUpNote,
+	})
+	if err != nil {
+		return fmt.Errorf("Run json.Marshal: %w", err)
+	}
+	_, err = doRequest(config, http.MethodPost, "/cmd/payments", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	fmt.Println("success")
+	return nil
+}
+
+// usage prints usage information for settle_up to STDOUT.
+func (c *SettleUpCommand) usage() {
+	fmt.Println(`
+Record that you, or as a leader another member, paid a member to settle up grocery balances.
+
+Usage:
+
+		dinny settle_up -to <slackUID> -amount <amount> [-from <slackUID>] [-note <note>]
+
+Arguments:
+
+		-to <slackUID>
+			The member who was paid
+		-amount <amount>
+			The amount paid, e.g. 23.50
+		-from <slackUID>
+			Record the payment of another member (leaders only)
+		-note <note>
+			A note about the payment
+`[1:])
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+var statsFrom string
+var statsTo string
+var statsSeason string
+
+// StatsCommand is a command to list per-member stats over a range of days.
+type StatsCommand struct {
+	ConfigPath string
+}
+
+// Run executes the stats command.
+func (c *StatsCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&statsFrom, "from", "", "first day of the stats <YYYY-MM-DD>")
+	fs.StringVar(&statsTo, "to", "", "last day of the stats <YYYY-MM-DD>")
+	fs.StringVar(&statsSeason, "season", "", "start the stats with the current season or all-time <current|all-time>")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	query := url.Values{}
+	for name, value := range map[string]string{"from": statsFrom, "to": statsTo} {
+		if value == "" {
+			continue
+		}
+		date, err := dinny.ParseDate(value)
+		if err != nil {
+			return fmt.Errorf("Run -%s: %w", name, err)
+		}
+		query.Set(name, date.String())
+	}
+	if statsSeason != "" {
+		query.Set("season", statsSeason)
+	}
+	path := "/stats"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	body, err := doRequest(config, http.MethodGet, path, nil)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	var stats dinny.Stats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return fmt.Errorf("Run json.Unmarshal: %w", err)
+	}
+
+	if stats.From == (dinny.Date{}) {
+		fmt.Printf("%d meals cooked up to %s\n\n", stats.Meals, stats.To)
+	} else {
+		fmt.Printf("%d meals cooked from %s to %s\n\n", stats.Meals, stats.From, stats.To)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "MEMBER\tSLACK UID\tEATEN\tCOOKED\tRATIO\tTREND\tSTREAK\tLONGEST\tAVG HEADCOUNT\tCO-DINERS")
+	for _, m := range stats.Members {
+		var coDiners []string
+		for _, d := range m.CoDiners {
+			coDiners = append(coDiners, fmt.Sprintf("%s (%d)", d.SlackUID, d.Meals))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%g\t%s\t%s\t%d\t%d\t%.1f\t%s\n", m.FullName, m.SlackUID, m.MealsEaten, m.MealsCooked, formatRatio(m.Ratio),
+			formatTrend(m.RatioTrend), m.CurrentStreak, m.LongestStreak, m.AverageHeadcount, strings.Join(coDiners, ", "))
+	}
+	return tw.Flush()
+}
+
+// formatRatio formats a meals eaten to meals cooked ratio with two decimals, or "inf" if the member ate without cooking.
+func formatRatio(ratio float32) string {
+	if ratio == math.MaxFloat32 {
+		return "inf"
+	}
+	return fmt.Sprintf("%.2f", ratio)
+}
+
+// formatTrend formats how the ratio moved from the first to the last week, e.g. "1.50 -> 2.00".
+func formatTrend(trend []dinny.RatioPoint) string {
+	if len(trend) == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s -> %s", formatRatio(trend[0].Ratio), formatRatio(trend[len(trend)-1].Ratio))
+}
+
+// usage prints usage information for stats to STDOUT.
+func (c *StatsCommand) usage() {
+	fmt.Println(`
+List the stats of each member over the meals cooked between two days, the last four weeks by default:
+meals eaten and cooked, the ratio and how it moved from the first to the last week, the current and longest
+streaks of meals eaten in a row, the average headcount of the meals cooked and the most frequent co-diners.
+
+Usage:
+
+		dinny stats [-from <YYYY-MM-DD>] [-to <YYYY-MM-DD>]
+		dinny stats -season <current|all-time> [-to <YYYY-MM-DD>]
+
+Arguments:
+
+		-from <YYYY-MM-DD>
+			First day of the stats
+		-to <YYYY-MM-DD>
+			Last day of the stats, today by default
+		-season <current|all-time>
+			Start the stats with the current season, or with the first meal for all-time stats
+`[1:])
+}
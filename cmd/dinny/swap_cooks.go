@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+var (
+	swapCooksFirst  string
+	swapCooksSecond string
+)
+
+// SwapCooksCommand is a command to swap the cooks of two scheduled meals.
+type SwapCooksCommand struct {
+	ConfigPath string
+}
+
+// Run executes the swap_cooks command.
+func (c *SwapCooksCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&swapCooksFirst, "first", "", "date of the first meal <YYYY-MM-DD>")
+	fs.StringVar(&swapCooksSecond, "second", "", "date of the second meal <YYYY-MM-DD>")
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	if swapCooksFirst == "" || swapCooksSecond == "" {
+		return fmt.Errorf("Run: -first and -second are required")
+	}
+	var request rest.SwapCooksRequest
+	request.First, err = dinny.ParseDate(swapCooksFirst)
+	if err != nil {
+		return fmt.Errorf("Run -first: %w", err)
+	}
+	request.Second, err = dinny.ParseDate(swapCooksSecond)
+	if err != nil {
+		return fmt.Errorf("Run -second: %w", err)
+	}
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("Run json.Marshal: %w", err)
+	}
+	_, err = doRequest(config, http.MethodPost, "/cmd/swap-cooks", bytes.NewBuffer(buf))
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	fmt.Println("success")
+	return nil
+}
+
+// usage prints usage information for swap_cooks to STDOUT.
+func (c *SwapCooksCommand) usage() {
+	fmt.Println(`
+Swap the cooks of two scheduled meals. The channel is notified.
+
+Usage:
+
+		dinny swap_cooks -first <YYYY-MM-DD> -second <YYYY-MM-DD>
+
+Arguments:
+
+		-first <YYYY-MM-DD>
+			The date of the first meal
+
+		-second <YYYY-MM-DD>
+			The date of the second meal
+`[1:])
+}
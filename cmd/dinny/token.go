@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	rest "github.com/ddritzenhoff/dinny/http"
+)
+
+// TokenCommand is a command to create, revoke, and list api tokens.
+type TokenCommand struct {
+	ConfigPath string
+}
+
+// Run executes the token command.
+func (c *TokenCommand) Run(ctx context.Context, args []string) error {
+	var sub string
+	if len(args) > 0 {
+		sub, args = args[0], args[1:]
+	}
+
+	var memberSlackUID, name, scope string
+	var id int64
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	switch sub {
+	case "create":
+		fs.StringVar(&memberSlackUID, "member", "", "slack UID of the token's owner")
+		fs.StringVar(&name, "name", "", "name describing the token")
+		fs.StringVar(&scope, "scope", dinny.TokenScopeRead, "scope of the token (read or write)")
+	case "revoke":
+		fs.Int64Var(&id, "id", 0, "id of the token to revoke")
+	case "list":
+	default:
+		c.usage()
+		return flag.ErrHelp
+	}
+	attachConfigFlags(fs, &c.ConfigPath)
+	fs.Usage = c.usage
+	err := fs.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(1)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the configuration.
+	config, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run ReadConfigFile: %w", err)
+	}
+
+	var body []byte
+	switch sub {
+	case "create":
+		if memberSlackUID == "" {
+			return fmt.Errorf("Run: -member flag is required")
+		}
+		buf, err := json.Marshal(rest.CreateTokenRequest{
+			MemberSlackUID: memberSlackUID,
+			Name:           name,
+			Scope:          scope,
+		})
+		if err != nil {
+			return fmt.Errorf("Run json.Marshal: %w", err)
+		}
+		body, err = doRequest(config, http.MethodPost, "/cmd/tokens", bytes.NewBuffer(buf))
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	case "revoke":
+		if id < 1 {
+			return fmt.Errorf("Run: -id flag must a value above 0")
+		}
+		_, err = doRequest(config, http.MethodDelete, fmt.Sprintf("/cmd/tokens/%d", id), nil)
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+		fmt.Println("success")
+		return nil
+	case "list":
+		body, err = doRequest(config, http.MethodGet, "/cmd/tokens", nil)
+		if err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	}
+
+	b, err := prettyPrint(body)
+	if err != nil {
+		return fmt.Errorf("Run prettyPrint: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// usage prints usage information for token to STDOUT.
+func (c *TokenCommand) usage() {
+	fmt.Println(`
+Create, revoke, and list api tokens. Only leaders may manage tokens.
+The secret of a new token is only printed once.
+
+Usage:
+
+		dinny token create -member <slackUID> -name <name> -scope <read|write>
+		dinny token revoke -id <id>
+		dinny token list
+
+Arguments:
+
+		-member <slackUID>
+			The member who owns the token
+		-name <name>
+			A name describing the token
+		-scope <read|write>
+			Read tokens may only list information, write tokens may also change it
+		-id <int64>
+			The id of the token to revoke
+`[1:])
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 )
@@ -42,15 +41,10 @@ func (c *UpcomingCooksCommand) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("Run ReadConfigFile: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/cmd/upcoming-cooks", config.URL)
-	resp, err := http.Get(url)
+	path := fmt.Sprintf("/cmd/upcoming-cooks?daysWanted=%d", daysWanted)
+	body, err := doRequest(config, http.MethodGet, path, nil)
 	if err != nil {
-		return fmt.Errorf("Run http.Get: %w", err)
-	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("Run io.ReadAll: %w", err)
+		return fmt.Errorf("Run: %w", err)
 	}
 	b, err := prettyPrint(body)
 	if err != nil {
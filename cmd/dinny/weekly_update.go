@@ -34,12 +34,10 @@ func (c *WeeklyUpdateCommand) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("Run ReadConfigFile: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/cmd/weekly-update", config.URL)
-	resp, err := http.Get(url)
+	_, err = doRequest(config, http.MethodGet, "/cmd/weekly-update", nil)
 	if err != nil {
-		return fmt.Errorf("Run http.Get: %w", err)
+		return fmt.Errorf("Run: %w", err)
 	}
-	defer resp.Body.Close()
 	fmt.Println("success")
 	return nil
 }
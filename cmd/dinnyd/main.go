@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	_ "embed"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -11,18 +13,34 @@ import (
 	"os/user"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pelletier/go-toml/v2"
 
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/cron"
 	rest "github.com/ddritzenhoff/dinny/http"
 	"github.com/ddritzenhoff/dinny/slack"
 	"github.com/ddritzenhoff/dinny/sqlite"
 	"github.com/ddritzenhoff/dinny/sqlite/gen"
 	_ "github.com/mattn/go-sqlite3"
+	slackgo "github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
 )
 
 func main() {
 
+	// Run a maintenance subcommand instead of the server if requested.
+	if len(os.Args) > 1 && isCommand(os.Args[1]) {
+		if err := RunCommand(context.Background(), os.Args[1], os.Args[2:]); err == flag.ErrHelp {
+			os.Exit(1)
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Setup signal handlers.
 	ctx, cancel := context.WithCancel(context.Background())
 	c := make(chan os.Signal, 1)
@@ -41,7 +59,7 @@ func main() {
 	}
 
 	// Execute program.
-	if err := Run(config); err != nil {
+	if err := Run(ctx, config); err != nil {
 		log.Fatal(err)
 	}
 
@@ -52,6 +70,25 @@ func main() {
 	// TODO (ddritzenhoff)
 }
 
+// isCommand reports whether name is a maintenance subcommand of dinnyd.
+func isCommand(name string) bool {
+	return name == "migrate" || name == "token" || name == "reconcile"
+}
+
+// RunCommand executes a maintenance subcommand against the configured database.
+func RunCommand(ctx context.Context, name string, args []string) error {
+	switch name {
+	case "migrate":
+		return (&MigrateCommand{}).Run(ctx, args)
+	case "token":
+		return (&TokenCommand{}).Run(ctx, args)
+	case "reconcile":
+		return (&ReconcileCommand{}).Run(ctx, args)
+	default:
+		return fmt.Errorf("dinnyd %s: unknown command", name)
+	}
+}
+
 // ParseFlag parses the config flag and loads the config.
 func ParseFlag(context context.Context, args []string) (*Config, error) {
 	fs := flag.NewFlagSet("dinnyd", flag.ContinueOnError)
@@ -76,8 +113,8 @@ func ParseFlag(context context.Context, args []string) (*Config, error) {
 	return &config, nil
 }
 
-// run initializes the member, meal, and Slack services and starts the REST and GRPC servers.
-func Run(config *Config) error {
+// Run initializes the member, meal, and Slack services and starts the REST server and the scheduler.
+func Run(ctx context.Context, config *Config) error {
 	logger := log.New(os.Stdout, "DEBUG: ", log.LstdFlags)
 
 	DSNPath, err := expandDSN(config.DB.DSN)
@@ -92,26 +129,256 @@ func Run(config *Config) error {
 
 	queries := gen.New(db)
 
-	mealService := sqlite.NewMealService(queries, db)
+	processedEventService := sqlite.NewProcessedEventService(queries, db)
 
-	memberService := sqlite.NewMemberService(queries, db)
+	location, err := configLocation(config)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
 
 	slackConfig := slack.Config{
-		Channel:       config.Slack.ChannelID,
-		BotSigningKey: config.Slack.BotSigningKey,
+		Channel:         config.Slack.ChannelID,
+		BotSigningKey:   config.Slack.BotSigningKey,
+		RSVPReactions:   config.Slack.RSVPReactions,
+		GuestReactions:  config.Slack.GuestReactions,
+		Location:        location,
+		WeightedRanking: config.Credits.WeightedRanking,
+		AllTimeRanking:  config.Seasons.AllTimeRanking,
+	}
+	slackConfig.RSVPDeadline, err = parseRSVPDeadline(config.Slack.RSVPDeadline)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+
+	creditFormula := dinny.CreditFormula{Name: config.Credits.Formula, Baseline: config.Credits.Baseline}
+	if err := creditFormula.Validate(); err != nil {
+		return fmt.Errorf("Run credits: %w", err)
+	}
+
+	rotationIDs, err := syncRotations(sqlite.NewRotationService(queries, db), config)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+
+	defaultRotation, err := newRotation(queries, db, dinny.DefaultRotationID, slackConfig, creditFormula)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+
+	restServer := rest.NewServer(logger, config.HTTP.URL, defaultRotation.MemberService, defaultRotation.MealService, defaultRotation.SlackService)
+	restServer.AttendanceService = defaultRotation.AttendanceService
+	restServer.AvailabilityService = defaultRotation.AvailabilityService
+	restServer.DietaryProfileService = defaultRotation.DietaryProfileService
+	restServer.ExpenseService = defaultRotation.ExpenseService
+	restServer.StatsService = defaultRotation.StatsService
+	restServer.SeasonService = defaultRotation.SeasonService
+	restServer.TokenService = defaultRotation.TokenService
+	restServer.ProcessedEventService = processedEventService
+	restServer.SigningSecret = config.Slack.SigningSecret
+	restServer.Location = location
+	if config.Slack.ReplayWindow != "" {
+		restServer.SlackReplayWindow, err = time.ParseDuration(config.Slack.ReplayWindow)
+		if err != nil {
+			return fmt.Errorf("Run time.ParseDuration: %w", err)
+		}
 	}
 
-	slackService, err := slack.NewService(&slackConfig, mealService, memberService)
+	scheduler, err := newScheduler(logger, config, location, sqlite.NewJobRunService(queries, db), defaultRotation.SlackService, processedEventService)
 	if err != nil {
-		return fmt.Errorf("Run slack.NewService: %w", err)
+		return fmt.Errorf("Run newScheduler: %w", err)
 	}
 
-	restServer := rest.NewServer(logger, config.HTTP.URL, memberService, mealService, slackService)
-	restServer.Open()
+	for ii, rc := range config.Rotations {
+		rotationConfig := slackConfig
+		rotationConfig.Channel = rc.ChannelID
+		if rc.RSVPDeadline != "" {
+			rotationConfig.RSVPDeadline, err = parseRSVPDeadline(rc.RSVPDeadline)
+			if err != nil {
+				return fmt.Errorf("Run rotation %s: %w", rc.Name, err)
+			}
+		}
+		rotation, err := newRotation(queries, db, rotationIDs[ii], rotationConfig, creditFormula)
+		if err != nil {
+			return fmt.Errorf("Run rotation %s: %w", rc.Name, err)
+		}
+		rotation.Name = rc.Name
+		restServer.Rotations = append(restServer.Rotations, rotation)
+		if err := addJobs(scheduler, rc.Name+"/", rc.Schedule, location, rotation.SlackService); err != nil {
+			return fmt.Errorf("Run rotation %s: %w", rc.Name, err)
+		}
+	}
+
+	scheduler.Start(ctx)
+	restServer.Scheduler = scheduler
+
+	if err := restServer.Open(); err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+
+	switch config.Slack.Mode {
+	case "", "http":
+	case "socket":
+		if config.Slack.AppToken == "" {
+			return fmt.Errorf("Run: socket mode requires slack.appToken")
+		}
+		client := socketmode.New(slackgo.New(config.Slack.BotSigningKey, slackgo.OptionAppLevelToken(config.Slack.AppToken)))
+		restServer.OpenSocketMode(ctx, client)
+	default:
+		return fmt.Errorf("Run: unknown slack.mode %q", config.Slack.Mode)
+	}
 
 	return nil
 }
 
+// configLocation returns the location configured in the [schedule] section. Defaults to time.Local.
+func configLocation(config *Config) (*time.Location, error) {
+	if config.Schedule.Timezone == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(config.Schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("configLocation time.LoadLocation: %w", err)
+	}
+	return location, nil
+}
+
+// parseRSVPDeadline parses an "HH:MM" RSVP deadline into the time after midnight. An empty deadline yields the default.
+func parseRSVPDeadline(deadline string) (time.Duration, error) {
+	if deadline == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", deadline)
+	if err != nil {
+		return 0, fmt.Errorf("parseRSVPDeadline: %w", err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// syncRotations makes the database's rotations match the configured ones, creating the missing rotations
+// and updating their Slack channels. Returns the ID of each configured rotation in order.
+func syncRotations(rotationService dinny.RotationService, config *Config) ([]int64, error) {
+	channel := config.Slack.ChannelID
+	if err := rotationService.UpdateRotation(dinny.DefaultRotationID, dinny.RotationUpdate{SlackChannel: &channel}); err != nil {
+		return nil, fmt.Errorf("syncRotations: %w", err)
+	}
+
+	var ids []int64
+	seen := make(map[string]bool)
+	for _, rc := range config.Rotations {
+		if rc.Name == "" || seen[rc.Name] {
+			return nil, fmt.Errorf("syncRotations: rotations need a unique name, got %q", rc.Name)
+		}
+		seen[rc.Name] = true
+
+		r, err := rotationService.FindRotationByName(rc.Name)
+		if errors.Is(err, dinny.ErrNotFound) {
+			r = &dinny.Rotation{Name: rc.Name, SlackChannel: rc.ChannelID}
+			if err := rotationService.CreateRotation(r); err != nil {
+				return nil, fmt.Errorf("syncRotations: %w", err)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("syncRotations: %w", err)
+		} else if r.ID == dinny.DefaultRotationID {
+			return nil, fmt.Errorf("syncRotations: rotation %q is the default rotation configured by the [slack] section", rc.Name)
+		}
+		channel := rc.ChannelID
+		if err := rotationService.UpdateRotation(r.ID, dinny.RotationUpdate{SlackChannel: &channel}); err != nil {
+			return nil, fmt.Errorf("syncRotations: %w", err)
+		}
+		ids = append(ids, r.ID)
+	}
+	return ids, nil
+}
+
+// newRotation creates the services of the rotation with the given ID, posting to the channel of slackConfig.
+func newRotation(queries *gen.Queries, db *sql.DB, rotationID int64, slackConfig slack.Config, creditFormula dinny.CreditFormula) (*rest.Rotation, error) {
+	mealService := sqlite.NewMealService(queries, db).ForRotation(rotationID)
+	mealService.CreditFormula = creditFormula
+	memberService := sqlite.NewMemberService(queries, db).ForRotation(rotationID)
+	attendanceService := sqlite.NewAttendanceService(queries, db).ForRotation(rotationID)
+	availabilityService := sqlite.NewAvailabilityService(queries, db).ForRotation(rotationID)
+	dietService := sqlite.NewDietaryProfileService(queries, db).ForRotation(rotationID)
+	seasonService := sqlite.NewSeasonService(queries, db).ForRotation(rotationID)
+
+	slackService, err := slack.NewService(&slackConfig, mealService, memberService, attendanceService, availabilityService, dietService, seasonService)
+	if err != nil {
+		return nil, fmt.Errorf("newRotation slack.NewService: %w", err)
+	}
+
+	return &rest.Rotation{
+		Channel:               slackConfig.Channel,
+		MemberService:         memberService,
+		MealService:           mealService,
+		SlackService:          slackService,
+		AvailabilityService:   availabilityService,
+		AttendanceService:     attendanceService,
+		DietaryProfileService: dietService,
+		ExpenseService:        sqlite.NewExpenseService(queries, db).ForRotation(rotationID),
+		StatsService:          sqlite.NewStatsService(queries, db).ForRotation(rotationID),
+		SeasonService:         seasonService,
+		TokenService:          sqlite.NewTokenService(queries, db).ForRotation(rotationID),
+	}, nil
+}
+
+// newScheduler creates a scheduler with the jobs configured in the [schedule] section. Jobs without a spec are not scheduled.
+func newScheduler(logger *log.Logger, config *Config, location *time.Location, jobRunService dinny.JobRunService, slackService slack.Service, processedEventService dinny.ProcessedEventService) (*cron.Scheduler, error) {
+	scheduler := cron.NewScheduler(logger, location, jobRunService)
+	if err := addJobs(scheduler, "", config.Schedule.JobsConfig, location, slackService); err != nil {
+		return nil, fmt.Errorf("newScheduler: %w", err)
+	}
+	if config.Schedule.PruneEvents != "" {
+		retention := dinny.DefaultProcessedEventRetention
+		if config.Slack.EventRetention != "" {
+			var err error
+			retention, err = time.ParseDuration(config.Slack.EventRetention)
+			if err != nil {
+				return nil, fmt.Errorf("newScheduler eventRetention: %w", err)
+			}
+		}
+		pruneEvents := func() error {
+			_, err := processedEventService.DeleteProcessedEventsBefore(time.Now().Add(-retention))
+			return err
+		}
+		if err := scheduler.Add("prune-events", config.Schedule.PruneEvents, pruneEvents); err != nil {
+			return nil, fmt.Errorf("newScheduler: %w", err)
+		}
+	}
+	return scheduler, nil
+}
+
+// addJobs adds the jobs of a rotation to the scheduler, prefixing their names with prefix. Jobs without a spec are not scheduled.
+func addJobs(scheduler *cron.Scheduler, prefix string, jobs JobsConfig, location *time.Location, slackService slack.Service) error {
+	if jobs.EatingTomorrow != "" {
+		if err := scheduler.Add(prefix+"eating-tomorrow", jobs.EatingTomorrow, slackService.PostEatingTomorrow); err != nil {
+			return fmt.Errorf("addJobs: %w", err)
+		}
+	}
+	if jobs.CloseOut != "" {
+		if err := scheduler.Add(prefix+"close-out", jobs.CloseOut, slackService.CloseOutPastMeals); err != nil {
+			return fmt.Errorf("addJobs: %w", err)
+		}
+	}
+	if jobs.Headcount != "" {
+		headcountToday := func() error {
+			return slackService.SendHeadcount(dinny.DateOf(time.Now().In(location)))
+		}
+		if err := scheduler.Add(prefix+"headcount", jobs.Headcount, headcountToday); err != nil {
+			return fmt.Errorf("addJobs: %w", err)
+		}
+	}
+	if jobs.CloseRSVPs != "" {
+		if err := scheduler.Add(prefix+"close-rsvps", jobs.CloseRSVPs, slackService.CloseRSVPs); err != nil {
+			return fmt.Errorf("addJobs: %w", err)
+		}
+	}
+	if jobs.WeeklyUpdate != "" {
+		if err := scheduler.Add(prefix+"weekly-update", jobs.WeeklyUpdate, slackService.WeeklyUpdate); err != nil {
+			return fmt.Errorf("addJobs: %w", err)
+		}
+	}
+	return nil
+}
+
 const (
 	// DefaultConfigPath is the the default path to the application configuration.
 	DefaultConfigPath = "~/dinnyd.toml"
@@ -131,13 +398,56 @@ type Config struct {
 	} `toml:"http"`
 
 	Slack struct {
-		BotSigningKey string `toml:"botSigningKey"`
-		AppID         string `toml:"appID"`
-		ClientID      string `toml:"clientID"`
-		ClientSecret  string `toml:"clientSecret"`
-		SigningSecret string `toml:"signingSecret"`
-		ChannelID     string `toml:"channelID"`
+		Mode           string   `toml:"mode"`
+		AppToken       string   `toml:"appToken"`
+		BotSigningKey  string   `toml:"botSigningKey"`
+		AppID          string   `toml:"appID"`
+		ClientID       string   `toml:"clientID"`
+		ClientSecret   string   `toml:"clientSecret"`
+		SigningSecret  string   `toml:"signingSecret"`
+		ChannelID      string   `toml:"channelID"`
+		ReplayWindow   string   `toml:"replayWindow"`
+		RSVPDeadline   string   `toml:"rsvpDeadline"`
+		RSVPReactions  []string `toml:"rsvpReactions"`
+		GuestReactions []string `toml:"guestReactions"`
+		EventRetention string   `toml:"eventRetention"`
 	} `toml:"slack"`
+
+	Schedule struct {
+		Timezone string `toml:"timezone"`
+		JobsConfig
+		PruneEvents string `toml:"pruneEvents"`
+	} `toml:"schedule"`
+
+	Credits struct {
+		Formula         string  `toml:"formula"`
+		Baseline        float64 `toml:"baseline"`
+		WeightedRanking bool    `toml:"weightedRanking"`
+	} `toml:"credits"`
+
+	Seasons struct {
+		AllTimeRanking bool `toml:"allTimeRanking"`
+	} `toml:"seasons"`
+
+	// Rotations are the dinner rotations run besides the default one configured by the [slack] and [schedule] sections.
+	Rotations []RotationConfig `toml:"rotations"`
+}
+
+// JobsConfig represents the schedules of a rotation's jobs.
+type JobsConfig struct {
+	EatingTomorrow string `toml:"eatingTomorrow"`
+	WeeklyUpdate   string `toml:"weeklyUpdate"`
+	CloseOut       string `toml:"closeOut"`
+	Headcount      string `toml:"headcount"`
+	CloseRSVPs     string `toml:"closeRSVPs"`
+}
+
+// RotationConfig represents an additional dinner rotation with its own channel, members and meals.
+type RotationConfig struct {
+	Name         string     `toml:"name"`
+	ChannelID    string     `toml:"channelID"`
+	RSVPDeadline string     `toml:"rsvpDeadline"`
+	Schedule     JobsConfig `toml:"schedule"`
 }
 
 // DefaultConfig returns a new instance of Config with defaults set.
@@ -182,6 +492,23 @@ func expand(path string) (string, error) {
 	return filepath.Join(u.HomeDir, strings.TrimPrefix(path, "~"+string(os.PathSeparator))), nil
 }
 
+// configDSN reads the config file at configPath and returns its expanded datasource name.
+func configDSN(configPath string) (string, error) {
+	configPath, err := expand(configPath)
+	if err != nil {
+		return "", fmt.Errorf("configDSN expand: %w", err)
+	}
+	config, err := ReadConfigFile(configPath)
+	if err != nil {
+		return "", fmt.Errorf("configDSN ReadConfigFile: %w", err)
+	}
+	DSNPath, err := expandDSN(config.DB.DSN)
+	if err != nil {
+		return "", fmt.Errorf("configDSN expandDSN: %w", err)
+	}
+	return DSNPath, nil
+}
+
 // expandDSN expands a datasource name. Ignores in-memory databases.
 func expandDSN(dsn string) (string, error) {
 	if dsn == ":memory:" {
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/ddritzenhoff/dinny/sqlite"
+)
+
+// MigrateCommand is a command to inspect and evolve the database schema.
+type MigrateCommand struct {
+	ConfigPath string
+}
+
+// Run executes the migrate command.
+func (c *MigrateCommand) Run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("dinnyd migrate", flag.ContinueOnError)
+	fs.StringVar(&c.ConfigPath, "config", DefaultConfigPath, "config path")
+	fs.Usage = c.usage
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var mode string
+	args = fs.Args()
+	if len(args) > 0 {
+		mode, args = args[0], args[1:]
+	}
+
+	DSNPath, err := configDSN(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+
+	db, err := sqlite.Connect(DSNPath)
+	if err != nil {
+		return fmt.Errorf("Run sqlite.Connect: %w", err)
+	}
+	defer db.Close()
+
+	switch mode {
+	case "status":
+	case "up":
+		if err := sqlite.MigrateUp(db); err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	case "down-to":
+		if len(args) != 1 {
+			c.usage()
+			return flag.ErrHelp
+		}
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("Run strconv.ParseInt: %w", err)
+		}
+		if err := sqlite.MigrateDownTo(db, version); err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	default:
+		c.usage()
+		return flag.ErrHelp
+	}
+
+	statuses, err := sqlite.MigrateStatus(db)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	for _, status := range statuses {
+		applied := "pending"
+		if status.Applied {
+			applied = "applied " + status.AppliedAt
+		}
+		fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, applied)
+	}
+	return nil
+}
+
+// usage prints usage information for migrate to STDOUT.
+func (c *MigrateCommand) usage() {
+	fmt.Println(`
+Inspect and evolve the database schema. Every mode prints the resulting migration status.
+
+Usage:
+
+		dinnyd migrate [-config <path>] <mode>
+
+The modes are:
+
+		status			list the migrations and whether they have been applied
+		up			apply every pending migration
+		down-to <version>	revert every applied migration above <version>
+`[1:])
+}
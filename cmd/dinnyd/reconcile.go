@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ddritzenhoff/dinny/sqlite"
+)
+
+// ReconcileCommand is a command to recompute the meals eaten of every member from the attendance ledger.
+type ReconcileCommand struct {
+	ConfigPath string
+}
+
+// Run executes the reconcile command.
+func (c *ReconcileCommand) Run(ctx context.Context, args []string) error {
+	var dryRun bool
+	fs := flag.NewFlagSet("dinnyd reconcile", flag.ContinueOnError)
+	fs.StringVar(&c.ConfigPath, "config", DefaultConfigPath, "config path")
+	fs.BoolVar(&dryRun, "dry-run", false, "only list the counters which would be corrected")
+	fs.Usage = c.usage
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	DSNPath, err := configDSN(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	db, err := sqlite.Open(DSNPath)
+	if err != nil {
+		return fmt.Errorf("Run sqlite.Open: %w", err)
+	}
+	defer db.Close()
+
+	corrections, err := sqlite.ReconcileMealsEaten(db, dryRun)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	if len(corrections) == 0 {
+		fmt.Println("every member's meals eaten matches their attendances")
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ROTATION\tMEMBER\tSLACK UID\tMEALS EATEN\tATTENDANCES")
+	for _, c := range corrections {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%d\n", c.RotationID, c.FullName, c.SlackUID, c.From, c.To)
+	}
+	return tw.Flush()
+}
+
+// usage prints usage information for reconcile to STDOUT.
+func (c *ReconcileCommand) usage() {
+	fmt.Println(`
+Recompute the meals eaten of every member from their attendances made since the last season was closed
+and list the members whose counter didn't match. Meals eaten set by hand are overwritten.
+
+Usage:
+
+		dinnyd reconcile [-config <path>] [-dry-run]
+
+Arguments:
+
+		-dry-run
+			Only list the counters which would be corrected
+`[1:])
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// TokenCommand is a command to create the first API token directly against the database.
+// Further tokens can be managed remotely by leaders with the dinny CLI.
+type TokenCommand struct {
+	ConfigPath string
+}
+
+// Run executes the token command.
+func (c *TokenCommand) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		c.usage()
+		return flag.ErrHelp
+	}
+
+	var memberSlackUID, fullName, name, scope, rotation string
+	var leader bool
+	fs := flag.NewFlagSet("dinnyd token create", flag.ContinueOnError)
+	fs.StringVar(&c.ConfigPath, "config", DefaultConfigPath, "config path")
+	fs.StringVar(&memberSlackUID, "member", "", "slack UID of the token's owner")
+	fs.StringVar(&name, "name", "", "name describing the token")
+	fs.StringVar(&scope, "scope", dinny.TokenScopeWrite, "scope of the token (read or write)")
+	fs.StringVar(&rotation, "rotation", "", "name of the owner's rotation, if not the default one")
+	fs.BoolVar(&leader, "leader", false, "mark the owner as a leader, creating the member if needed")
+	fs.StringVar(&fullName, "fullname", "", "full name of the owner if the member is created by -leader")
+	fs.Usage = c.usage
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if memberSlackUID == "" || !dinny.ValidTokenScope(scope) {
+		c.usage()
+		return flag.ErrHelp
+	}
+
+	DSNPath, err := configDSN(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	db, err := sqlite.Open(DSNPath)
+	if err != nil {
+		return fmt.Errorf("Run sqlite.Open: %w", err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+
+	var rotationID int64 = dinny.DefaultRotationID
+	if rotation != "" {
+		r, err := sqlite.NewRotationService(queries, db).FindRotationByName(rotation)
+		if err != nil {
+			return fmt.Errorf("Run FindRotationByName %s: %w", rotation, err)
+		}
+		rotationID = r.ID
+	}
+
+	memberService := sqlite.NewMemberService(queries, db).ForRotation(rotationID)
+	member, err := memberService.FindMemberBySlackUID(memberSlackUID)
+	if errors.Is(err, dinny.ErrNotFound) && leader {
+		if fullName == "" {
+			fullName = memberSlackUID
+		}
+		member = &dinny.Member{SlackUID: memberSlackUID, FullName: fullName, Leader: true}
+		if err := memberService.CreateMember(member); err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("Run FindMemberBySlackUID %s: %w", memberSlackUID, err)
+	} else if leader && !member.Leader {
+		if err := memberService.UpdateMember(member.ID, dinny.MemberUpdate{Leader: &leader}); err != nil {
+			return fmt.Errorf("Run: %w", err)
+		}
+	}
+	secret, err := dinny.NewTokenSecret()
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	token := &dinny.Token{
+		MemberID: member.ID,
+		Name:     name,
+		Scope:    scope,
+		Hash:     dinny.HashTokenSecret(secret),
+	}
+	if err := sqlite.NewTokenService(queries, db).CreateToken(token); err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+
+	e := json.NewEncoder(os.Stdout)
+	e.SetIndent("", "  ")
+	return e.Encode(struct {
+		Token  *dinny.Token `json:"token"`
+		Secret string       `json:"secret"`
+	}{token, secret})
+}
+
+// usage prints usage information for token to STDOUT.
+func (c *TokenCommand) usage() {
+	fmt.Println(`
+Create an API token directly against the database. Use this with -leader to bootstrap the first leader token,
+which marks the member as a leader and creates them if they don't exist yet; afterwards leaders can manage tokens
+with 'dinny token'.
+
+Usage:
+
+		dinnyd token create [-config <path>] -member <slackUID> [-rotation <name>] [-name <name>] [-scope read|write] [-leader [-fullname <name>]]
+`[1:])
+}
@@ -0,0 +1,59 @@
+package dinny
+
+import "fmt"
+
+// Cooking credit formulas determine how much credit a cook gets for closing out a meal.
+const (
+	// CreditFormulaFlat credits every meal with one credit, regardless of its headcount.
+	CreditFormulaFlat = "flat"
+
+	// CreditFormulaAverage credits a meal with its headcount divided by the average headcount of the rotation's cooked meals,
+	// so an average meal is worth one credit.
+	CreditFormulaAverage = "average"
+
+	// CreditFormulaBaseline credits a meal with its headcount divided by a fixed baseline headcount.
+	CreditFormulaBaseline = "baseline"
+)
+
+// CreditFormula represents the formula with which cooks are credited for their meals.
+type CreditFormula struct {
+	// Name is one of the CreditFormula constants. Defaults to CreditFormulaFlat.
+	Name string
+
+	// Baseline is the headcount worth one credit with CreditFormulaBaseline.
+	Baseline float64
+}
+
+// Validate returns an error if the formula is unknown or lacks its baseline.
+func (f CreditFormula) Validate() error {
+	switch f.Name {
+	case "", CreditFormulaFlat, CreditFormulaAverage:
+		return nil
+	case CreditFormulaBaseline:
+		if f.Baseline <= 0 {
+			return fmt.Errorf("Validate: the %s formula needs a positive baseline", f.Name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("Validate: unknown credit formula %q", f.Name)
+	}
+}
+
+// Credit returns the credit for cooking a meal for headcount eaters, given the average headcount of the cooked meals.
+// A meal is worth one credit if its weight can't be determined, e.g. before any meal had eaters.
+func (f CreditFormula) Credit(headcount int64, averageHeadcount float64) float64 {
+	switch f.Name {
+	case CreditFormulaAverage:
+		if averageHeadcount <= 0 {
+			return 1
+		}
+		return float64(headcount) / averageHeadcount
+	case CreditFormulaBaseline:
+		if f.Baseline <= 0 {
+			return 1
+		}
+		return float64(headcount) / f.Baseline
+	default:
+		return 1
+	}
+}
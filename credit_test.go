@@ -0,0 +1,27 @@
+package dinny
+
+import "testing"
+
+// TestCreditFormula ensures meals are weighted by headcount as configured and fall back to one credit.
+func TestCreditFormula(t *testing.T) {
+	tests := []struct {
+		name      string
+		formula   CreditFormula
+		headcount int64
+		average   float64
+		want      float64
+	}{
+		{"flat", CreditFormula{}, 12, 6, 1},
+		{"average", CreditFormula{Name: CreditFormulaAverage}, 12, 6, 2},
+		{"average small meal", CreditFormula{Name: CreditFormulaAverage}, 3, 6, 0.5},
+		{"average without history", CreditFormula{Name: CreditFormulaAverage}, 3, 0, 1},
+		{"baseline", CreditFormula{Name: CreditFormulaBaseline, Baseline: 4}, 6, 2, 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formula.Credit(tt.headcount, tt.average); got != tt.want {
+				t.Errorf("Credit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// Spec represents when a job should run. Jobs either run daily at a time of day, weekly on a weekday at a time of day,
+// or repeatedly at a fixed interval.
+type Spec struct {
+	// Weekday is nil for jobs which run every day.
+	Weekday *time.Weekday
+	Hour    int
+	Minute  int
+
+	// Every is the interval of jobs which run repeatedly. Weekday, Hour, and Minute are ignored if set.
+	Every time.Duration
+}
+
+// weekdays maps the accepted weekday abbreviations to a time.Weekday.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseSpec parses a spec of the form "HH:MM" (daily), "Mon HH:MM" (weekly), or "every 5m" (repeatedly).
+func ParseSpec(s string) (Spec, error) {
+	var spec Spec
+	fields := strings.Fields(s)
+	if len(fields) == 2 && strings.ToLower(fields[0]) == "every" {
+		every, err := time.ParseDuration(fields[1])
+		if err != nil {
+			return spec, fmt.Errorf("ParseSpec time.ParseDuration: %w", err)
+		}
+		if every < time.Minute {
+			return spec, fmt.Errorf("ParseSpec: interval %s is shorter than a minute", every)
+		}
+		spec.Every = every
+		return spec, nil
+	}
+	switch len(fields) {
+	case 1:
+	case 2:
+		name := strings.ToLower(fields[0])
+		if len(name) > 3 {
+			name = name[:3]
+		}
+		weekday, ok := weekdays[name]
+		if !ok {
+			return spec, fmt.Errorf("ParseSpec: unknown weekday %q", fields[0])
+		}
+		spec.Weekday = &weekday
+	default:
+		return spec, fmt.Errorf("ParseSpec: expected \"HH:MM\", \"Mon HH:MM\", or \"every 5m\", got %q", s)
+	}
+	t, err := time.Parse("15:04", fields[len(fields)-1])
+	if err != nil {
+		return spec, fmt.Errorf("ParseSpec time.Parse: %w", err)
+	}
+	spec.Hour, spec.Minute = t.Hour(), t.Minute()
+	return spec, nil
+}
+
+// Next returns the first time strictly after t at which the spec fires. The result is in t's location.
+// Intervals are aligned to multiples of the interval, e.g. "every 5m" fires at :00, :05, :10 and so on.
+func (s Spec) Next(t time.Time) time.Time {
+	if s.Every > 0 {
+		return t.Truncate(s.Every).Add(s.Every)
+	}
+	year, month, day := t.Date()
+	next := time.Date(year, month, day, s.Hour, s.Minute, 0, 0, t.Location())
+	for !next.After(t) || (s.Weekday != nil && next.Weekday() != *s.Weekday) {
+		next = time.Date(next.Year(), next.Month(), next.Day()+1, s.Hour, s.Minute, 0, 0, t.Location())
+	}
+	return next
+}
+
+// Prev returns the last time at or before t at which the spec fired. The result is in t's location.
+func (s Spec) Prev(t time.Time) time.Time {
+	if s.Every > 0 {
+		return t.Truncate(s.Every)
+	}
+	year, month, day := t.Date()
+	prev := time.Date(year, month, day, s.Hour, s.Minute, 0, 0, t.Location())
+	for prev.After(t) || (s.Weekday != nil && prev.Weekday() != *s.Weekday) {
+		prev = time.Date(prev.Year(), prev.Month(), prev.Day()-1, s.Hour, s.Minute, 0, 0, t.Location())
+	}
+	return prev
+}
+
+// Job represents a named function run according to a spec.
+type Job struct {
+	Name string
+	Spec Spec
+	// Raw is the spec as it was configured.
+	Raw string
+	Run func() error
+}
+
+// JobStatus represents when a job last ran, whether it succeeded, and when it will run next.
+type JobStatus struct {
+	Name          string     `json:"name"`
+	Spec          string     `json:"spec"`
+	LastRunAt     *time.Time `json:"lastRunAt,omitempty"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+	NextRunAt     time.Time  `json:"nextRunAt"`
+}
+
+// DefaultRetryInterval is how long the scheduler waits before retrying a failed run.
+const DefaultRetryInterval = 5 * time.Minute
+
+// Scheduler runs jobs at their scheduled times and records each run so restarts don't run a job twice.
+// A run which fails is retried until it succeeds or the job is next due, and a run missed while the scheduler was
+// stopped is caught up when it starts.
+type Scheduler struct {
+	mu   sync.Mutex
+	jobs []*Job
+
+	// RetryInterval is how long to wait before retrying a failed run. Defaults to DefaultRetryInterval.
+	RetryInterval time.Duration
+
+	logger        *log.Logger
+	location      *time.Location
+	jobRunService dinny.JobRunService
+}
+
+// NewScheduler returns a new instance of Scheduler which interprets specs in the given location.
+func NewScheduler(logger *log.Logger, location *time.Location, jobRunService dinny.JobRunService) *Scheduler {
+	return &Scheduler{
+		RetryInterval: DefaultRetryInterval,
+		logger:        logger,
+		location:      location,
+		jobRunService: jobRunService,
+	}
+}
+
+// Add registers a job to be run according to spec. Jobs must be added before Start is called.
+func (s *Scheduler) Add(name string, spec string, run func() error) error {
+	parsed, err := ParseSpec(spec)
+	if err != nil {
+		return fmt.Errorf("Add %s: %w", name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &Job{Name: name, Spec: parsed, Raw: spec, Run: run})
+	return nil
+}
+
+// Start runs every registered job in its own goroutine until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+// loop waits for the next run of the job and runs it until ctx is cancelled.
+func (s *Scheduler) loop(ctx context.Context, job *Job) {
+	now := time.Now().In(s.location)
+	next := job.Spec.Next(now)
+	scheduled, runAt := next, next
+	if prev := job.Spec.Prev(now); s.missed(job, prev) {
+		s.logger.Printf("Scheduler %s: catching up on the run of %s", job.Name, prev.Format(time.RFC3339))
+		scheduled, runAt = prev, now
+	}
+	for {
+		timer := time.NewTimer(time.Until(runAt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		err := s.runJob(job, scheduled)
+		now = time.Now().In(s.location)
+		next = job.Spec.Next(now)
+		if err != nil {
+			s.logger.Printf("Scheduler %s: %s", job.Name, err.Error())
+
+			// Retry the failed run unless the job is due again first.
+			if retry := now.Add(s.RetryInterval); retry.Before(next) {
+				runAt = retry
+				continue
+			}
+		}
+		scheduled, runAt = next, next
+	}
+}
+
+// missed reports whether the run of the job scheduled at the given time never succeeded, either because the scheduler
+// was stopped or because it failed. Jobs which have never run aren't caught up, so a new job first runs when it's due.
+func (s *Scheduler) missed(job *Job, scheduled time.Time) bool {
+	last, err := s.jobRunService.FindJobRun(job.Name)
+	if errors.Is(err, dinny.ErrNotFound) {
+		return false
+	} else if err != nil {
+		s.logger.Printf("Scheduler %s: missed FindJobRun: %s", job.Name, err.Error())
+		return false
+	}
+	return last.LastSuccessAt == nil || last.LastSuccessAt.Before(scheduled)
+}
+
+// runJob runs the job for the scheduled time unless a successful run at or after that time has already been recorded.
+// The run is recorded once it finishes, so a run interrupted by a crash is caught up on the next start.
+func (s *Scheduler) runJob(job *Job, scheduled time.Time) error {
+	last, err := s.jobRunService.FindJobRun(job.Name)
+	if err != nil && !errors.Is(err, dinny.ErrNotFound) {
+		return fmt.Errorf("runJob FindJobRun: %w", err)
+	}
+	if last != nil && last.LastSuccessAt != nil && !last.LastSuccessAt.Before(scheduled) {
+		return nil
+	}
+
+	run := &dinny.JobRun{Name: job.Name, LastRunAt: time.Now()}
+	if last != nil {
+		run.LastSuccessAt = last.LastSuccessAt
+	}
+	jobErr := job.Run()
+	if jobErr != nil {
+		run.LastError = jobErr.Error()
+	} else {
+		successAt := time.Now()
+		run.LastSuccessAt = &successAt
+	}
+	err = s.jobRunService.UpsertJobRun(run)
+	if err != nil {
+		return fmt.Errorf("runJob UpsertJobRun: %w", err)
+	}
+	if jobErr != nil {
+		return fmt.Errorf("runJob: %w", jobErr)
+	}
+	s.logger.Printf("Scheduler %s: ran", job.Name)
+	return nil
+}
+
+// Status returns when each job last ran and when it will run next.
+func (s *Scheduler) Status() ([]JobStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now().In(s.location)
+	var statuses []JobStatus
+	for _, job := range s.jobs {
+		status := JobStatus{
+			Name:      job.Name,
+			Spec:      job.Raw,
+			NextRunAt: job.Spec.Next(now),
+		}
+		last, err := s.jobRunService.FindJobRun(job.Name)
+		if err == nil {
+			lastRunAt := last.LastRunAt.In(s.location)
+			status.LastRunAt = &lastRunAt
+			if last.LastSuccessAt != nil {
+				lastSuccessAt := last.LastSuccessAt.In(s.location)
+				status.LastSuccessAt = &lastSuccessAt
+			}
+			status.LastError = last.LastError
+		} else if !errors.Is(err, dinny.ErrNotFound) {
+			return nil, fmt.Errorf("Status FindJobRun: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
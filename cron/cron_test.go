@@ -0,0 +1,141 @@
+package cron
+
+import (
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// TestSpecNext ensures daily and weekly specs fire at the next matching time strictly after now.
+func TestSpecNext(t *testing.T) {
+	// 2023-01-04 was a Wednesday.
+	now := time.Date(2023, time.January, 4, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"18:00", time.Date(2023, time.January, 4, 18, 0, 0, 0, time.UTC)},
+		{"12:00", time.Date(2023, time.January, 5, 12, 0, 0, 0, time.UTC)},
+		{"08:30", time.Date(2023, time.January, 5, 8, 30, 0, 0, time.UTC)},
+		{"Wed 13:00", time.Date(2023, time.January, 4, 13, 0, 0, 0, time.UTC)},
+		{"Wed 12:00", time.Date(2023, time.January, 11, 12, 0, 0, 0, time.UTC)},
+		{"Sun 10:00", time.Date(2023, time.January, 8, 10, 0, 0, 0, time.UTC)},
+		{"monday 07:00", time.Date(2023, time.January, 9, 7, 0, 0, 0, time.UTC)},
+		{"every 5m", time.Date(2023, time.January, 4, 12, 5, 0, 0, time.UTC)},
+		{"every 1h", time.Date(2023, time.January, 4, 13, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := spec.Next(now); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseSpecInvalid ensures malformed specs are rejected.
+func TestParseSpecInvalid(t *testing.T) {
+	for _, spec := range []string{"", "25:00", "Funday 10:00", "Sun 10:00 extra", "noon", "every", "every 10s", "every soon"} {
+		if _, err := ParseSpec(spec); err == nil {
+			t.Errorf("ParseSpec(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+// TestSpecPrev ensures daily and weekly specs fired last at the latest matching time at or before now.
+func TestSpecPrev(t *testing.T) {
+	// 2023-01-04 was a Wednesday.
+	now := time.Date(2023, time.January, 4, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"18:00", time.Date(2023, time.January, 3, 18, 0, 0, 0, time.UTC)},
+		{"12:00", time.Date(2023, time.January, 4, 12, 0, 0, 0, time.UTC)},
+		{"08:30", time.Date(2023, time.January, 4, 8, 30, 0, 0, time.UTC)},
+		{"Wed 13:00", time.Date(2022, time.December, 28, 13, 0, 0, 0, time.UTC)},
+		{"Sun 10:00", time.Date(2023, time.January, 1, 10, 0, 0, 0, time.UTC)},
+		{"every 5m", time.Date(2023, time.January, 4, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := spec.Prev(now); !got.Equal(tt.want) {
+				t.Errorf("Prev() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// jobRunService is an in-memory dinny.JobRunService.
+type jobRunService map[string]dinny.JobRun
+
+func (js jobRunService) FindJobRun(name string) (*dinny.JobRun, error) {
+	run, ok := js[name]
+	if !ok {
+		return nil, dinny.ErrNotFound
+	}
+	return &run, nil
+}
+
+func (js jobRunService) UpsertJobRun(run *dinny.JobRun) error {
+	js[run.Name] = *run
+	return nil
+}
+
+// TestRunJob ensures a failed run is recorded without a success so it is retried and caught up, and a successful run
+// is not repeated.
+func TestRunJob(t *testing.T) {
+	runs := jobRunService{}
+	s := NewScheduler(log.New(io.Discard, "", 0), time.UTC, runs)
+	fail := true
+	calls := 0
+	job := &Job{Name: "eating-tomorrow", Run: func() error {
+		calls++
+		if fail {
+			return errors.New("slack is down")
+		}
+		return nil
+	}}
+	scheduled := time.Now().Add(-time.Hour)
+
+	if err := s.runJob(job, scheduled); err == nil {
+		t.Fatal("runJob succeeded, want error")
+	}
+	if run := runs[job.Name]; run.LastSuccessAt != nil || run.LastError != "slack is down" {
+		t.Errorf("JobRun = %+v, want no success and the error", run)
+	}
+	if !s.missed(job, scheduled) {
+		t.Error("missed() = false after a failed run, want true")
+	}
+
+	fail = false
+	for ii := 0; ii < 2; ii++ {
+		if err := s.runJob(job, scheduled); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	if run := runs[job.Name]; run.LastSuccessAt == nil || run.LastError != "" {
+		t.Errorf("JobRun = %+v, want a success and no error", run)
+	}
+	if s.missed(job, scheduled) {
+		t.Error("missed() = true after a successful run, want false")
+	}
+	if !s.missed(job, time.Now().Add(time.Hour)) {
+		t.Error("missed() = false for a later run, want true")
+	}
+}
@@ -0,0 +1,91 @@
+package dinny
+
+import "fmt"
+
+// Dietary restrictions a member may have.
+const (
+	DietVegan      = "vegan"
+	DietVegetarian = "vegetarian"
+	DietGlutenFree = "gluten-free"
+	DietDairyFree  = "dairy-free"
+	DietNutAllergy = "nut-allergy"
+)
+
+// DietRestrictions lists every known dietary restriction.
+var DietRestrictions = []string{DietVegan, DietVegetarian, DietGlutenFree, DietDairyFree, DietNutAllergy}
+
+// DietaryProfile represents the dietary restrictions and allergies of a member.
+// Allergies is free text for anything which can't be expressed as a restriction, so it never causes a conflict.
+type DietaryProfile struct {
+	MemberID     int64    `json:"memberID"`
+	Restrictions []string `json:"restrictions"`
+	Allergies    string   `json:"allergies"`
+}
+
+// ParseDietRestrictions parses a comma separated list of dietary restrictions like "vegan, nut-allergy". Duplicates are dropped.
+func ParseDietRestrictions(s string) ([]string, error) {
+	restrictions, err := parseList(s, DietRestrictions)
+	if err != nil {
+		return nil, fmt.Errorf("ParseDietRestrictions: %w", err)
+	}
+	return restrictions, nil
+}
+
+// hasTag reports whether tags contains any of want.
+func hasTag(tags []string, want ...string) bool {
+	for _, tag := range tags {
+		for _, w := range want {
+			if tag == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Conflicts returns the restrictions of the profile which a meal with the given tags doesn't satisfy.
+// A meal must be tagged as suitable for a restriction, so an untagged meal conflicts with every restriction but nut-allergy.
+func (p *DietaryProfile) Conflicts(tags []string) []string {
+	var conflicts []string
+	for _, r := range p.Restrictions {
+		var ok bool
+		switch r {
+		case DietVegan:
+			ok = hasTag(tags, MealTagVegan)
+		case DietVegetarian:
+			ok = hasTag(tags, MealTagVegan, MealTagVegetarian)
+		case DietGlutenFree:
+			ok = hasTag(tags, MealTagGlutenFree)
+		case DietDairyFree:
+			ok = hasTag(tags, MealTagVegan, MealTagDairyFree)
+		case DietNutAllergy:
+			ok = !hasTag(tags, MealTagContainsNuts)
+		default:
+			ok = true
+		}
+		if !ok {
+			conflicts = append(conflicts, r)
+		}
+	}
+	return conflicts
+}
+
+// DietaryProfileService represents a service for managing the dietary profiles of members.
+type DietaryProfileService interface {
+	// FindDietaryProfile retrieves the dietary profile of a member.
+	// Returns ErrNotFound if the member hasn't set one.
+	FindDietaryProfile(memberID int64) (*DietaryProfile, error)
+
+	// ListDietaryProfiles retrieves every dietary profile.
+	ListDietaryProfiles() ([]*DietaryProfile, error)
+
+	// SetDietaryProfile creates or replaces the dietary profile of p.MemberID.
+	SetDietaryProfile(p *DietaryProfile) error
+
+	// ListDietWarnings retrieves the IDs of the members whose dietary restrictions the cooks of the meal were warned about.
+	ListDietWarnings(mealID int64) ([]int64, error)
+
+	// CreateDietWarning records that the cooks of the meal were warned about the dietary restrictions of the member.
+	// Recording the same warning twice is a no-op.
+	CreateDietWarning(mealID int64, memberID int64) error
+}
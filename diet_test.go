@@ -0,0 +1,30 @@
+package dinny
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDietaryProfileConflicts ensures restrictions are only satisfied by suitable meal tags.
+func TestDietaryProfileConflicts(t *testing.T) {
+	tests := []struct {
+		name         string
+		restrictions []string
+		tags         []string
+		want         []string
+	}{
+		{"untagged", []string{DietVegetarian, DietNutAllergy}, nil, []string{DietVegetarian}},
+		{"vegan covers vegetarian", []string{DietVegetarian, DietDairyFree}, []string{MealTagVegan}, nil},
+		{"vegetarian doesn't cover vegan", []string{DietVegan}, []string{MealTagVegetarian}, []string{DietVegan}},
+		{"nuts", []string{DietNutAllergy, DietGlutenFree}, []string{MealTagGlutenFree, MealTagContainsNuts}, []string{DietNutAllergy}},
+		{"no restrictions", nil, []string{MealTagContainsNuts}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &DietaryProfile{Restrictions: tt.restrictions}
+			if got := p.Conflicts(tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Conflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,5 +6,6 @@ import (
 
 // Application error codes
 var (
-	ErrNotFound = errors.New("not found")
+	ErrNotFound   = errors.New("not found")
+	ErrMealClosed = errors.New("meal closed")
 )
@@ -0,0 +1,21 @@
+package dinny
+
+import "time"
+
+// DefaultProcessedEventRetention is how long processed Slack events are remembered by default.
+// Slack stops retrying an event well within this period.
+const DefaultProcessedEventRetention = 24 * time.Hour
+
+// ProcessedEventService represents a service for remembering which Slack events have been processed,
+// so that events Slack delivers more than once are only processed once.
+type ProcessedEventService interface {
+	// CreateProcessedEvent records the Slack event with the given event_id as processed.
+	// Returns false if the event has already been recorded.
+	CreateProcessedEvent(eventID string) (bool, error)
+
+	// DeleteProcessedEvent forgets the Slack event with the given event_id, so that a retry of it is processed again.
+	DeleteProcessedEvent(eventID string) error
+
+	// DeleteProcessedEventsBefore forgets the events processed before t and returns how many were forgotten.
+	DeleteProcessedEventsBefore(t time.Time) (int64, error)
+}
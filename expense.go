@@ -0,0 +1,148 @@
+package dinny
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expense represents a grocery cost a member paid for a meal.
+// The cost is split across the meal's recorded eaters, so a meal nobody ate, e.g. a cancelled one, stays with the payer.
+type Expense struct {
+	ID          int64     `json:"id"`
+	MealID      int64     `json:"mealID"`
+	MemberID    int64     `json:"memberID"`
+	AmountCents int64     `json:"amountCents"`
+	Note        string    `json:"note"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Payment represents money a member paid another member to settle up.
+type Payment struct {
+	ID           int64     `json:"id"`
+	FromMemberID int64     `json:"fromMemberID"`
+	ToMemberID   int64     `json:"toMemberID"`
+	AmountCents  int64     `json:"amountCents"`
+	Note         string    `json:"note"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Balance represents how much a member is owed by the rest of the dinner rotation.
+// A negative balance is owed by the member.
+type Balance struct {
+	MemberID int64  `json:"memberID"`
+	SlackUID string `json:"slackUID"`
+	FullName string `json:"fullName"`
+	Cents    int64  `json:"cents"`
+}
+
+// Portions returns the number of portions each member ate of a meal: one for themselves if they attended, plus one per guest.
+func Portions(attendances []*Attendance, guests []*Guests) map[int64]int64 {
+	portions := make(map[int64]int64)
+	for _, a := range attendances {
+		portions[a.MemberID]++
+	}
+	for _, g := range guests {
+		if g.Count > 0 {
+			portions[g.MemberID] += g.Count
+		}
+	}
+	return portions
+}
+
+// SplitCost splits cents across the members proportionally to their portions.
+// Cents which can't be split evenly go to the members with the lowest IDs, one each, so the shares always add up to cents.
+// Returns nil if nobody ate a portion.
+func SplitCost(cents int64, portions map[int64]int64) map[int64]int64 {
+	var total int64
+	var memberIDs []int64
+	for memberID, n := range portions {
+		if n > 0 {
+			total += n
+			memberIDs = append(memberIDs, memberID)
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	sort.Slice(memberIDs, func(ii, jj int) bool { return memberIDs[ii] < memberIDs[jj] })
+
+	shares := make(map[int64]int64, len(memberIDs))
+	remainder := cents
+	for _, memberID := range memberIDs {
+		shares[memberID] = cents * portions[memberID] / total
+		remainder -= shares[memberID]
+	}
+	for ii := 0; remainder > 0; ii++ {
+		shares[memberIDs[ii]]++
+		remainder--
+	}
+	return shares
+}
+
+// ParseCents parses a positive amount of money such as "12", "12.5" or "12.50" into cents.
+func ParseCents(s string) (int64, error) {
+	whole, frac, hasFrac := strings.Cut(strings.TrimSpace(s), ".")
+	if !isDigits(whole) || (hasFrac && !isDigits(frac)) || len(frac) > 2 {
+		return 0, fmt.Errorf("ParseCents: invalid amount %q", s)
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("ParseCents strconv.ParseInt: %w", err)
+	}
+	cents, _ := strconv.ParseInt((frac + "00")[:2], 10, 64)
+	amount := units*100 + cents
+	if amount <= 0 {
+		return 0, fmt.Errorf("ParseCents: amount %q must be positive", s)
+	}
+	return amount, nil
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatCents formats cents as an amount of money with two decimals, e.g. "-12.50".
+func FormatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// ExpenseService represents a service for managing grocery expenses, payments between members and the resulting balances.
+type ExpenseService interface {
+	// ListExpensesByMeal retrieves every expense logged for a meal.
+	ListExpensesByMeal(mealID int64) ([]*Expense, error)
+
+	// FindExpenseByID retrieves an expense by ID.
+	// Returns ErrNotFound if the expense does not exist.
+	FindExpenseByID(id int64) (*Expense, error)
+
+	// CreateExpense logs a grocery cost against a meal. Sets the ID of e on success.
+	// Returns ErrNotFound if the meal doesn't exist.
+	CreateExpense(e *Expense) error
+
+	// DeleteExpense permanently deletes an expense logged by mistake.
+	// Returns ErrNotFound if the expense does not exist.
+	DeleteExpense(id int64) error
+
+	// CreatePayment records a member paying another member. Sets the ID of p on success.
+	// Returns ErrNotFound if either member doesn't exist and an error if a member pays themselves.
+	CreatePayment(p *Payment) error
+
+	// ListBalances retrieves the balance of every member, splitting each expense across the eaters of its meal.
+	ListBalances() ([]*Balance, error)
+}
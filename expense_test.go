@@ -0,0 +1,55 @@
+package dinny
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSplitCost ensures costs are split by portions and the shares always add up to the cost.
+func TestSplitCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		cents    int64
+		portions map[int64]int64
+		want     map[int64]int64
+	}{
+		{"even", 900, map[int64]int64{1: 1, 2: 1, 3: 1}, map[int64]int64{1: 300, 2: 300, 3: 300}},
+		{"remainder to lowest ids", 1000, map[int64]int64{3: 1, 1: 1, 2: 1}, map[int64]int64{1: 334, 2: 333, 3: 333}},
+		{"guests", 1200, map[int64]int64{1: 1, 2: 3}, map[int64]int64{1: 300, 2: 900}},
+		{"nobody ate", 1200, map[int64]int64{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitCost(tt.cents, tt.portions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseCents ensures only positive amounts with at most two decimals are accepted.
+func TestParseCents(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"12", 1200, false},
+		{"12.5", 1250, false},
+		{"12.05", 1205, false},
+		{"0.99", 99, false},
+		{"0", 0, true},
+		{"-3", 0, true},
+		{"12.", 0, true},
+		{"12.345", 0, true},
+		{"1e3", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseCents(tt.in)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseCents() = %d, %v, want %d, wantErr %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
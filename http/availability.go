@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateAvailabilityRequest represents a request to mark a member as away.
+// MemberSlackUID defaults to the owner of the API token. Only leaders may mark other members as away.
+type CreateAvailabilityRequest struct {
+	MemberSlackUID string     `json:"memberSlackUID,omitempty"`
+	From           dinny.Date `json:"from"`
+	To             dinny.Date `json:"to"`
+	CannotCook     bool       `json:"cannotCook"`
+	NotEating      bool       `json:"notEating"`
+	Note           string     `json:"note"`
+}
+
+// handleListAvailabilities is a handler for listing the availabilities between the from and to query parameters.
+// Lists the next 30 days if unset.
+func (s *Server) handleListAvailabilities(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	from := dinny.DateOf(time.Now())
+	to := from.AddDays(30)
+	var err error
+	if v := r.URL.Query().Get("from"); v != "" {
+		if from, err = dinny.ParseDate(v); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, err = dinny.ParseDate(v); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+	}
+
+	availabilities, err := rot.AvailabilityService.ListAvailabilities(from, to)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleListAvailabilities ListAvailabilities: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(availabilities)
+}
+
+// handleCreateAvailability is a handler for the away command.
+func (s *Server) handleCreateAvailability(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req CreateAvailabilityRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreateAvailability: %s", err.Error())
+		return
+	}
+	if req.To.Before(req.From) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("to %s is before from %s", req.To, req.From)))
+		return
+	}
+
+	owner, err := s.tokenOwner(r)
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("the api token doesn't belong to a member"))
+		return
+	}
+	member := owner
+	if req.MemberSlackUID != "" && req.MemberSlackUID != owner.SlackUID {
+		if !owner.Leader {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("only leaders may mark other members as away"))
+			return
+		}
+		member, err = rot.MemberService.FindMemberBySlackUID(req.MemberSlackUID)
+		if errors.Is(err, dinny.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(fmt.Sprintf("member %s not found", req.MemberSlackUID)))
+			return
+		} else if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("handleCreateAvailability FindMemberBySlackUID: %s", err.Error())
+			return
+		}
+	}
+
+	availability := &dinny.Availability{
+		MemberID:   member.ID,
+		From:       req.From,
+		To:         req.To,
+		CannotCook: req.CannotCook,
+		NotEating:  req.NotEating,
+		Note:       req.Note,
+	}
+	err = rot.AvailabilityService.CreateAvailability(availability)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreateAvailability CreateAvailability: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(availability)
+}
+
+// handleDeleteAvailability is a handler for deleting an availability. Members may only delete their own unless they are a leader.
+func (s *Server) handleDeleteAvailability(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	owner, err := s.tokenOwner(r)
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("the api token doesn't belong to a member"))
+		return
+	}
+	if !owner.Leader {
+		own, err := rot.AvailabilityService.ListAvailabilitiesByMember(owner.ID)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("handleDeleteAvailability ListAvailabilitiesByMember: %s", err.Error())
+			return
+		}
+		found := false
+		for _, a := range own {
+			found = found || a.ID == id
+		}
+		if !found {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("only leaders may delete other members' availabilities"))
+			return
+		}
+	}
+
+	err = rot.AvailabilityService.DeleteAvailability(id)
+	if errors.Is(err, dinny.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("availability %d not found", id)))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleDeleteAvailability DeleteAvailability: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
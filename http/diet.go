@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// SetDietRequest represents a request to set the dietary profile of a member.
+// MemberSlackUID defaults to the owner of the API token. Only leaders may set the profiles of other members.
+type SetDietRequest struct {
+	MemberSlackUID string   `json:"memberSlackUID,omitempty"`
+	Restrictions   []string `json:"restrictions"`
+	Allergies      string   `json:"allergies"`
+}
+
+// DietResponse represents the dietary profile of a member.
+type DietResponse struct {
+	SlackUID     string   `json:"slackUID"`
+	FullName     string   `json:"fullName"`
+	Restrictions []string `json:"restrictions"`
+	Allergies    string   `json:"allergies"`
+}
+
+// handleListDiets is a handler for listing the dietary profiles of every member.
+func (s *Server) handleListDiets(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	profiles, err := rot.DietaryProfileService.ListDietaryProfiles()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleListDiets ListDietaryProfiles: %s", err.Error())
+		return
+	}
+	diets := []DietResponse{}
+	for _, p := range profiles {
+		member, err := rot.MemberService.FindMemberByID(p.MemberID)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("handleListDiets FindMemberByID: %s", err.Error())
+			return
+		}
+		diets = append(diets, DietResponse{
+			SlackUID:     member.SlackUID,
+			FullName:     member.FullName,
+			Restrictions: p.Restrictions,
+			Allergies:    p.Allergies,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diets)
+}
+
+// handleSetDiet is a handler for the diet command.
+func (s *Server) handleSetDiet(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req SetDietRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetDiet: %s", err.Error())
+		return
+	}
+	restrictions, err := dinny.ParseDietRestrictions(strings.Join(req.Restrictions, ","))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	owner, err := s.tokenOwner(r)
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("the api token doesn't belong to a member"))
+		return
+	}
+	member := owner
+	if req.MemberSlackUID != "" && req.MemberSlackUID != owner.SlackUID {
+		if !owner.Leader {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("only leaders may set the dietary profiles of other members"))
+			return
+		}
+		member, err = rot.MemberService.FindMemberBySlackUID(req.MemberSlackUID)
+		if errors.Is(err, dinny.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(fmt.Sprintf("member %s not found", req.MemberSlackUID)))
+			return
+		} else if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("handleSetDiet FindMemberBySlackUID: %s", err.Error())
+			return
+		}
+	}
+
+	profile := &dinny.DietaryProfile{
+		MemberID:     member.ID,
+		Restrictions: restrictions,
+		Allergies:    req.Allergies,
+	}
+	err = rot.DietaryProfileService.SetDietaryProfile(profile)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetDiet SetDietaryProfile: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/slack-go/slack/slackevents"
+)
+
+// DefaultEventQueueSize is the number of Slack events which may wait to be processed before further events are turned away.
+const DefaultEventQueueSize = 100
+
+// slackEvent represents a Slack event waiting to be processed.
+type slackEvent struct {
+	id    string
+	event slackevents.EventsAPIEvent
+}
+
+// handleCallbackEvent acknowledges a Slack event right away and queues it to be processed, as Slack retries events which aren't acknowledged within three seconds.
+// Without a ProcessedEventService, retries of timed out deliveries are dropped as the original delivery has been queued already.
+func (s *Server) handleCallbackEvent(w http.ResponseWriter, r *http.Request, event slackevents.EventsAPIEvent) {
+	id := eventID(event)
+
+	if retry := r.Header.Get("X-Slack-Retry-Num"); retry != "" {
+		reason := r.Header.Get("X-Slack-Retry-Reason")
+		slackStats.Add("retried", 1)
+		s.Logger.Printf("handleCallbackEvent: retry %s of event %s (%s)", retry, id, reason)
+		if s.ProcessedEventService == nil && reason == "http_timeout" {
+			return
+		}
+	}
+
+	if !s.enqueueEvent(event) {
+		// Slack retries the event later on.
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+// eventID returns the event_id of a Slack event, if any.
+func eventID(event slackevents.EventsAPIEvent) string {
+	if callback, ok := event.Data.(*slackevents.EventsAPICallbackEvent); ok {
+		return callback.EventID
+	}
+	return ""
+}
+
+// enqueueEvent queues a Slack event to be processed. Returns false if the queue is full.
+func (s *Server) enqueueEvent(event slackevents.EventsAPIEvent) bool {
+	id := eventID(event)
+	select {
+	case s.events <- slackEvent{id: id, event: event}:
+		return true
+	default:
+		slackStats.Add("dropped", 1)
+		s.Logger.Printf("enqueueEvent: event queue full, dropped event %s", id)
+		return false
+	}
+}
+
+// processEvents processes the queued Slack events one at a time.
+func (s *Server) processEvents() {
+	for e := range s.events {
+		s.processEvent(e)
+	}
+}
+
+// processEvent processes a Slack event unless it has been processed before.
+// An event which fails to be processed is forgotten again, so that a retry of it by Slack isn't dropped as a duplicate.
+func (s *Server) processEvent(e slackEvent) {
+	recorded := false
+	if s.ProcessedEventService != nil && e.id != "" {
+		fresh, err := s.ProcessedEventService.CreateProcessedEvent(e.id)
+		if err != nil {
+			s.Logger.Printf("processEvent ProcessedEventService.CreateProcessedEvent: %s", err.Error())
+			return
+		}
+		if !fresh {
+			slackStats.Add("duplicate", 1)
+			return
+		}
+		recorded = true
+	}
+
+	var err error
+	switch innerEvent := e.event.InnerEvent.Data.(type) {
+	case *slackevents.ReactionAddedEvent:
+		err = s.slackRotation(innerEvent.Item.Channel, innerEvent.User).SlackService.ReactionAddedEvent(innerEvent)
+	case *slackevents.ReactionRemovedEvent:
+		err = s.slackRotation(innerEvent.Item.Channel, innerEvent.User).SlackService.ReactionRemovedEvent(innerEvent)
+	}
+	if err == nil {
+		return
+	}
+	s.Logger.Printf("%s", err.Error())
+	if recorded {
+		if err := s.ProcessedEventService.DeleteProcessedEvent(e.id); err != nil {
+			s.Logger.Printf("processEvent ProcessedEventService.DeleteProcessedEvent: %s", err.Error())
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ddritzenhoff/dinny/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// reactionCounter is a slack.Service which counts the reactions it handles. It fails to handle the first failures reactions.
+type reactionCounter struct {
+	slack.Service
+	added    int
+	failures int
+}
+
+func (rc *reactionCounter) ReactionAddedEvent(e *slackevents.ReactionAddedEvent) error {
+	if rc.failures > 0 {
+		rc.failures--
+		return errors.New("slack is down")
+	}
+	rc.added++
+	return nil
+}
+
+// processedEvents is an in-memory dinny.ProcessedEventService.
+type processedEvents map[string]bool
+
+func (pe processedEvents) CreateProcessedEvent(eventID string) (bool, error) {
+	if pe[eventID] {
+		return false, nil
+	}
+	pe[eventID] = true
+	return true, nil
+}
+
+func (pe processedEvents) DeleteProcessedEvent(eventID string) error {
+	delete(pe, eventID)
+	return nil
+}
+
+func (pe processedEvents) DeleteProcessedEventsBefore(t time.Time) (int64, error) {
+	return 0, nil
+}
+
+// reactionEvent returns a reaction_added event with the given event_id.
+func reactionEvent(id string) slackevents.EventsAPIEvent {
+	return slackevents.EventsAPIEvent{
+		Type: slackevents.CallbackEvent,
+		Data: &slackevents.EventsAPICallbackEvent{EventID: id},
+		InnerEvent: slackevents.EventsAPIInnerEvent{
+			Type: "reaction_added",
+			Data: &slackevents.ReactionAddedEvent{Reaction: "+1"},
+		},
+	}
+}
+
+// TestProcessEvent ensures events delivered more than once are only processed once.
+func TestProcessEvent(t *testing.T) {
+	counter := &reactionCounter{}
+	s := &Server{
+		Logger:                log.New(io.Discard, "", 0),
+		SlackService:          counter,
+		ProcessedEventService: processedEvents{},
+	}
+	for _, id := range []string{"Ev1", "Ev1", "Ev2"} {
+		s.processEvent(slackEvent{id: id, event: reactionEvent(id)})
+	}
+	if counter.added != 2 {
+		t.Errorf("processed %d reactions, want 2", counter.added)
+	}
+}
+
+// TestProcessEventFailure ensures a retry of an event which failed to be processed is processed again.
+func TestProcessEventFailure(t *testing.T) {
+	counter := &reactionCounter{failures: 1}
+	s := &Server{
+		Logger:                log.New(io.Discard, "", 0),
+		SlackService:          counter,
+		ProcessedEventService: processedEvents{},
+	}
+	for ii := 0; ii < 3; ii++ {
+		s.processEvent(slackEvent{id: "Ev1", event: reactionEvent("Ev1")})
+	}
+	if counter.added != 1 {
+		t.Errorf("processed %d reactions, want 1", counter.added)
+	}
+}
+
+// TestHandleCallbackEvent ensures events are queued and acknowledged, unless the queue is full or they are retries of timed out deliveries which can't be deduplicated.
+func TestHandleCallbackEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		store      bool
+		queueSize  int
+		retry      string
+		want       int
+		wantQueued int
+	}{
+		{"first delivery", false, 1, "", http.StatusOK, 1},
+		{"queue full", false, 0, "", http.StatusServiceUnavailable, 0},
+		{"retry after timeout", false, 1, "http_timeout", http.StatusOK, 0},
+		{"retry after error", false, 1, "http_error", http.StatusOK, 1},
+		{"retry after timeout with store", true, 1, "http_timeout", http.StatusOK, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{
+				Logger: log.New(io.Discard, "", 0),
+				events: make(chan slackEvent, tt.queueSize),
+			}
+			if tt.store {
+				s.ProcessedEventService = processedEvents{}
+			}
+			r := httptest.NewRequest(http.MethodPost, "/event", nil)
+			if tt.retry != "" {
+				r.Header.Set("X-Slack-Retry-Num", "1")
+				r.Header.Set("X-Slack-Retry-Reason", tt.retry)
+			}
+			w := httptest.NewRecorder()
+			s.handleCallbackEvent(w, r, reactionEvent("Ev1"))
+			if w.Code != tt.want {
+				t.Errorf("handleCallbackEvent() status = %d, want %d", w.Code, tt.want)
+			}
+			if len(s.events) != tt.wantQueued {
+				t.Errorf("queued %d events, want %d", len(s.events), tt.wantQueued)
+			}
+		})
+	}
+}
+
+// TestNewServerQueuesEvents ensures events can be queued as soon as the server is created rather than only once it's opened.
+func TestNewServerQueuesEvents(t *testing.T) {
+	s := NewServer(log.New(io.Discard, "", 0), "", nil, nil, nil)
+	if !s.enqueueEvent(reactionEvent("Ev1")) {
+		t.Fatal("enqueueEvent() = false before Open, want true")
+	}
+	if cap(s.events) != DefaultEventQueueSize {
+		t.Errorf("cap(events) = %d, want %d", cap(s.events), DefaultEventQueueSize)
+	}
+}
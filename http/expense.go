@@ -0,0 +1,267 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateExpenseRequest represents a request to log a grocery cost against the meal on Date.
+// MemberSlackUID, the member who paid, defaults to the owner of the API token. Only leaders may log the expenses of other members.
+type CreateExpenseRequest struct {
+	MemberSlackUID string     `json:"memberSlackUID,omitempty"`
+	Date           dinny.Date `json:"date"`
+	AmountCents    int64      `json:"amountCents"`
+	Note           string     `json:"note"`
+}
+
+// CreatePaymentRequest represents a request to record a payment from one member to another to settle up.
+// FromSlackUID defaults to the owner of the API token. Only leaders may record the payments of other members.
+type CreatePaymentRequest struct {
+	FromSlackUID string `json:"fromSlackUID,omitempty"`
+	ToSlackUID   string `json:"toSlackUID"`
+	AmountCents  int64  `json:"amountCents"`
+	Note         string `json:"note"`
+}
+
+// requestMember returns the member identified by slackUID, defaulting to the owner of the API token.
+// Writes an error response and returns nil if the member can't be found or the owner may not act on behalf of them.
+func (s *Server) requestMember(w http.ResponseWriter, r *http.Request, slackUID string, action string) *dinny.Member {
+	owner, err := s.tokenOwner(r)
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("the api token doesn't belong to a member"))
+		return nil
+	}
+	if slackUID == "" || slackUID == owner.SlackUID {
+		return owner
+	}
+	if !owner.Leader {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(fmt.Sprintf("only leaders may %s", action)))
+		return nil
+	}
+	member, err := s.rotation(r).MemberService.FindMemberBySlackUID(slackUID)
+	if errors.Is(err, dinny.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("member %s not found", slackUID)))
+		return nil
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("requestMember FindMemberBySlackUID: %s", err.Error())
+		return nil
+	}
+	return member
+}
+
+// handleListExpenses is a handler for listing the expenses logged for the meal on the date query parameter.
+func (s *Server) handleListExpenses(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	date, err := dinny.ParseDate(r.URL.Query().Get("date"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	meal, err := rot.MealService.FindMealByDate(date)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleListExpenses FindMealByDate: %s", err.Error())
+		return
+	}
+	expenses, err := rot.ExpenseService.ListExpensesByMeal(meal.ID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleListExpenses ListExpensesByMeal: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(expenses)
+}
+
+// handleCreateExpense is a handler for the expense command. Only the cooks of the meal and leaders may have paid for it.
+func (s *Server) handleCreateExpense(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req CreateExpenseRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreateExpense: %s", err.Error())
+		return
+	}
+	if req.AmountCents <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("amount must be positive"))
+		return
+	}
+
+	member := s.requestMember(w, r, req.MemberSlackUID, "log the expenses of other members")
+	if member == nil {
+		return
+	}
+	meal, err := rot.MealService.FindMealByDate(req.Date)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreateExpense FindMealByDate: %s", err.Error())
+		return
+	}
+	if !meal.HasCook(member.SlackUID) && !member.Leader {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(fmt.Sprintf("only the cooks and leaders may log expenses for the meal on %s", meal.Date)))
+		return
+	}
+
+	expense := &dinny.Expense{
+		MealID:      meal.ID,
+		MemberID:    member.ID,
+		AmountCents: req.AmountCents,
+		Note:        req.Note,
+	}
+	err = rot.ExpenseService.CreateExpense(expense)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreateExpense CreateExpense: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(expense)
+}
+
+// handleDeleteExpense is a handler for deleting an expense logged by mistake.
+// Only the member who paid, the cooks of the meal and leaders may delete an expense.
+func (s *Server) handleDeleteExpense(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	owner, err := s.tokenOwner(r)
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("the api token doesn't belong to a member"))
+		return
+	}
+	expense, err := rot.ExpenseService.FindExpenseByID(id)
+	if errors.Is(err, dinny.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("expense %d not found", id)))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleDeleteExpense FindExpenseByID: %s", err.Error())
+		return
+	}
+	if expense.MemberID != owner.ID && !owner.Leader {
+		meal, err := rot.MealService.FindMealByID(expense.MealID)
+		if err != nil {
+			w.WriteHeader(mealErrorStatus(err))
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("handleDeleteExpense FindMealByID: %s", err.Error())
+			return
+		}
+		if !meal.HasCook(owner.SlackUID) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("only the member who paid, the cooks and leaders may delete an expense"))
+			return
+		}
+	}
+
+	err = rot.ExpenseService.DeleteExpense(id)
+	if errors.Is(err, dinny.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("expense %d not found", id)))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleDeleteExpense DeleteExpense: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleCreatePayment is a handler for the settle_up command.
+func (s *Server) handleCreatePayment(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req CreatePaymentRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreatePayment: %s", err.Error())
+		return
+	}
+	if req.AmountCents <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("amount must be positive"))
+		return
+	}
+
+	from := s.requestMember(w, r, req.FromSlackUID, "record the payments of other members")
+	if from == nil {
+		return
+	}
+	to, err := rot.MemberService.FindMemberBySlackUID(req.ToSlackUID)
+	if errors.Is(err, dinny.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("member %s not found", req.ToSlackUID)))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreatePayment FindMemberBySlackUID: %s", err.Error())
+		return
+	}
+	if from.ID == to.ID {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("members can't pay themselves"))
+		return
+	}
+
+	payment := &dinny.Payment{
+		FromMemberID: from.ID,
+		ToMemberID:   to.ID,
+		AmountCents:  req.AmountCents,
+		Note:         req.Note,
+	}
+	err = rot.ExpenseService.CreatePayment(payment)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreatePayment CreatePayment: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(payment)
+}
+
+// handleListBalances is a handler for the balances command.
+func (s *Server) handleListBalances(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	balances, err := rot.ExpenseService.ListBalances()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleListBalances ListBalances: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(balances)
+}
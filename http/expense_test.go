@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// meals is a dinny.MealService which knows meals by their date.
+type meals struct {
+	dinny.MealService
+	byDate map[dinny.Date]*dinny.Meal
+}
+
+func (ms meals) FindMealByDate(date dinny.Date) (*dinny.Meal, error) {
+	meal, ok := ms.byDate[date]
+	if !ok {
+		return nil, dinny.ErrNotFound
+	}
+	return meal, nil
+}
+
+func (ms meals) ListMeals(from dinny.Date, to dinny.Date) ([]*dinny.Meal, error) {
+	var list []*dinny.Meal
+	for date, meal := range ms.byDate {
+		if !date.Before(from) && !to.Before(date) {
+			list = append(list, meal)
+		}
+	}
+	return list, nil
+}
+
+// expenses is a dinny.ExpenseService which keeps the expenses it's given.
+type expenses struct {
+	dinny.ExpenseService
+	created *[]*dinny.Expense
+}
+
+func (es expenses) CreateExpense(e *dinny.Expense) error {
+	*es.created = append(*es.created, e)
+	return nil
+}
+
+// TestCreateExpense ensures only the cooks of a meal and leaders may log its expenses.
+func TestCreateExpense(t *testing.T) {
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	s := NewServer(log.New(io.Discard, "", 0), "", members{byID: map[int64]*dinny.Member{
+		1: {ID: 1, SlackUID: "U1"},
+		2: {ID: 2, SlackUID: "U2", Leader: true},
+		3: {ID: 3, SlackUID: "U3"},
+	}}, meals{byDate: map[dinny.Date]*dinny.Meal{
+		date: {ID: 1, Date: date, CookSlackUID: "U1"},
+	}}, nil)
+	var created []*dinny.Expense
+	s.ExpenseService = expenses{created: &created}
+	s.TokenService = tokens{bySecret: map[string]*dinny.Token{
+		"cook":   {MemberID: 1, Scope: dinny.TokenScopeWrite},
+		"leader": {MemberID: 2, Scope: dinny.TokenScopeWrite},
+		"eater":  {MemberID: 3, Scope: dinny.TokenScopeWrite},
+	}}
+	tests := []struct {
+		secret string
+		want   int
+	}{
+		{"cook", http.StatusCreated},
+		{"leader", http.StatusCreated},
+		{"eater", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.secret, func(t *testing.T) {
+			body, err := json.Marshal(CreateExpenseRequest{Date: date, AmountCents: 2000})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest(http.MethodPost, "/cmd/expenses", bytes.NewReader(body))
+			r.Header.Set("Authorization", "Bearer "+tt.secret)
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("POST /cmd/expenses = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+	if len(created) != 2 {
+		t.Errorf("created %d expenses, want 2", len(created))
+	}
+}
@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// SetGuestsRequest represents a request to set the number of guests a member brings to the meal on Date.
+// MemberSlackUID defaults to the owner of the API token. Only leaders may set the guests of other members.
+type SetGuestsRequest struct {
+	MemberSlackUID string     `json:"memberSlackUID,omitempty"`
+	Date           dinny.Date `json:"date"`
+	Count          int64      `json:"count"`
+}
+
+// handleListGuests is a handler for listing the guests brought to the meal on the date query parameter.
+func (s *Server) handleListGuests(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	date, err := dinny.ParseDate(r.URL.Query().Get("date"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	meal, err := rot.MealService.FindMealByDate(date)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleListGuests FindMealByDate: %s", err.Error())
+		return
+	}
+	guests, err := rot.AttendanceService.ListGuestsByMeal(meal.ID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleListGuests ListGuestsByMeal: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(guests)
+}
+
+// handleSetGuests is a handler for the guests command. Guests are set like a guest reaction, so they can't be changed
+// after the RSVP deadline and the cooks are told about changes after the headcount.
+func (s *Server) handleSetGuests(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req SetGuestsRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetGuests: %s", err.Error())
+		return
+	}
+	if req.Count < 0 || req.Count > dinny.MaxGuests {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("count must be between 0 and %d", dinny.MaxGuests)))
+		return
+	}
+
+	owner, err := s.tokenOwner(r)
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("the api token doesn't belong to a member"))
+		return
+	}
+	member := owner
+	if req.MemberSlackUID != "" && req.MemberSlackUID != owner.SlackUID {
+		if !owner.Leader {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("only leaders may set the guests of other members"))
+			return
+		}
+		member, err = rot.MemberService.FindMemberBySlackUID(req.MemberSlackUID)
+		if errors.Is(err, dinny.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(fmt.Sprintf("member %s not found", req.MemberSlackUID)))
+			return
+		} else if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("handleSetGuests FindMemberBySlackUID: %s", err.Error())
+			return
+		}
+	}
+
+	err = rot.SlackService.SetGuests(req.Date, member.SlackUID, req.Count)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetGuests SlackService.SetGuests: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
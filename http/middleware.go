@@ -0,0 +1,185 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// DefaultSlackReplayWindow is the maximum age of a Slack request before it is rejected as a possible replay.
+const DefaultSlackReplayWindow = 5 * time.Minute
+
+// slackStats counts the outcomes of requests made to the Slack routes and is published at /debug/vars to read tokens.
+var slackStats = expvar.NewMap("slack")
+
+// verifySlackSignature computes the expected signature of a Slack request and compares it against the provided one.
+// See https://api.slack.com/authentication/verifying-requests-from-slack.
+func verifySlackSignature(signingSecret string, header http.Header, body []byte, now time.Time, window time.Duration) error {
+	signature := header.Get("X-Slack-Signature")
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	if signature == "" || timestamp == "" {
+		return fmt.Errorf("verifySlackSignature: missing signature headers")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("verifySlackSignature strconv.ParseInt: %w", err)
+	}
+	age := now.Sub(time.Unix(ts, 0))
+	if age < 0 {
+		age = -age
+	}
+	if age > window {
+		return fmt.Errorf("verifySlackSignature: timestamp %s outside of the %s replay window", timestamp, window)
+	}
+
+	provided, err := hex.DecodeString(strings.TrimPrefix(signature, "v0="))
+	if err != nil {
+		return fmt.Errorf("verifySlackSignature hex.DecodeString: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	fmt.Fprintf(mac, "v0:%s:", timestamp)
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), provided) {
+		return fmt.Errorf("verifySlackSignature: signature mismatch")
+	}
+	return nil
+}
+
+// requireSlackSignature is a middleware which rejects requests not signed with the Slack app's signing secret.
+func (s *Server) requireSlackSignature(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.SigningSecret == "" {
+			slackStats.Add("rejected", 1)
+			s.Logger.Printf("requireSlackSignature: rejected request from %s: no signing secret configured", r.RemoteAddr)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			s.Logger.Printf("requireSlackSignature io.ReadAll: %s", err.Error())
+			return
+		}
+
+		window := s.SlackReplayWindow
+		if window == 0 {
+			window = DefaultSlackReplayWindow
+		}
+		err = verifySlackSignature(s.SigningSecret, r.Header, body, time.Now(), window)
+		if err != nil {
+			slackStats.Add("rejected", 1)
+			s.Logger.Printf("requireSlackSignature: rejected request from %s: %s", r.RemoteAddr, err.Error())
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		slackStats.Add("verified", 1)
+
+		// Hand the consumed body to the next handler.
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// tokenContextKey is the context key under which the authenticated API token is stored.
+type tokenContextKey struct{}
+
+// tokenFromContext returns the API token which authenticated the request, if any.
+func tokenFromContext(ctx context.Context) *dinny.Token {
+	token, _ := ctx.Value(tokenContextKey{}).(*dinny.Token)
+	return token
+}
+
+// requireToken is a middleware which rejects requests without a valid "Authorization: Bearer <token>" header.
+// The authenticated token and the rotation of its owner are attached to the request's context.
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		secret := strings.TrimPrefix(header, "Bearer ")
+		if secret == header || secret == "" || s.TokenService == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("missing or malformed api token"))
+			return
+		}
+		token, err := s.TokenService.FindTokenByHash(dinny.HashTokenSecret(secret))
+		if errors.Is(err, dinny.ErrNotFound) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("invalid api token"))
+			s.Logger.Printf("requireToken: rejected request from %s: invalid api token", r.RemoteAddr)
+			return
+		} else if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("requireToken FindTokenByHash: %s", err.Error())
+			return
+		}
+		rot, err := s.tokenRotation(token)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("requireToken tokenRotation: %s", err.Error())
+			return
+		}
+		ctx := context.WithValue(r.Context(), tokenContextKey{}, token)
+		next.ServeHTTP(w, withRotation(r.WithContext(ctx), rot))
+	})
+}
+
+// requireScope returns a middleware which rejects requests whose API token doesn't grant scope.
+// Must be used after requireToken.
+func (s *Server) requireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := tokenFromContext(r.Context())
+			if token == nil || !token.Allows(scope) {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(fmt.Sprintf("api token lacks the %s scope", scope)))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// tokenOwner returns the member owning the API token which authenticated the request.
+// Returns ErrNotFound if the request isn't authenticated or the member no longer exists.
+func (s *Server) tokenOwner(r *http.Request) (*dinny.Member, error) {
+	token := tokenFromContext(r.Context())
+	if token == nil {
+		return nil, dinny.ErrNotFound
+	}
+	return s.rotation(r).MemberService.FindMemberByID(token.MemberID)
+}
+
+// requireLeader is a middleware which rejects requests whose API token doesn't belong to a dinner rotation leader.
+// Must be used after requireToken.
+func (s *Server) requireLeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		member, err := s.tokenOwner(r)
+		if err != nil && !errors.Is(err, dinny.ErrNotFound) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("requireLeader FindMemberByID: %s", err.Error())
+			return
+		}
+		if member == nil || !member.Leader {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("only leaders may do this"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
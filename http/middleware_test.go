@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// sign returns the Slack signature headers for body at the given time.
+func sign(secret string, body []byte, at time.Time) http.Header {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:", timestamp)
+	mac.Write(body)
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", timestamp)
+	header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return header
+}
+
+// TestVerifySlackSignature ensures only correctly signed, recent requests are accepted.
+func TestVerifySlackSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"type":"event_callback"}`)
+	tests := []struct {
+		name    string
+		header  http.Header
+		body    []byte
+		wantErr bool
+	}{
+		{"valid", sign("secret", body, now), body, false},
+		{"valid-within-window", sign("secret", body, now.Add(-4*time.Minute)), body, false},
+		{"wrong-secret", sign("other", body, now), body, true},
+		{"tampered-body", sign("secret", body, now), []byte(`{"type":"url_verification"}`), true},
+		{"replayed", sign("secret", body, now.Add(-6*time.Minute)), body, true},
+		{"missing-headers", http.Header{}, body, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifySlackSignature("secret", tt.header, tt.body, now, DefaultSlackReplayWindow)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifySlackSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// tokens is a dinny.TokenService which knows tokens by their secret.
+type tokens struct {
+	dinny.TokenService
+	bySecret map[string]*dinny.Token
+}
+
+func (ts tokens) ListTokens() ([]*dinny.Token, error) {
+	return []*dinny.Token{}, nil
+}
+
+func (ts tokens) FindTokenByHash(hash string) (*dinny.Token, error) {
+	for secret, token := range ts.bySecret {
+		if dinny.HashTokenSecret(secret) == hash {
+			return token, nil
+		}
+	}
+	return nil, dinny.ErrNotFound
+}
+
+// members is a dinny.MemberService which knows members by their ID.
+type members struct {
+	dinny.MemberService
+	byID map[int64]*dinny.Member
+}
+
+func (ms members) FindMemberByID(id int64) (*dinny.Member, error) {
+	m, ok := ms.byID[id]
+	if !ok {
+		return nil, dinny.ErrNotFound
+	}
+	return m, nil
+}
+
+func (ms members) ListMembers() ([]*dinny.Member, error) {
+	var list []*dinny.Member
+	for _, m := range ms.byID {
+		list = append(list, m)
+	}
+	return list, nil
+}
+
+// TestRoutes ensures Slack events are accepted over POST and the debug variables require an API token.
+func TestRoutes(t *testing.T) {
+	s := NewServer(log.New(io.Discard, "", 0), "", members{byID: map[int64]*dinny.Member{1: {ID: 1}}}, nil, nil)
+	s.SigningSecret = "secret"
+	s.TokenService = tokens{bySecret: map[string]*dinny.Token{"reader": {MemberID: 1, Scope: dinny.TokenScopeRead}}}
+
+	body := []byte(`{"type":"url_verification","challenge":"abc"}`)
+	r := httptest.NewRequest(http.MethodPost, "/event", bytes.NewReader(body))
+	r.Header = sign("secret", body, time.Now())
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Body.String() != "abc" {
+		t.Errorf("POST /event = %d %q, want 200 \"abc\"", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		want   int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"invalid token", "other", http.StatusUnauthorized},
+		{"read token", "reader", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/debug/vars", nil)
+			if tt.secret != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.secret)
+			}
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("GET /debug/vars = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+// TestRequireLeader ensures only the write tokens of leaders reach the leader-only routes.
+func TestRequireLeader(t *testing.T) {
+	s := NewServer(log.New(io.Discard, "", 0), "", members{byID: map[int64]*dinny.Member{
+		1: {ID: 1, Leader: true},
+		2: {ID: 2},
+	}}, nil, nil)
+	s.TokenService = tokens{bySecret: map[string]*dinny.Token{
+		"leader":        {MemberID: 1, Scope: dinny.TokenScopeWrite},
+		"leader-reader": {MemberID: 1, Scope: dinny.TokenScopeRead},
+		"member":        {MemberID: 2, Scope: dinny.TokenScopeWrite},
+	}}
+	tests := []struct {
+		secret string
+		want   int
+	}{
+		{"leader", http.StatusOK},
+		{"leader-reader", http.StatusForbidden},
+		{"member", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.secret, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/cmd/tokens/", nil)
+			r.Header.Set("Authorization", "Bearer "+tt.secret)
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("GET /cmd/tokens = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/slack"
+)
+
+// Rotation holds the services of a single dinner rotation, scoped to its members and meals.
+type Rotation struct {
+	// Name of the rotation, used in logs.
+	Name string
+
+	// Channel is the ID of the Slack channel the rotation posts its meals to.
+	// Slack requests made from this channel are routed to the rotation.
+	Channel string
+
+	MemberService         dinny.MemberService
+	MealService           dinny.MealService
+	SlackService          slack.Service
+	AvailabilityService   dinny.AvailabilityService
+	AttendanceService     dinny.AttendanceService
+	DietaryProfileService dinny.DietaryProfileService
+	ExpenseService        dinny.ExpenseService
+	StatsService          dinny.StatsService
+	SeasonService         dinny.SeasonService
+	TokenService          dinny.TokenService
+}
+
+// defaultRotation returns the rotation formed by the services set on the Server.
+func (s *Server) defaultRotation() *Rotation {
+	return &Rotation{
+		Name:                  "default",
+		MemberService:         s.MemberService,
+		MealService:           s.MealService,
+		SlackService:          s.SlackService,
+		AvailabilityService:   s.AvailabilityService,
+		AttendanceService:     s.AttendanceService,
+		DietaryProfileService: s.DietaryProfileService,
+		ExpenseService:        s.ExpenseService,
+		StatsService:          s.StatsService,
+		SeasonService:         s.SeasonService,
+		TokenService:          s.TokenService,
+	}
+}
+
+// allRotations returns the default rotation followed by the additional rotations.
+func (s *Server) allRotations() []*Rotation {
+	return append([]*Rotation{s.defaultRotation()}, s.Rotations...)
+}
+
+// tokenRotation returns the rotation of the member owning the API token.
+// Falls back to the default rotation if no rotation knows the member.
+func (s *Server) tokenRotation(token *dinny.Token) (*Rotation, error) {
+	for _, rot := range s.allRotations() {
+		if rot.MemberService == nil {
+			continue
+		}
+		_, err := rot.MemberService.FindMemberByID(token.MemberID)
+		if err == nil {
+			return rot, nil
+		} else if !errors.Is(err, dinny.ErrNotFound) {
+			return nil, err
+		}
+	}
+	return s.defaultRotation(), nil
+}
+
+// slackRotation returns the rotation a Slack request made by a user from a channel belongs to.
+// The rotation posting to the channel wins, otherwise the first rotation the user is a member of, e.g. for direct messages.
+// Falls back to the default rotation.
+func (s *Server) slackRotation(channelID, userID string) *Rotation {
+	for _, rot := range s.Rotations {
+		if channelID != "" && rot.Channel == channelID {
+			return rot
+		}
+	}
+	if userID != "" && len(s.Rotations) > 0 {
+		for _, rot := range s.allRotations() {
+			if rot.MemberService == nil {
+				continue
+			}
+			if _, err := rot.MemberService.FindMemberBySlackUID(userID); err == nil {
+				return rot
+			}
+		}
+	}
+	return s.defaultRotation()
+}
+
+// rotationContextKey is the context key under which the rotation of a request is stored.
+type rotationContextKey struct{}
+
+// rotation returns the rotation the request was routed to, or the default rotation.
+func (s *Server) rotation(r *http.Request) *Rotation {
+	if rot, ok := r.Context().Value(rotationContextKey{}).(*Rotation); ok {
+		return rot
+	}
+	return s.defaultRotation()
+}
+
+// withRotation returns a copy of r routed to rot.
+func withRotation(r *http.Request, rot *Rotation) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), rotationContextKey{}, rot))
+}
@@ -0,0 +1,47 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// slackMembers is a dinny.MemberService which knows members by their Slack UID.
+type slackMembers struct {
+	dinny.MemberService
+	uids map[string]bool
+}
+
+func (sm slackMembers) FindMemberBySlackUID(slackUID string) (*dinny.Member, error) {
+	if !sm.uids[slackUID] {
+		return nil, dinny.ErrNotFound
+	}
+	return &dinny.Member{SlackUID: slackUID}, nil
+}
+
+// TestSlackRotation ensures Slack requests are routed by channel first, then by membership, and otherwise to the default rotation.
+func TestSlackRotation(t *testing.T) {
+	second := &Rotation{Name: "second", Channel: "C2", MemberService: slackMembers{uids: map[string]bool{"U2": true}}}
+	s := &Server{
+		MemberService: slackMembers{uids: map[string]bool{"U1": true}},
+		Rotations:     []*Rotation{second},
+	}
+	tests := []struct {
+		name    string
+		channel string
+		user    string
+		want    string
+	}{
+		{"rotation channel", "C2", "U1", "second"},
+		{"other channel", "C1", "U1", "default"},
+		{"direct message", "D1", "U2", "second"},
+		{"unknown member", "D1", "U3", "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.slackRotation(tt.channel, tt.user).Name; got != tt.want {
+				t.Errorf("slackRotation() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/go-chi/chi/v5"
+)
+
+// CloseSeasonRequest represents a request to close the season in progress on To, which defaults to today.
+// To can't be any other day, as the counters archived are those members have when the season is closed.
+type CloseSeasonRequest struct {
+	Name string     `json:"name"`
+	To   dinny.Date `json:"to"`
+}
+
+// handleCloseSeason is a handler for the season command. Only leaders may close a season.
+func (s *Server) handleCloseSeason(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req CloseSeasonRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCloseSeason: %s", err.Error())
+		return
+	}
+	if req.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("a season needs a name"))
+		return
+	}
+	today := s.today()
+	if req.To == (dinny.Date{}) {
+		req.To = today
+	}
+	if req.To != today {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("the season in progress can only be closed today, " + today.String()))
+		return
+	}
+
+	from, err := rot.SeasonService.CurrentSeasonStart()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCloseSeason CurrentSeasonStart: %s", err.Error())
+		return
+	}
+	if req.To.Before(from) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte("the season in progress started on " + from.String()))
+		return
+	}
+
+	season := &dinny.Season{Name: req.Name, To: req.To}
+	err = rot.SeasonService.CloseSeason(season)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCloseSeason CloseSeason: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(season)
+}
+
+// handleListSeasons is a handler for listing the closed seasons.
+func (s *Server) handleListSeasons(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	seasons, err := rot.SeasonService.ListSeasons()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleListSeasons ListSeasons: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(seasons)
+}
+
+// handleFindSeason is a handler for retrieving a closed season along with the archived counters of its members.
+func (s *Server) handleFindSeason(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	season, err := rot.SeasonService.FindSeasonByID(id)
+	if errors.Is(err, dinny.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("season not found"))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleFindSeason FindSeasonByID: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(season)
+}
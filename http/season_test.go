@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// seasons is a dinny.SeasonService which keeps the seasons it closes.
+type seasons struct {
+	dinny.SeasonService
+	closed *[]*dinny.Season
+}
+
+func (ss seasons) CurrentSeasonStart() (dinny.Date, error) {
+	return dinny.Date{}, nil
+}
+
+func (ss seasons) CloseSeason(s *dinny.Season) error {
+	*ss.closed = append(*ss.closed, s)
+	return nil
+}
+
+// TestCloseSeason ensures the season in progress can only be closed today.
+func TestCloseSeason(t *testing.T) {
+	s := NewServer(log.New(io.Discard, "", 0), "", members{byID: map[int64]*dinny.Member{
+		1: {ID: 1, SlackUID: "U1", Leader: true},
+	}}, nil, nil)
+	var closed []*dinny.Season
+	s.SeasonService = seasons{closed: &closed}
+	s.TokenService = tokens{bySecret: map[string]*dinny.Token{"leader": {MemberID: 1, Scope: dinny.TokenScopeWrite}}}
+
+	today := dinny.DateOf(time.Now())
+	tests := []struct {
+		name string
+		to   dinny.Date
+		want int
+	}{
+		{"default", dinny.Date{}, http.StatusCreated},
+		{"today", today, http.StatusCreated},
+		{"yesterday", today.AddDays(-1), http.StatusBadRequest},
+		{"tomorrow", today.AddDays(1), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(CloseSeasonRequest{Name: tt.name, To: tt.to})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest(http.MethodPost, "/cmd/seasons", bytes.NewReader(body))
+			r.Header.Set("Authorization", "Bearer leader")
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("POST /cmd/seasons = %d %q, want %d", w.Code, w.Body.String(), tt.want)
+			}
+		})
+	}
+	for _, season := range closed {
+		if season.To != today {
+			t.Errorf("%s To = %s, want %s", season.Name, season.To, today)
+		}
+	}
+	if len(closed) != 2 {
+		t.Errorf("closed %d seasons, want 2", len(closed))
+	}
+}
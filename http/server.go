@@ -3,17 +3,21 @@ package rest
 import (
 	"encoding/json"
 	"errors"
+	"expvar"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/cron"
 	"github.com/ddritzenhoff/dinny/slack"
 	"github.com/go-chi/chi/v5"
+	slackgo "github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 )
 
@@ -32,6 +36,49 @@ type Server struct {
 	MemberService dinny.MemberService
 	MealService   dinny.MealService
 	SlackService  slack.Service
+
+	// AvailabilityService tracks when members can't cook or aren't eating.
+	AvailabilityService dinny.AvailabilityService
+
+	// AttendanceService tracks who eats which meal and the guests they bring.
+	AttendanceService dinny.AttendanceService
+
+	// DietaryProfileService tracks the dietary restrictions of members.
+	DietaryProfileService dinny.DietaryProfileService
+
+	// ExpenseService tracks the grocery costs of meals and the payments settling them.
+	ExpenseService dinny.ExpenseService
+
+	// StatsService computes per-member stats over the meal history.
+	StatsService dinny.StatsService
+
+	// SeasonService closes seasons and archives the counters of past ones.
+	SeasonService dinny.SeasonService
+
+	// TokenService authenticates the /cmd routes. Every /cmd request is rejected if unset.
+	TokenService dinny.TokenService
+
+	// Rotations are the rotations served besides the default one formed by the services above. Optional.
+	// Slack requests are routed by channel and /cmd requests by the member owning the API token.
+	Rotations []*Rotation
+
+	// Scheduler runs the recurring jobs. Optional.
+	Scheduler *cron.Scheduler
+
+	// SigningSecret is the Slack app's signing secret used to verify requests made by Slack.
+	SigningSecret string
+
+	// Location is the location in which meal days start. Defaults to time.Local.
+	Location *time.Location
+
+	// SlackReplayWindow is the maximum age of a signed Slack request. Defaults to DefaultSlackReplayWindow.
+	SlackReplayWindow time.Duration
+
+	// ProcessedEventService remembers the processed Slack events so that retried events are only processed once. Optional.
+	ProcessedEventService dinny.ProcessedEventService
+
+	// events queues the Slack events to be processed. It's created by NewServer so events can be queued before Open.
+	events chan slackEvent
 }
 
 // NewServer creates a new dinny REST server instance.
@@ -44,16 +91,57 @@ func NewServer(logger *log.Logger, addr string, memberService dinny.MemberServic
 		MemberService: memberService,
 		MealService:   mealService,
 		SlackService:  slackService,
+		events:        make(chan slackEvent, DefaultEventQueueSize),
 	}
 
-	s.router.Put("/event", s.handleSlackEvent)
+	s.router.With(s.requireSlackSignature).Post("/event", s.handleSlackEvent)
+	s.router.With(s.requireSlackSignature).Post("/slash", s.handleSlashCommand)
+	s.router.With(s.requireSlackSignature).Post("/interactivity", s.handleInteractivity)
 	s.router.Get("/ping", s.handlePing)
+	s.router.With(s.requireToken, s.requireScope(dinny.TokenScopeRead)).Get("/debug/vars", expvar.Handler().ServeHTTP)
+	s.router.With(s.requireToken, s.requireScope(dinny.TokenScopeRead)).Get("/stats", s.handleStats)
 	s.router.Route("/cmd", func(r chi.Router) {
-		r.Put("/assign-cooks", s.handleAssignCooks)
-		r.Get("/eating-tomorrow", s.handleEatingTomorrow)
-		r.Get("/members", s.handleMembers)
-		r.Get("/upcoming-cooks", s.handleUpcomingCooks)
-		r.Get("/weekly-update", s.handleWeeklyUpdate)
+		r.Use(s.requireToken)
+		r.Group(func(r chi.Router) {
+			r.Use(s.requireScope(dinny.TokenScopeRead))
+			r.Get("/availabilities", s.handleListAvailabilities)
+			r.Get("/balances", s.handleListBalances)
+			r.Get("/diets", s.handleListDiets)
+			r.Get("/expenses", s.handleListExpenses)
+			r.Get("/guests", s.handleListGuests)
+			r.Get("/members", s.handleMembers)
+			r.Post("/propose-schedule", s.handleProposeSchedule)
+			r.Get("/schedule", s.handleSchedule)
+			r.Get("/seasons", s.handleListSeasons)
+			r.Get("/seasons/{id}", s.handleFindSeason)
+			r.Get("/upcoming-cooks", s.handleUpcomingCooks)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(s.requireScope(dinny.TokenScopeWrite))
+			r.Put("/assign-cooks", s.handleAssignCooks)
+			r.Post("/availabilities", s.handleCreateAvailability)
+			r.Post("/cancel-meal", s.handleCancelMeal)
+			r.Post("/close-out", s.handleCloseOut)
+			r.Put("/diet", s.handleSetDiet)
+			r.Post("/expenses", s.handleCreateExpense)
+			r.Delete("/availabilities/{id}", s.handleDeleteAvailability)
+			r.Delete("/expenses/{id}", s.handleDeleteExpense)
+			r.Get("/eating-tomorrow", s.handleEatingTomorrow)
+			r.Put("/guests", s.handleSetGuests)
+			r.Post("/headcount", s.handleHeadcount)
+			r.Put("/menu", s.handleSetMenu)
+			r.Post("/payments", s.handleCreatePayment)
+			r.Put("/rsvp-deadline", s.handleSetRSVPDeadline)
+			r.Post("/swap-cooks", s.handleSwapCooks)
+			r.Get("/weekly-update", s.handleWeeklyUpdate)
+		})
+		r.With(s.requireScope(dinny.TokenScopeWrite), s.requireLeader).Post("/seasons", s.handleCloseSeason)
+		r.Route("/tokens", func(r chi.Router) {
+			r.Use(s.requireScope(dinny.TokenScopeWrite), s.requireLeader)
+			r.Get("/", s.handleListTokens)
+			r.Post("/", s.handleCreateToken)
+			r.Delete("/{id}", s.handleRevokeToken)
+		})
 	})
 
 	s.server.Handler = s.router
@@ -68,6 +156,7 @@ func (s *Server) Open() error {
 	if err != nil {
 		return fmt.Errorf("Open net.Listen: %w", err)
 	}
+	go s.processEvents()
 	go s.server.Serve(s.ln)
 	s.Logger.Printf("Server listening on %s", s.ln.Addr())
 	return nil
@@ -92,8 +181,8 @@ func (s *Server) handleSlackEvent(w http.ResponseWriter, r *http.Request) {
 		s.handleCallbackEvent(w, r, event)
 	default:
 		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(err.Error()))
-		s.Logger.Printf("handleSlackEvent: %s", err.Error())
+		w.Write([]byte(fmt.Sprintf("unexpected event type %q", event.Type)))
+		s.Logger.Printf("handleSlackEvent: unexpected event type %q", event.Type)
 		return
 	}
 }
@@ -101,7 +190,7 @@ func (s *Server) handleSlackEvent(w http.ResponseWriter, r *http.Request) {
 // handleSlackURLVerification verifies the slack request.
 func (s *Server) handleSlackURLVerification(w http.ResponseWriter, r *http.Request, body []byte) {
 	var ch *slackevents.ChallengeResponse
-	err := json.Unmarshal(body, &r)
+	err := json.Unmarshal(body, &ch)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -113,37 +202,134 @@ func (s *Server) handleSlackURLVerification(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// handleCallbackEvent checks the slack reaction.
-func (s *Server) handleCallbackEvent(w http.ResponseWriter, r *http.Request, event slackevents.EventsAPIEvent) {
-	switch innerEvent := event.InnerEvent.Data.(type) {
-	case *slackevents.ReactionAddedEvent:
-		err := s.SlackService.ReactionAddedEvent(innerEvent)
-		if err != nil {
-			s.Logger.Printf("%s", err.Error())
-		}
-	case *slackevents.ReactionRemovedEvent:
-		err := s.SlackService.ReactionRemovedEvent(innerEvent)
-		if err != nil {
-			s.Logger.Printf("%s", err.Error())
-		}
+// handleSlashCommand handles the /dinny Slack slash command and replies with an ephemeral message.
+func (s *Server) handleSlashCommand(w http.ResponseWriter, r *http.Request) {
+	cmd, err := slackgo.SlashCommandParse(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSlashCommand SlashCommandParse: %s", err.Error())
+		return
+	}
+	msg, err := s.slackRotation(cmd.ChannelID, cmd.UserID).SlackService.SlashCommand(cmd)
+	if err != nil {
+		// Slack shows the member the body of the response, so keep the internals in the log.
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("something went wrong, please try again later"))
+		s.Logger.Printf("handleSlashCommand SlackService.SlashCommand: %s", err.Error())
+		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(msg)
 }
 
-// CookAssignment represents the assignment of a cook on a specific date.
+// handleInteractivity handles the interactions of members with Slack messages, such as clicking the RSVP buttons of a 'who's eating' message.
+func (s *Server) handleInteractivity(w http.ResponseWriter, r *http.Request) {
+	var callback slackgo.InteractionCallback
+	err := json.Unmarshal([]byte(r.PostFormValue("payload")), &callback)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleInteractivity Unmarshal: %s", err.Error())
+		return
+	}
+	if callback.Type != slackgo.InteractionTypeBlockActions {
+		return
+	}
+	err = s.slackRotation(callback.Channel.ID, callback.User.ID).SlackService.BlockActions(callback)
+	if err != nil {
+		// Slack shows the member the body of the response, so keep the internals in the log.
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("something went wrong, please try again later"))
+		s.Logger.Printf("handleInteractivity SlackService.BlockActions: %s", err.Error())
+		return
+	}
+}
+
+// CookAssignment represents the assignment of a cook, and optionally co-cooks, on a specific date.
 type CookAssignment struct {
 	Date         dinny.Date `json:"date"`
 	CookSlackUID string     `json:"cookSlackUID"`
+
+	// CoCookSlackUIDs lists the members cooking together with the lead cook.
+	CoCookSlackUIDs []string `json:"coCookSlackUIDs,omitempty"`
+}
+
+// Cooks returns the Slack UIDs of everybody assigned to cook, the lead cook first.
+func (ca CookAssignment) Cooks() []string {
+	return append([]string{ca.CookSlackUID}, ca.CoCookSlackUIDs...)
+}
+
+// validate ensures the assignment has a lead cook and doesn't list anybody twice.
+func (ca CookAssignment) validate() error {
+	seen := make(map[string]bool)
+	for _, cook := range ca.Cooks() {
+		if cook == "" {
+			return fmt.Errorf("a cook on %s is missing a slack uid", ca.Date)
+		}
+		if seen[cook] {
+			return fmt.Errorf("%s is assigned to cook on %s more than once", cook, ca.Date)
+		}
+		seen[cook] = true
+	}
+	return nil
 }
 
 // AssignCooksRequest represents the a collection of cook assignments.
 type AssignCooksRequest struct {
 	CookAssignments []CookAssignment `json:"cooks"`
+
+	// Force assigns cooks even if they marked themselves as unable to cook.
+	Force bool `json:"force,omitempty"`
+}
+
+// AssignCooksResponse represents the outcome of assigning cooks.
+type AssignCooksResponse struct {
+	// Warnings lists the cooks who were assigned although they can't cook.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// cookingConflicts describes every assignment of a cook who marked themselves as unable to cook on that date.
+func (s *Server) cookingConflicts(rot *Rotation, assignments []CookAssignment) ([]string, error) {
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+	from, to := assignments[0].Date, assignments[0].Date
+	for _, assignment := range assignments {
+		if assignment.Date.Before(from) {
+			from = assignment.Date
+		}
+		if to.Before(assignment.Date) {
+			to = assignment.Date
+		}
+	}
+	availabilities, err := rot.AvailabilityService.ListAvailabilities(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("cookingConflicts ListAvailabilities: %w", err)
+	}
+
+	var conflicts []string
+	for _, assignment := range assignments {
+		for _, cook := range assignment.Cooks() {
+			member, err := rot.MemberService.FindMemberBySlackUID(cook)
+			if errors.Is(err, dinny.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("cookingConflicts FindMemberBySlackUID: %w", err)
+			}
+			if a := dinny.CookingConflict(availabilities, member.ID, assignment.Date); a != nil {
+				conflicts = append(conflicts, fmt.Sprintf("%s can't cook on %s (away %s to %s) %s", cook, assignment.Date, a.From, a.To, a.Note))
+			}
+		}
+	}
+	return conflicts, nil
 }
 
 // handleAssignCooks represents a handler for assigning multiple cooks.
 func (s *Server) handleAssignCooks(w http.ResponseWriter, r *http.Request) {
-	var req *AssignCooksRequest
-	err := json.NewDecoder(r.Body).Decode(req)
+	rot := s.rotation(r)
+	var req AssignCooksRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		w.Write([]byte(err.Error()))
@@ -151,42 +337,371 @@ func (s *Server) handleAssignCooks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	for _, assignment := range req.CookAssignments {
-		m, err := s.MealService.FindMealByDate(assignment.Date)
-		if errors.Is(err, dinny.ErrNotFound) {
-			err := s.MealService.CreateMeal(&dinny.Meal{
-				CookSlackUID: assignment.CookSlackUID,
-				Date:         assignment.Date,
-			})
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				w.Write([]byte(err.Error()))
-				s.Logger.Printf("handleAssignCooks MealService.CreateMeal: %s", err.Error())
-				return
-			}
-		} else if err != nil {
+		if err := assignment.validate(); err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(err.Error()))
-			s.Logger.Printf("handleAssignCooks MealService.FindMealByDate: %s", err.Error())
 			return
-		} else {
-			err := s.MealService.UpdateMeal(m.ID, dinny.MealUpdate{
-				ChefSlackUID: &assignment.CookSlackUID,
-			})
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				w.Write([]byte(err.Error()))
-				s.Logger.Printf("handleAssignCooks MealService.UpdateMeal: %s", err.Error())
-				return
-			}
+		}
+	}
 
+	// Refuse to assign cooks who can't cook unless forced to.
+	conflicts, err := s.cookingConflicts(rot, req.CookAssignments)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleAssignCooks: %s", err.Error())
+		return
+	}
+	if len(conflicts) > 0 && !req.Force {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(strings.Join(conflicts, "\n")))
+		return
+	}
+
+	for _, assignment := range req.CookAssignments {
+		err := rot.MealService.AssignCooks(assignment.Date, assignment.Cooks())
+		if err != nil {
+			w.WriteHeader(mealErrorStatus(err))
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("handleAssignCooks MealService.AssignCooks: %s", err.Error())
+			return
 		}
 	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AssignCooksResponse{Warnings: conflicts})
+}
 
+// ProposeScheduleRequest represents a request to propose cooks for the days starting at From.
+type ProposeScheduleRequest struct {
+	From           dinny.Date `json:"from"`
+	Days           int        `json:"days"`
+	MinDaysBetween int        `json:"minDaysBetween"`
+}
+
+// handleProposeSchedule is a handler which proposes a fair cook assignment without assigning anyone.
+// The response can be sent to /cmd/assign-cooks as is, minus the days no cook could be found for.
+func (s *Server) handleProposeSchedule(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req ProposeScheduleRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleProposeSchedule: %s", err.Error())
+		return
+	}
+	if req.From == (dinny.Date{}) {
+		req.From = s.today()
+	}
+	if req.Days < 1 {
+		req.Days = 7
+	}
+
+	members, err := rot.MemberService.ListMembers()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleProposeSchedule ListMembers: %s", err.Error())
+		return
+	}
+	meals, err := rot.MealService.ListMeals(req.From.AddDays(-req.MinDaysBetween), req.From.AddDays(req.Days+req.MinDaysBetween))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleProposeSchedule ListMeals: %s", err.Error())
+		return
+	}
+	// Cancelled meals neither take their day nor count as cooking for the spacing between a cook's meals.
+	var existing []*dinny.Meal
+	for _, meal := range meals {
+		if meal.Status != dinny.MealStatusCancelled {
+			existing = append(existing, meal)
+		}
+	}
+
+	availabilities, err := rot.AvailabilityService.ListAvailabilities(req.From, req.From.AddDays(req.Days))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleProposeSchedule ListAvailabilities: %s", err.Error())
+		return
+	}
+
+	scheduler := dinny.Scheduler{
+		MinDaysBetween: req.MinDaysBetween,
+		Available: func(member *dinny.Member, date dinny.Date) bool {
+			return dinny.CookingConflict(availabilities, member.ID, date) == nil
+		},
+	}
+	var resp AssignCooksRequest
+	for _, meal := range scheduler.Propose(members, existing, req.From, req.Days) {
+		resp.CookAssignments = append(resp.CookAssignments, CookAssignment{
+			Date:         meal.Date,
+			CookSlackUID: meal.CookSlackUID,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CancelMealRequest represents a request to cancel the meal on Date. Reason is shared with the channel.
+type CancelMealRequest struct {
+	Date   dinny.Date `json:"date"`
+	Reason string     `json:"reason"`
+}
+
+// mealErrorStatus maps errors returned by the MealService onto HTTP status codes.
+func mealErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dinny.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dinny.ErrMealClosed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// handleCancelMeal is a handler for the cancel_meal command.
+func (s *Server) handleCancelMeal(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req CancelMealRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCancelMeal: %s", err.Error())
+		return
+	}
+	if req.Date == (dinny.Date{}) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("a date is required"))
+		return
+	}
+	err = rot.SlackService.CancelMeal(req.Date, req.Reason)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCancelMeal SlackService.CancelMeal: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// SetMenuRequest represents a request to set the menu of the meal on Date.
+type SetMenuRequest struct {
+	Date        dinny.Date `json:"date"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Tags        []string   `json:"tags"`
+}
+
+// handleSetMenu is a handler for the menu command. Only the cooks of the meal and leaders may set its menu.
+func (s *Server) handleSetMenu(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req SetMenuRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetMenu: %s", err.Error())
+		return
+	}
+	if req.Title == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("a title is required"))
+		return
+	}
+	tags, err := dinny.ParseMealTags(strings.Join(req.Tags, ","))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	meal, err := rot.MealService.FindMealByDate(req.Date)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetMenu FindMealByDate: %s", err.Error())
+		return
+	}
+	owner, err := s.tokenOwner(r)
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("the api token doesn't belong to a member"))
+		return
+	}
+	if !meal.HasCook(owner.SlackUID) && !owner.Leader {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("only the cooks and leaders may set the menu"))
+		return
+	}
+
+	err = rot.SlackService.SetMenu(req.Date, dinny.MealUpdate{Title: &req.Title, Description: &req.Description, Tags: &tags})
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetMenu SlackService.SetMenu: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// SetRSVPDeadlineRequest represents a request to override the RSVP deadline of the meal on Date.
+type SetRSVPDeadlineRequest struct {
+	Date     dinny.Date `json:"date"`
+	Deadline time.Time  `json:"deadline"`
+}
+
+// handleSetRSVPDeadline is a handler for the rsvp_deadline command. Only the cooks of the meal and leaders may set its RSVP deadline.
+func (s *Server) handleSetRSVPDeadline(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req SetRSVPDeadlineRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetRSVPDeadline: %s", err.Error())
+		return
+	}
+	if req.Deadline.IsZero() {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("a deadline is required"))
+		return
+	}
+
+	meal, err := rot.MealService.FindMealByDate(req.Date)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetRSVPDeadline FindMealByDate: %s", err.Error())
+		return
+	}
+	owner, err := s.tokenOwner(r)
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("the api token doesn't belong to a member"))
+		return
+	}
+	if !meal.HasCook(owner.SlackUID) && !owner.Leader {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("only the cooks and leaders may set the rsvp deadline"))
+		return
+	}
+
+	err = rot.SlackService.SetRSVPDeadline(req.Date, req.Deadline)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSetRSVPDeadline SlackService.SetRSVPDeadline: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// SwapCooksRequest represents a request to swap the cooks of the meals on First and Second.
+type SwapCooksRequest struct {
+	First  dinny.Date `json:"first"`
+	Second dinny.Date `json:"second"`
+}
+
+// handleSwapCooks is a handler for the swap_cooks command.
+func (s *Server) handleSwapCooks(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req SwapCooksRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSwapCooks: %s", err.Error())
+		return
+	}
+	if req.First == (dinny.Date{}) || req.Second == (dinny.Date{}) || req.First == req.Second {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("two different dates are required"))
+		return
+	}
+	err = rot.SlackService.SwapCooks(req.First, req.Second)
+	if err != nil {
+		w.WriteHeader(mealErrorStatus(err))
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSwapCooks SlackService.SwapCooks: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// CloseOutRequest represents a request to close out the meal on Date. Defaults to yesterday.
+type CloseOutRequest struct {
+	Date dinny.Date `json:"date"`
+}
+
+// today returns the current day in the server's location.
+func (s *Server) today() dinny.Date {
+	if s.Location == nil {
+		return dinny.DateOf(time.Now())
+	}
+	return dinny.DateOf(time.Now().In(s.Location))
+}
+
+// handleCloseOut is a handler for the close_out command. Rerunning it for the same date never credits the cook twice.
+func (s *Server) handleCloseOut(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req CloseOutRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCloseOut: %s", err.Error())
+		return
+	}
+	if req.Date == (dinny.Date{}) {
+		req.Date = s.today().AddDays(-1)
+	}
+	err = rot.SlackService.CloseOutMeal(req.Date)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCloseOut SlackService.CloseOutMeal: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// HeadcountRequest represents a request to send the cooks of the meal on Date its headcount. Defaults to today.
+type HeadcountRequest struct {
+	Date dinny.Date `json:"date"`
+}
+
+// handleHeadcount is a handler for the headcount command. The headcount of a meal is only ever sent once.
+func (s *Server) handleHeadcount(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req HeadcountRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleHeadcount: %s", err.Error())
+		return
+	}
+	if req.Date == (dinny.Date{}) {
+		req.Date = s.today()
+	}
+	err = rot.SlackService.SendHeadcount(req.Date)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleHeadcount SlackService.SendHeadcount: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
 }
 
 // handleEatingTomorrow is a handler for the eating_tomorrow command.
 func (s *Server) handleEatingTomorrow(w http.ResponseWriter, r *http.Request) {
-	err := s.SlackService.PostEatingTomorrow()
+	rot := s.rotation(r)
+	err := rot.SlackService.PostEatingTomorrow()
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
@@ -198,7 +713,8 @@ func (s *Server) handleEatingTomorrow(w http.ResponseWriter, r *http.Request) {
 
 // handleMembers is a handler for the members command.
 func (s *Server) handleMembers(w http.ResponseWriter, r *http.Request) {
-	members, err := s.MemberService.ListMembers()
+	rot := s.rotation(r)
+	members, err := rot.MemberService.ListMembers()
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
@@ -218,8 +734,27 @@ func (s *Server) handlePing(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("pong"))
 }
 
+// handleSchedule is a handler for the schedule command. Lists when each scheduled job last ran and will run next.
+func (s *Server) handleSchedule(w http.ResponseWriter, r *http.Request) {
+	if s.Scheduler == nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("no jobs are scheduled"))
+		return
+	}
+	statuses, err := s.Scheduler.Status()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleSchedule Scheduler.Status: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statuses)
+}
+
 // handleUpcomingCooks is a handler for the upcoming_cooks command.
 func (s *Server) handleUpcomingCooks(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
 	year, month, day := time.Now().Date()
 	daysWanted, err := strconv.Atoi(r.URL.Query().Get("daysWanted"))
 	if err != nil {
@@ -231,7 +766,7 @@ func (s *Server) handleUpcomingCooks(w http.ResponseWriter, r *http.Request) {
 	e := json.NewEncoder(w)
 	for ii := 0; ii < int(daysWanted); ii++ {
 		date := dinny.Date{Year: year, Month: month, Day: day + ii}
-		meal, err := s.MealService.FindMealByDate(date)
+		meal, err := rot.MealService.FindMealByDate(date)
 		if errors.Is(dinny.ErrNotFound, err) {
 			e.Encode(dinny.Meal{CookSlackUID: "NOT SET", Date: date})
 		} else if err != nil {
@@ -247,7 +782,8 @@ func (s *Server) handleUpcomingCooks(w http.ResponseWriter, r *http.Request) {
 
 // handleWeeklyUpdate is a handler for the weekly_update command.
 func (s *Server) handleWeeklyUpdate(w http.ResponseWriter, r *http.Request) {
-	err := s.SlackService.WeeklyUpdate()
+	rot := s.rotation(r)
+	err := rot.SlackService.WeeklyUpdate()
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
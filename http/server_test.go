@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// availabilities is a dinny.AvailabilityService without any availabilities.
+type availabilities struct {
+	dinny.AvailabilityService
+}
+
+func (as availabilities) ListAvailabilities(from dinny.Date, to dinny.Date) ([]*dinny.Availability, error) {
+	return nil, nil
+}
+
+// TestProposeSchedule ensures cancelled meals neither take their day nor keep their cook from being proposed.
+func TestProposeSchedule(t *testing.T) {
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	s := NewServer(log.New(io.Discard, "", 0), "", members{byID: map[int64]*dinny.Member{
+		1: {ID: 1, SlackUID: "U1", MealsEaten: 3},
+	}}, meals{byDate: map[dinny.Date]*dinny.Meal{
+		date.AddDays(-1): {ID: 1, Date: date.AddDays(-1), CookSlackUID: "U1", Status: dinny.MealStatusCancelled},
+		date:             {ID: 2, Date: date, CookSlackUID: "U1", Status: dinny.MealStatusCancelled},
+	}}, nil)
+	s.AvailabilityService = availabilities{}
+	s.TokenService = tokens{bySecret: map[string]*dinny.Token{"reader": {MemberID: 1, Scope: dinny.TokenScopeRead}}}
+
+	body, err := json.Marshal(ProposeScheduleRequest{From: date, Days: 1, MinDaysBetween: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/cmd/propose-schedule", bytes.NewReader(body))
+	r.Header.Set("Authorization", "Bearer reader")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST /cmd/propose-schedule = %d %q, want %d", w.Code, w.Body.String(), http.StatusOK)
+	}
+	var resp AssignCooksRequest
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	want := []CookAssignment{{Date: date, CookSlackUID: "U1"}}
+	if !reflect.DeepEqual(resp.CookAssignments, want) {
+		t.Errorf("CookAssignments = %+v, want %+v", resp.CookAssignments, want)
+	}
+}
@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"context"
+	"time"
+
+	slackgo "github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+)
+
+// Bounds of the delay before reconnecting to Slack via Socket Mode. The delay doubles with every failed attempt.
+const (
+	minSocketBackoff = time.Second
+	maxSocketBackoff = 5 * time.Minute
+)
+
+// OpenSocketMode connects to Slack via Socket Mode, which doesn't require the server to be reachable by Slack,
+// and handles the events, interactions and slash commands sent over it like those sent to the Slack routes.
+// The client must be created with an app-level token. The connection is kept until ctx is done.
+func (s *Server) OpenSocketMode(ctx context.Context, client *socketmode.Client) {
+	go s.runSocketMode(ctx, client)
+	go s.handleSocketEvents(ctx, client)
+}
+
+// runSocketMode keeps the Socket Mode connection open, reconnecting with exponential backoff whenever it fails.
+func (s *Server) runSocketMode(ctx context.Context, client *socketmode.Client) {
+	backoff := minSocketBackoff
+	for {
+		connectedAt := time.Now()
+		err := client.RunContext(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		// A connection which held up for a while starts over with the shortest delay.
+		if time.Since(connectedAt) > maxSocketBackoff {
+			backoff = minSocketBackoff
+		}
+		slackStats.Add("reconnects", 1)
+		s.Logger.Printf("runSocketMode: reconnecting in %s: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxSocketBackoff {
+			backoff = maxSocketBackoff
+		}
+	}
+}
+
+// handleSocketEvents handles the requests Slack sends over the Socket Mode connection until ctx is done.
+func (s *Server) handleSocketEvents(ctx context.Context, client *socketmode.Client) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt := <-client.Events:
+			s.handleSocketEvent(client, evt)
+		}
+	}
+}
+
+// handleSocketEvent handles a single request sent over the Socket Mode connection. Requests are acknowledged the way Slack expects them to be answered over HTTP.
+func (s *Server) handleSocketEvent(client *socketmode.Client, evt socketmode.Event) {
+	switch evt.Type {
+	case socketmode.EventTypeConnected:
+		s.Logger.Printf("handleSocketEvent: connected to Slack via Socket Mode")
+	case socketmode.EventTypeConnectionError, socketmode.EventTypeInvalidAuth:
+		s.Logger.Printf("handleSocketEvent: %s: %v", evt.Type, evt.Data)
+	case socketmode.EventTypeEventsAPI:
+		event, ok := evt.Data.(slackevents.EventsAPIEvent)
+		if !ok || event.Type != slackevents.CallbackEvent {
+			client.Ack(*evt.Request)
+			return
+		}
+		// Events which aren't acknowledged are retried by Slack.
+		if s.enqueueEvent(event) {
+			client.Ack(*evt.Request)
+		}
+	case socketmode.EventTypeInteractive:
+		client.Ack(*evt.Request)
+		callback, ok := evt.Data.(slackgo.InteractionCallback)
+		if !ok || callback.Type != slackgo.InteractionTypeBlockActions {
+			return
+		}
+		err := s.slackRotation(callback.Channel.ID, callback.User.ID).SlackService.BlockActions(callback)
+		if err != nil {
+			s.Logger.Printf("handleSocketEvent SlackService.BlockActions: %s", err.Error())
+		}
+	case socketmode.EventTypeSlashCommand:
+		cmd, ok := evt.Data.(slackgo.SlashCommand)
+		if !ok {
+			client.Ack(*evt.Request)
+			return
+		}
+		msg, err := s.slackRotation(cmd.ChannelID, cmd.UserID).SlackService.SlashCommand(cmd)
+		if err != nil {
+			client.Ack(*evt.Request, slackgo.Msg{Text: "something went wrong, please try again later"})
+			s.Logger.Printf("handleSocketEvent SlackService.SlashCommand: %s", err.Error())
+			return
+		}
+		client.Ack(*evt.Request, msg)
+	}
+}
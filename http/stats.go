@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+)
+
+// handleStats is a handler for the stats command. Computes the stats of every member over the from and to query parameters,
+// which default to the last four weeks. The season query parameter starts the stats with the current season or, if "all-time", with the first meal instead.
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	from, to := dinny.DefaultStatsRange(time.Now())
+	var err error
+	switch season := r.URL.Query().Get("season"); season {
+	case "":
+	case dinny.SeasonScopeCurrent:
+		from, err = rot.SeasonService.CurrentSeasonStart()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			s.Logger.Printf("handleStats CurrentSeasonStart: %s", err.Error())
+			return
+		}
+	case dinny.SeasonScopeAllTime:
+		from = dinny.Date{}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("season must be %q or %q", dinny.SeasonScopeCurrent, dinny.SeasonScopeAllTime)))
+		return
+	}
+	if v := r.URL.Query().Get("from"); v != "" {
+		from, err = dinny.ParseDate(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		to, err = dinny.ParseDate(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+	}
+	if to.Before(from) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("from must not be after to"))
+		return
+	}
+
+	stats, err := rot.StatsService.Stats(from, to)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleStats Stats: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
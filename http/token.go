@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateTokenRequest represents a request to create an API token for a member.
+type CreateTokenRequest struct {
+	MemberSlackUID string `json:"memberSlackUID"`
+	Name           string `json:"name"`
+	Scope          string `json:"scope"`
+}
+
+// CreateTokenResponse represents a newly created API token. The secret is only ever returned once.
+type CreateTokenResponse struct {
+	Token  *dinny.Token `json:"token"`
+	Secret string       `json:"secret"`
+}
+
+// handleListTokens is a handler for the token list command.
+func (s *Server) handleListTokens(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	tokens, err := rot.TokenService.ListTokens()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleListTokens ListTokens: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+// handleCreateToken is a handler for the token create command.
+func (s *Server) handleCreateToken(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	var req CreateTokenRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreateToken: %s", err.Error())
+		return
+	}
+	if !dinny.ValidTokenScope(req.Scope) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("unknown scope %q", req.Scope)))
+		return
+	}
+	member, err := rot.MemberService.FindMemberBySlackUID(req.MemberSlackUID)
+	if errors.Is(err, dinny.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("member %s not found", req.MemberSlackUID)))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreateToken FindMemberBySlackUID: %s", err.Error())
+		return
+	}
+
+	secret, err := dinny.NewTokenSecret()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreateToken: %s", err.Error())
+		return
+	}
+	token := &dinny.Token{
+		MemberID: member.ID,
+		Name:     req.Name,
+		Scope:    req.Scope,
+		Hash:     dinny.HashTokenSecret(secret),
+	}
+	err = rot.TokenService.CreateToken(token)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleCreateToken CreateToken: %s", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreateTokenResponse{Token: token, Secret: secret})
+}
+
+// handleRevokeToken is a handler for the token revoke command.
+func (s *Server) handleRevokeToken(w http.ResponseWriter, r *http.Request) {
+	rot := s.rotation(r)
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	err = rot.TokenService.RevokeToken(id)
+	if errors.Is(err, dinny.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("token %d not found or already revoked", id)))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		s.Logger.Printf("handleRevokeToken RevokeToken: %s", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
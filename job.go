@@ -0,0 +1,27 @@
+package dinny
+
+import "time"
+
+// JobRun represents the last run of a scheduled job.
+type JobRun struct {
+	Name string `json:"name"`
+
+	// LastRunAt is when the job last ran, whether or not it succeeded.
+	LastRunAt time.Time `json:"lastRunAt"`
+
+	// LastSuccessAt is when the job last finished without an error. Nil if it never has.
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+
+	// LastError is the error of the last run. Empty if it succeeded.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// JobRunService represents a service for persisting when scheduled jobs last ran.
+type JobRunService interface {
+	// FindJobRun retrieves the last run of a job by name.
+	// Returns ErrNotFound if the job has never run.
+	FindJobRun(name string) (*JobRun, error)
+
+	// UpsertJobRun creates or replaces the last run of a job.
+	UpsertJobRun(run *JobRun) error
+}
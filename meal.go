@@ -1,6 +1,10 @@
 package dinny
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Date represents the year, month, and day of the meal.
 type Date struct {
@@ -9,19 +13,143 @@ type Date struct {
 	Day   int        `json:"day"`
 }
 
+// DateOf returns the Date of t in t's location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// Time returns midnight of the date in the local time zone.
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.Local)
+}
+
+// AddDays returns the date n days after d. n may be negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.Local))
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.Time().Before(other.Time())
+}
+
+// String returns the date formatted as YYYY-MM-DD.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// ParseDate parses a date formatted as YYYY-MM-DD.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return Date{}, fmt.Errorf("ParseDate: %w", err)
+	}
+	return DateOf(t), nil
+}
+
+// Meal statuses. A meal is scheduled until it's either closed out as cooked or cancelled.
+const (
+	MealStatusScheduled = "scheduled"
+	MealStatusCooked    = "cooked"
+	MealStatusCancelled = "cancelled"
+)
+
+// Dietary tags a cook may put on the menu of a meal.
+const (
+	MealTagVegan        = "vegan"
+	MealTagVegetarian   = "vegetarian"
+	MealTagGlutenFree   = "gluten-free"
+	MealTagDairyFree    = "dairy-free"
+	MealTagContainsNuts = "contains-nuts"
+)
+
+// MealTags lists every known dietary tag.
+var MealTags = []string{MealTagVegan, MealTagVegetarian, MealTagGlutenFree, MealTagDairyFree, MealTagContainsNuts}
+
+// ValidMealTag reports whether tag is a known dietary tag.
+func ValidMealTag(tag string) bool {
+	return hasTag(MealTags, tag)
+}
+
+// ParseMealTags parses a comma separated list of dietary tags like "vegan, gluten-free". Duplicates are dropped.
+func ParseMealTags(s string) ([]string, error) {
+	tags, err := parseList(s, MealTags)
+	if err != nil {
+		return nil, fmt.Errorf("ParseMealTags: %w", err)
+	}
+	return tags, nil
+}
+
+// parseList parses a comma separated list of known values, ignoring case and dropping duplicates.
+func parseList(s string, known []string) ([]string, error) {
+	values := []string{}
+	for _, v := range strings.Split(s, ",") {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if !hasTag(known, v) {
+			return nil, fmt.Errorf("unknown %q, expected one of %s", v, strings.Join(known, ", "))
+		}
+		if !hasTag(values, v) {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
 // Meal represents a meal in dinner rotation.
 type Meal struct {
-	ID             int64  `json:"id"`
-	CookSlackUID   string `json:"cookSlackUID"`
-	Date           Date   `json:"date"`
-	Description    string `json:"description"`
-	SlackMessageID string `json:"slackMessageID"`
+	ID             int64    `json:"id"`
+	CookSlackUID   string   `json:"cookSlackUID"`
+	Date           Date     `json:"date"`
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	Tags           []string `json:"tags"`
+	SlackMessageID string   `json:"slackMessageID"`
+	Status         string   `json:"status"`
+	RotationID     int64    `json:"rotationID"`
+
+	// Cooks lists everybody cooking the meal, starting with CookSlackUID, the lead cook.
+	Cooks []string `json:"cooks"`
+
+	// HeadcountSentAt is when the cook was sent the headcount. Changes to who's eating after it are late.
+	HeadcountSentAt *time.Time `json:"headcountSentAt,omitempty"`
+
+	// RSVPDeadline overrides the default deadline after which members can no longer join or leave the meal.
+	RSVPDeadline *time.Time `json:"rsvpDeadline,omitempty"`
+
+	// RSVPsClosedAt is when the 'who's eating' message was marked as closed.
+	RSVPsClosedAt *time.Time `json:"rsvpsClosedAt,omitempty"`
+}
+
+// Closed reports whether the meal has been closed out, after which its eaters can no longer change.
+func (m *Meal) Closed() bool {
+	return m.Status != MealStatusScheduled
 }
 
-// Expired determines if a meal is expired if it's after the date the meal was supposed to occur.
-func (m *Meal) Expired() bool {
-	year, month, day := time.Now().Date()
-	return year > m.Date.Year || month > m.Date.Month || day > m.Date.Day
+// CookSlackUIDs returns the Slack UIDs of everybody cooking the meal, the lead cook first.
+func (m *Meal) CookSlackUIDs() []string {
+	if len(m.Cooks) == 0 && m.CookSlackUID != "" {
+		return []string{m.CookSlackUID}
+	}
+	return m.Cooks
+}
+
+// HasCook reports whether the member with the given Slack UID is one of the cooks of the meal.
+func (m *Meal) HasCook(slackUID string) bool {
+	for _, cook := range m.CookSlackUIDs() {
+		if cook == slackUID {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired reports whether the day of the meal has passed at now, in now's location.
+func (m *Meal) Expired(now time.Time) bool {
+	return m.Date.Before(DateOf(now))
 }
 
 // MealService represents a service for managing meals.
@@ -38,19 +166,67 @@ type MealService interface {
 	// Returns ErrNotFound if meal does not exist.
 	FindMealBySlackMessageID(slackMessageID string) (*Meal, error)
 
-	// CreateMeal creates a new meal.
+	// ListMeals retrieves the meals between from and to, inclusive, ordered by date.
+	ListMeals(from Date, to Date) ([]*Meal, error)
+
+	// ListScheduledMealsBefore retrieves the meals before date which haven't been closed out yet, ordered by date.
+	ListScheduledMealsBefore(date Date) ([]*Meal, error)
+
+	// CreateMeal creates a new meal cooked by m.Cooks, or m.CookSlackUID alone if it has no cooks.
+	// A meal which was cancelled on the same date is reopened with the new cooks instead.
 	CreateMeal(m *Meal) error
 
+	// AssignCook sets the sole cook of the meal on date, creating the meal if it doesn't exist yet and reopening it if it was cancelled.
+	AssignCook(date Date, cookSlackUID string) error
+
+	// AssignCooks sets the cooks of the meal on date, the first being the lead cook, creating the meal if it doesn't exist yet
+	// and reopening it if it was cancelled.
+	AssignCooks(date Date, cookSlackUIDs []string) error
+
+	// CompleteMeal closes out a scheduled meal as cooked and credits each cook with a meal cooked and an equal share of its cooking credit.
+	// Completing a meal which has already been closed out is a no-op, so a meal is never credited twice.
+	// Returns ErrNotFound if the meal or its cook does not exist.
+	CompleteMeal(id int64) error
+
+	// CancelMeal closes out a scheduled meal as cancelled. Its attendances and guests are removed and the eaters' meals eaten are decremented,
+	// unless an attendance was counted towards a closed season.
+	// Returns ErrNotFound if the meal does not exist and ErrMealClosed if it has already been closed out.
+	CancelMeal(id int64) error
+
+	// SwapCooks atomically swaps the cooks, co-cooks included, of the scheduled meals on the two dates.
+	// Returns ErrNotFound if either meal does not exist and ErrMealClosed if either has been closed out.
+	SwapCooks(first Date, second Date) error
+
+	// MarkHeadcountSent records that the cooks were sent the headcount of the meal.
+	// Reports false if the headcount had already been marked as sent.
+	MarkHeadcountSent(id int64) (bool, error)
+
+	// ListHeadcountDeliveries retrieves the Slack UIDs of the cooks who were sent the headcount of the meal.
+	ListHeadcountDeliveries(mealID int64) ([]string, error)
+
+	// CreateHeadcountDelivery records that the cook with the given Slack UID was sent the headcount of the meal.
+	// Recording it again is a no-op.
+	CreateHeadcountDelivery(mealID int64, slackUID string) error
+
+	// CloseRSVPs records that the RSVPs of the meal were closed.
+	// Reports false if they had already been closed.
+	CloseRSVPs(id int64) (bool, error)
+
 	// UpdateMeal updates a meal object.
 	UpdateMeal(id int64, upd MealUpdate) error
 
-	// DeleteMeal permanently deletes a meal.
+	// DeleteMeal permanently deletes a meal. Its attendances are removed and the eaters' meals eaten are decremented,
+	// unless an attendance was counted towards a closed season.
+	// Returns ErrNotFound if the meal does not exist and ErrMealClosed if it has been cooked.
 	DeleteMeal(id int64) error
 }
 
 // MealUpdate represents a set of fields to be updated via UpdateMeal().
 type MealUpdate struct {
 	ChefSlackUID   *string
+	Title          *string
 	Description    *string
+	Tags           *[]string
 	SlackMessageID *string
+	RSVPDeadline   *time.Time
 }
@@ -0,0 +1,59 @@
+package dinny
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParseMealTags ensures tags are normalized, deduplicated, and unknown tags are rejected.
+func TestParseMealTags(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    []string
+		wantErr bool
+	}{
+		{"", []string{}, false},
+		{"vegan", []string{MealTagVegan}, false},
+		{" Gluten-Free, vegan ,gluten-free", []string{MealTagGlutenFree, MealTagVegan}, false},
+		{"vegan,,contains-nuts", []string{MealTagVegan, MealTagContainsNuts}, false},
+		{"vegan,spicy", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseMealTags(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMealTags() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMealTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMealExpired ensures a meal expires once its day has passed, including across month and year boundaries.
+func TestMealExpired(t *testing.T) {
+	tests := []struct {
+		name string
+		date Date
+		now  time.Time
+		want bool
+	}{
+		{"same day", Date{Year: 2023, Month: time.January, Day: 31}, time.Date(2023, time.January, 31, 23, 59, 0, 0, time.UTC), false},
+		{"next day", Date{Year: 2023, Month: time.January, Day: 30}, time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC), true},
+		{"end of last month", Date{Year: 2023, Month: time.January, Day: 31}, time.Date(2023, time.February, 1, 9, 0, 0, 0, time.UTC), true},
+		{"start of next month", Date{Year: 2023, Month: time.February, Day: 1}, time.Date(2023, time.January, 31, 9, 0, 0, 0, time.UTC), false},
+		{"later day of next month", Date{Year: 2023, Month: time.February, Day: 28}, time.Date(2023, time.January, 31, 9, 0, 0, 0, time.UTC), false},
+		{"end of last year", Date{Year: 2022, Month: time.December, Day: 31}, time.Date(2023, time.January, 1, 9, 0, 0, 0, time.UTC), true},
+		{"start of next year", Date{Year: 2024, Month: time.January, Day: 1}, time.Date(2023, time.December, 31, 9, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Meal{Date: tt.date}
+			if got := m.Expired(tt.now); got != tt.want {
+				t.Errorf("Expired(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
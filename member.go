@@ -1,13 +1,48 @@
 package dinny
 
+import "math"
+
 // Member represents a member of dinner rotation.
 type Member struct {
-	ID          int64  `json:"id"`
-	SlackUID    string `json:"slackUID"`
-	FullName    string `json:"fullName"`
-	MealsEaten  int64  `json:"mealsEaten"`
-	MealsCooked int64  `json:"mealsCooked"`
-	Leader      bool   `json:"leader"`
+	ID          int64   `json:"id"`
+	SlackUID    string  `json:"slackUID"`
+	FullName    string  `json:"fullName"`
+	MealsEaten  int64   `json:"mealsEaten"`
+	MealsCooked float64 `json:"mealsCooked"`
+	Leader      bool    `json:"leader"`
+	RotationID  int64   `json:"rotationID"`
+
+	// CookingCredits are the credits for the meals cooked, weighted by the configured CreditFormula.
+	CookingCredits float64 `json:"cookingCredits"`
+}
+
+// Ratio calculates the meals eaten to meals cooked ratio. Returns math.MaxFloat32 for 0 meals cooked and >0 meals eaten.
+func (m *Member) Ratio() float32 {
+	if m.MealsCooked <= 0 {
+		if m.MealsEaten > 0 {
+			return math.MaxFloat32
+		}
+		return 0
+	}
+	return float32(float64(m.MealsEaten) / m.MealsCooked)
+}
+
+// WeightedRatio calculates the meals eaten to cooking credits ratio. Returns math.MaxFloat32 for 0 credits and >0 meals eaten.
+func (m *Member) WeightedRatio() float32 {
+	if m.CookingCredits <= 0 {
+		if m.MealsEaten > 0 {
+			return math.MaxFloat32
+		}
+		return 0
+	}
+	return float32(float64(m.MealsEaten) / m.CookingCredits)
+}
+
+// AddCounters adds the counters of other to the counters of m.
+func (m *Member) AddCounters(other *Member) {
+	m.MealsEaten += other.MealsEaten
+	m.MealsCooked += other.MealsCooked
+	m.CookingCredits += other.CookingCredits
 }
 
 // MemberService represents a service for managing members.
@@ -23,7 +58,7 @@ type MemberService interface {
 	// ListMembers retrieves a list of members.
 	ListMembers() ([]*Member, error)
 
-	// CreateMember creates a new member.
+	// CreateMember creates a new member. Sets the ID of m on success.
 	CreateMember(m *Member) error
 
 	// UpdateMember updates a member object.
@@ -36,6 +71,6 @@ type MemberService interface {
 // MemberUpdate represents a set of fields to be updated via UpdateMember().
 type MemberUpdate struct {
 	MealsEaten  *int64
-	MealsCooked *int64
+	MealsCooked *float64
 	Leader      *bool
 }
@@ -0,0 +1,36 @@
+package dinny
+
+// DefaultRotationID is the ID of the rotation which every member and meal belonged to before dinnyd ran several rotations.
+const DefaultRotationID = 1
+
+// Rotation represents a dinner group with its own Slack channel, members and meals.
+type Rotation struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	SlackChannel string `json:"slackChannel"`
+}
+
+// RotationService represents a service for managing rotations.
+type RotationService interface {
+	// FindRotationByID retrieves a rotation by ID.
+	// Returns ErrNotFound if the rotation does not exist.
+	FindRotationByID(id int64) (*Rotation, error)
+
+	// FindRotationByName retrieves a rotation by name.
+	// Returns ErrNotFound if the rotation does not exist.
+	FindRotationByName(name string) (*Rotation, error)
+
+	// ListRotations retrieves every rotation.
+	ListRotations() ([]*Rotation, error)
+
+	// CreateRotation creates a new rotation. Sets the ID of r on success.
+	CreateRotation(r *Rotation) error
+
+	// UpdateRotation updates a rotation.
+	UpdateRotation(id int64, upd RotationUpdate) error
+}
+
+// RotationUpdate represents a set of fields to be updated via UpdateRotation().
+type RotationUpdate struct {
+	SlackChannel *string
+}
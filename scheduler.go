@@ -0,0 +1,79 @@
+package dinny
+
+import "sort"
+
+// Scheduler proposes a fair cook assignment for a range of dates.
+// Members with the worst meals eaten to meals cooked ratio are asked to cook first.
+type Scheduler struct {
+	// MinDaysBetween is the minimum number of days between two meals cooked by the same member.
+	MinDaysBetween int
+
+	// Available reports whether a member can cook on a date. Every member is available if nil.
+	Available func(member *Member, date Date) bool
+}
+
+// Propose assigns a cook to each of the days starting at from which doesn't already have a meal in existing.
+// existing should contain the meals from at least MinDaysBetween days before from up to the last day so earlier cooks are respected.
+// A proposed meal has an empty CookSlackUID if no member could be found to cook on that day.
+func (s *Scheduler) Propose(members []*Member, existing []*Meal, from Date, days int) []*Meal {
+	cooked := make(map[string][]Date)
+	taken := make(map[Date]bool)
+	for _, meal := range existing {
+		taken[meal.Date] = true
+		for _, cook := range meal.CookSlackUIDs() {
+			cooked[cook] = append(cooked[cook], meal.Date)
+		}
+	}
+
+	// Work on copies so the ratios can account for the meals proposed so far.
+	candidates := make([]*Member, 0, len(members))
+	for _, member := range members {
+		m := *member
+		candidates = append(candidates, &m)
+	}
+
+	var proposals []*Meal
+	for ii := 0; ii < days; ii++ {
+		date := from.AddDays(ii)
+		if taken[date] {
+			continue
+		}
+
+		sort.SliceStable(candidates, func(ii, jj int) bool {
+			ri, rj := candidates[ii].Ratio(), candidates[jj].Ratio()
+			if ri != rj {
+				return ri > rj
+			}
+			if candidates[ii].MealsEaten != candidates[jj].MealsEaten {
+				return candidates[ii].MealsEaten > candidates[jj].MealsEaten
+			}
+			return candidates[ii].SlackUID < candidates[jj].SlackUID
+		})
+
+		proposal := &Meal{Date: date}
+		for _, member := range candidates {
+			if s.tooSoon(cooked[member.SlackUID], date) {
+				continue
+			}
+			if s.Available != nil && !s.Available(member, date) {
+				continue
+			}
+			proposal.CookSlackUID = member.SlackUID
+			cooked[member.SlackUID] = append(cooked[member.SlackUID], date)
+			member.MealsCooked++
+			break
+		}
+		proposals = append(proposals, proposal)
+	}
+	return proposals
+}
+
+// tooSoon reports whether date is within MinDaysBetween days of any of the given dates.
+func (s *Scheduler) tooSoon(dates []Date, date Date) bool {
+	for _, d := range dates {
+		if date.Before(d.AddDays(s.MinDaysBetween+1)) && d.Before(date.AddDays(s.MinDaysBetween+1)) {
+			return true
+		}
+	}
+	return false
+}
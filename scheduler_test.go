@@ -0,0 +1,41 @@
+package dinny
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSchedulerPropose ensures the worst ratios cook first, existing meals are kept, and nobody cooks twice within MinDaysBetween days.
+func TestSchedulerPropose(t *testing.T) {
+	members := []*Member{
+		{SlackUID: "A", MealsEaten: 10, MealsCooked: 1},
+		{SlackUID: "B", MealsEaten: 10, MealsCooked: 5},
+		{SlackUID: "C", MealsEaten: 4, MealsCooked: 4},
+		{SlackUID: "D", MealsEaten: 0, MealsCooked: 0},
+	}
+	from := Date{Year: 2023, Month: time.January, Day: 30}
+	existing := []*Meal{
+		// C cooked recently, so can't cook on the first day.
+		{CookSlackUID: "C", Date: from.AddDays(-1)},
+		// The third day already has a cook.
+		{CookSlackUID: "B", Date: from.AddDays(2)},
+	}
+	s := &Scheduler{
+		MinDaysBetween: 1,
+		Available: func(member *Member, date Date) bool {
+			return !(member.SlackUID == "A" && date == from.AddDays(3))
+		},
+	}
+	got := s.Propose(members, existing, from, 5)
+
+	want := []string{"A", "C", "C", "A"}
+	if len(got) != len(want) {
+		t.Fatalf("Propose() returned %d meals, want %d", len(got), len(want))
+	}
+	wantDates := []Date{from, from.AddDays(1), from.AddDays(3), from.AddDays(4)}
+	for ii, meal := range got {
+		if meal.CookSlackUID != want[ii] || meal.Date != wantDates[ii] {
+			t.Errorf("Propose()[%d] = %s on %s, want %s on %s", ii, meal.CookSlackUID, meal.Date, want[ii], wantDates[ii])
+		}
+	}
+}
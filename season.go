@@ -0,0 +1,55 @@
+package dinny
+
+import "time"
+
+// Season scopes, selecting whether counters cover the current season or every season of the rotation.
+const (
+	SeasonScopeCurrent = "current"
+	SeasonScopeAllTime = "all-time"
+)
+
+// Season represents a closed stretch of a dinner rotation. Closing a season archives the counters of every member and resets them,
+// so that ratios reflect recent meals.
+//
+// Meals eaten are counted when members RSVP while cooks are credited when the meal is closed out, so a meal around the end of
+// a season can be split across two: RSVPs made before closing count towards the closed season and the cooking towards the next.
+// RSVPs counted towards a closed season can't be withdrawn, and cancelling their meal doesn't take them off the next season.
+type Season struct {
+	ID         int64  `json:"id"`
+	RotationID int64  `json:"rotationID"`
+	Name       string `json:"name"`
+
+	// From is the first day of the season. It is the zero Date for the first season, which started with the rotation.
+	From Date `json:"from"`
+
+	// To is the last day of the season. The next season starts the day after.
+	To Date `json:"to"`
+
+	ClosedAt time.Time `json:"closedAt"`
+
+	// Members are the members with their counters at the end of the season. Only set by FindSeasonByID.
+	Members []*Member `json:"members,omitempty"`
+}
+
+// SeasonService represents a service for closing seasons and looking up the archived counters of past ones.
+type SeasonService interface {
+	// CurrentSeasonStart returns the first day of the season in progress, the day after the last season was closed.
+	// Returns the zero Date if no season has been closed yet.
+	CurrentSeasonStart() (Date, error)
+
+	// ListSeasons retrieves the closed seasons, oldest first, without their members.
+	ListSeasons() ([]*Season, error)
+
+	// FindSeasonByID retrieves a closed season along with its members.
+	// Returns ErrNotFound if the season does not exist.
+	FindSeasonByID(id int64) (*Season, error)
+
+	// CloseSeason closes the season in progress on s.To under s.Name, archiving the counters of every member and resetting them.
+	// s.To must be the day the season is closed, as the counters are archived as they are then.
+	// Sets the ID, From and ClosedAt of s on success. Meals eaten count when members RSVP, so RSVPs to later meals made so far count
+	// towards the closed season.
+	CloseSeason(s *Season) error
+
+	// ListAllTimeMembers retrieves the members with their counters summed over every season, the current one included.
+	ListAllTimeMembers() ([]*Member, error)
+}
@@ -0,0 +1,315 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/slack-go/slack"
+)
+
+// slashCommandUsage describes the /dinny slash command.
+const slashCommandUsage = "*Usage:*\n" +
+	"`/dinny cooks [days]` list the upcoming cooks\n" +
+	"`/dinny assign @member [@member ...] <weekday|YYYY-MM-DD>` assign the cooks of a day (leaders only)\n" +
+	"`/dinny diet [restrictions|none] [| allergies]` show or set your dietary restrictions\n" +
+	"`/dinny menu <weekday|YYYY-MM-DD> <title> [| description] [| tags]` set the menu of a meal you cook\n" +
+	"`/dinny ratio` show your and the worst meals eaten to meals cooked ratios\n" +
+	"`/dinny members` list the current members of dinner rotation"
+
+// ephemeralMsg creates a slash command response only visible to the member who invoked the command.
+func ephemeralMsg(blocks ...slack.Block) *slack.Msg {
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Blocks:       slack.Blocks{BlockSet: blocks},
+	}
+}
+
+// textMsg creates an ephemeral slash command response consisting of a single markdown section.
+func textMsg(format string, a ...interface{}) *slack.Msg {
+	text := slack.NewTextBlockObject("mrkdwn", fmt.Sprintf(format, a...), false, false)
+	return ephemeralMsg(slack.NewSectionBlock(text, nil, nil))
+}
+
+// SlashCommand executes a /dinny slash command on behalf of the invoking member and returns the ephemeral response.
+// Mistakes made by the member are reported within the response rather than as an error.
+func (s *service) SlashCommand(cmd slack.SlashCommand) (*slack.Msg, error) {
+	args := strings.Fields(cmd.Text)
+	var sub string
+	if len(args) > 0 {
+		sub, args = strings.ToLower(args[0]), args[1:]
+	}
+
+	switch sub {
+	case "cooks":
+		return s.slashCooks(args)
+	case "assign":
+		return s.slashAssign(cmd.UserID, args)
+	case "diet":
+		return s.slashDiet(cmd.UserID, cmd.Text)
+	case "menu":
+		return s.slashMenu(cmd.UserID, cmd.Text)
+	case "ratio":
+		return s.slashRatio(cmd.UserID)
+	case "members":
+		return s.slashMembers()
+	case "", "help":
+		return textMsg(slashCommandUsage), nil
+	default:
+		return textMsg("unknown command `%s`\n%s", sub, slashCommandUsage), nil
+	}
+}
+
+// slashCooks lists the cooks of the upcoming days.
+func (s *service) slashCooks(args []string) (*slack.Msg, error) {
+	daysWanted := 7
+	if len(args) > 0 {
+		var err error
+		daysWanted, err = strconv.Atoi(args[0])
+		if err != nil || daysWanted < 1 {
+			return textMsg("`%s` isn't a number of days above 0", args[0]), nil
+		}
+	}
+
+	var lines []string
+	year, month, day := time.Now().In(s.location()).Date()
+	for ii := 0; ii < daysWanted; ii++ {
+		t := time.Date(year, month, day+ii, 0, 0, 0, 0, s.location())
+		date := dinny.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+		meal, err := s.mealService.FindMealByDate(date)
+		if errors.Is(err, dinny.ErrNotFound) {
+			lines = append(lines, fmt.Sprintf("*%s:* not set", t.Format("Mon Jan 2")))
+		} else if err != nil {
+			return nil, fmt.Errorf("slashCooks FindMealByDate: %w", err)
+		} else {
+			lines = append(lines, fmt.Sprintf("*%s:* %s", t.Format("Mon Jan 2"), mentionCooks(meal.CookSlackUIDs())))
+		}
+	}
+	return textMsg("*Upcoming cooks:*\n%s", strings.Join(lines, "\n")), nil
+}
+
+// parseSlackUID extracts the Slack UID of an escaped mention like <@U123|name> or <@U123>.
+func parseSlackUID(mention string) (string, bool) {
+	if !strings.HasPrefix(mention, "<@") || !strings.HasSuffix(mention, ">") {
+		return "", false
+	}
+	uid := strings.TrimSuffix(strings.TrimPrefix(mention, "<@"), ">")
+	uid, _, _ = strings.Cut(uid, "|")
+	return uid, uid != ""
+}
+
+// parseDay parses either a weekday, which refers to the next occurrence of that weekday starting today, or a YYYY-MM-DD date.
+func parseDay(now time.Time, s string) (dinny.Date, bool) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return dinny.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}, true
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if strings.ToLower(s) == name || strings.ToLower(s) == name[:3] {
+			diff := (int(weekday) - int(now.Weekday()) + 7) % 7
+			year, month, day := now.AddDate(0, 0, diff).Date()
+			return dinny.Date{Year: year, Month: month, Day: day}, true
+		}
+	}
+	return dinny.Date{}, false
+}
+
+// slashAssign assigns one or more cooks to a day, the first being the lead cook. Only leaders may assign cooks.
+func (s *service) slashAssign(callerSlackUID string, args []string) (*slack.Msg, error) {
+	caller, err := s.memberService.FindMemberBySlackUID(callerSlackUID)
+	if err != nil && !errors.Is(err, dinny.ErrNotFound) {
+		return nil, fmt.Errorf("slashAssign FindMemberBySlackUID: %w", err)
+	}
+	if caller == nil || !caller.Leader {
+		return textMsg("only leaders may assign cooks"), nil
+	}
+
+	if len(args) < 2 {
+		return textMsg("usage: `/dinny assign @member [@member ...] <weekday|YYYY-MM-DD>`"), nil
+	}
+	var cooks []string
+	for _, arg := range args[:len(args)-1] {
+		cookSlackUID, ok := parseSlackUID(arg)
+		if !ok {
+			return textMsg("`%s` isn't a member mention", arg), nil
+		}
+		for _, cook := range cooks {
+			if cook == cookSlackUID {
+				return textMsg("<@%s> is mentioned more than once", cookSlackUID), nil
+			}
+		}
+		cooks = append(cooks, cookSlackUID)
+	}
+	date, ok := parseDay(time.Now().In(s.location()), args[len(args)-1])
+	if !ok {
+		return textMsg("`%s` isn't a weekday or YYYY-MM-DD date", args[len(args)-1]), nil
+	}
+
+	// Refuse to assign cooks who marked themselves as unable to cook.
+	availabilities, err := s.availabilityService.ListAvailabilities(date, date)
+	if err != nil {
+		return nil, fmt.Errorf("slashAssign ListAvailabilities: %w", err)
+	}
+	for _, cookSlackUID := range cooks {
+		cook, err := s.memberService.FindMemberBySlackUID(cookSlackUID)
+		if errors.Is(err, dinny.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("slashAssign FindMemberBySlackUID: %w", err)
+		}
+		if a := dinny.CookingConflict(availabilities, cook.ID, date); a != nil {
+			return textMsg("<@%s> can't cook on %s (away %s to %s) %s", cookSlackUID, date, a.From, a.To, a.Note), nil
+		}
+	}
+
+	err = s.mealService.AssignCooks(date, cooks)
+	if errors.Is(err, dinny.ErrMealClosed) {
+		return textMsg("the meal on %s has already been cooked", date), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("slashAssign AssignCooks: %w", err)
+	}
+	return textMsg("%s cooking on %s", mentionCooks(cooks), date), nil
+}
+
+// slashMenu sets the menu of a meal from text like "menu fri Lasagna | with garlic bread | vegetarian".
+// Only the cooks of the meal and leaders may set its menu.
+func (s *service) slashMenu(callerSlackUID string, text string) (*slack.Msg, error) {
+	const usage = "usage: `/dinny menu <weekday|YYYY-MM-DD> <title> [| description] [| tags]`"
+	fields := strings.Fields(text)
+	if len(fields) < 3 {
+		return textMsg(usage), nil
+	}
+	date, ok := parseDay(time.Now().In(s.location()), fields[1])
+	if !ok {
+		return textMsg("`%s` isn't a weekday or YYYY-MM-DD date", fields[1]), nil
+	}
+	rest := strings.TrimSpace(text)
+	rest = strings.TrimSpace(rest[len(fields[0]):])
+	rest = strings.TrimSpace(rest[len(fields[1]):])
+	parts := strings.SplitN(rest, "|", 3)
+	title := strings.TrimSpace(parts[0])
+	if title == "" {
+		return textMsg("the menu needs a title, %s", usage), nil
+	}
+	var description string
+	if len(parts) > 1 {
+		description = strings.TrimSpace(parts[1])
+	}
+	tags := []string{}
+	if len(parts) > 2 {
+		var err error
+		tags, err = dinny.ParseMealTags(parts[2])
+		if err != nil {
+			return textMsg("%s", err.Error()), nil
+		}
+	}
+
+	meal, err := s.mealService.FindMealByDate(date)
+	if errors.Is(err, dinny.ErrNotFound) {
+		return textMsg("nobody is cooking on %s", date), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("slashMenu FindMealByDate: %w", err)
+	}
+	if !meal.HasCook(callerSlackUID) {
+		caller, err := s.memberService.FindMemberBySlackUID(callerSlackUID)
+		if err != nil && !errors.Is(err, dinny.ErrNotFound) {
+			return nil, fmt.Errorf("slashMenu FindMemberBySlackUID: %w", err)
+		}
+		if caller == nil || !caller.Leader {
+			return textMsg("only %s and leaders may set the menu on %s", mentionCooks(meal.CookSlackUIDs()), date), nil
+		}
+	}
+
+	err = s.SetMenu(date, dinny.MealUpdate{Title: &title, Description: &description, Tags: &tags})
+	if errors.Is(err, dinny.ErrMealClosed) {
+		return textMsg("the meal on %s has already been closed out", date), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("slashMenu: %w", err)
+	}
+	return textMsg("the menu on %s is *%s*", date, title), nil
+}
+
+// slashDiet shows the invoking member's dietary profile, or sets it from text like "diet vegan, nut-allergy | shellfish".
+func (s *service) slashDiet(callerSlackUID string, text string) (*slack.Msg, error) {
+	member, err := s.findOrCreateMember(callerSlackUID)
+	if err != nil {
+		return nil, fmt.Errorf("slashDiet: %w", err)
+	}
+	rest := strings.TrimSpace(text)
+	rest = strings.TrimSpace(rest[len(strings.Fields(rest)[0]):])
+
+	if rest == "" {
+		profile, err := s.dietService.FindDietaryProfile(member.ID)
+		if errors.Is(err, dinny.ErrNotFound) {
+			return textMsg("you haven't set any dietary restrictions"), nil
+		} else if err != nil {
+			return nil, fmt.Errorf("slashDiet FindDietaryProfile: %w", err)
+		}
+		line := dietLine(member, profile, nil)
+		if line == "" {
+			return textMsg("you haven't set any dietary restrictions"), nil
+		}
+		return textMsg("*Your dietary profile:*\n%s", line), nil
+	}
+
+	parts := strings.SplitN(rest, "|", 2)
+	profile := &dinny.DietaryProfile{MemberID: member.ID, Restrictions: []string{}}
+	if r := strings.TrimSpace(parts[0]); strings.ToLower(r) != "none" {
+		profile.Restrictions, err = dinny.ParseDietRestrictions(r)
+		if err != nil {
+			return textMsg("%s", err.Error()), nil
+		}
+	}
+	if len(parts) > 1 {
+		profile.Allergies = strings.TrimSpace(parts[1])
+	}
+	err = s.dietService.SetDietaryProfile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("slashDiet SetDietaryProfile: %w", err)
+	}
+	return textMsg("your dietary profile has been updated"), nil
+}
+
+// slashRatio shows the invoking member's ratio alongside the worst ratios.
+func (s *service) slashRatio(callerSlackUID string) (*slack.Msg, error) {
+	members, err := s.rankedMembers()
+	if err != nil {
+		return nil, fmt.Errorf("slashRatio: %w", err)
+	}
+	sortByWorstRatio(members, s.config.WeightedRanking)
+
+	yours := "you haven't eaten or cooked yet"
+	var worst []string
+	for ii, member := range members {
+		if member.SlackUID == callerSlackUID {
+			yours = memberRatioStatus(member, s.config.WeightedRanking)
+		}
+		if ii < 10 {
+			worst = append(worst, fmt.Sprintf("%d. <@%s> %s", ii+1, member.SlackUID, memberRatioStatus(member, s.config.WeightedRanking)))
+		}
+	}
+	return textMsg("*Your ratio:* %s\n\n*Worst ratios:*\n%s", yours, strings.Join(worst, "\n")), nil
+}
+
+// slashMembers lists the current members of dinner rotation.
+func (s *service) slashMembers() (*slack.Msg, error) {
+	members, err := s.memberService.ListMembers()
+	if err != nil {
+		return nil, fmt.Errorf("slashMembers ListMembers: %w", err)
+	}
+	var lines []string
+	for _, member := range members {
+		line := fmt.Sprintf("<@%s> %s: eaten %d, cooked %g", member.SlackUID, member.FullName, member.MealsEaten, member.MealsCooked)
+		if member.Leader {
+			line += " (leader)"
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return textMsg("dinner rotation has no members yet"), nil
+	}
+	return textMsg("*Members:*\n%s", strings.Join(lines, "\n")), nil
+}
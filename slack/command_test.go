@@ -0,0 +1,96 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/slack-go/slack"
+)
+
+// TestParseDay ensures weekdays resolve to their next occurrence starting today and dates are parsed as is.
+func TestParseDay(t *testing.T) {
+	// 2023-01-04 was a Wednesday.
+	now := time.Date(2023, time.January, 4, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		day    string
+		want   dinny.Date
+		wantOk bool
+	}{
+		{"wednesday", dinny.Date{Year: 2023, Month: time.January, Day: 4}, true},
+		{"Fri", dinny.Date{Year: 2023, Month: time.January, Day: 6}, true},
+		{"tuesday", dinny.Date{Year: 2023, Month: time.January, Day: 10}, true},
+		{"2023-02-28", dinny.Date{Year: 2023, Month: time.February, Day: 28}, true},
+		{"someday", dinny.Date{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.day, func(t *testing.T) {
+			got, ok := parseDay(now, tt.day)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("parseDay() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+// TestParseSlackUID ensures escaped mentions are parsed with and without a display name.
+func TestParseSlackUID(t *testing.T) {
+	tests := []struct {
+		mention string
+		want    string
+		wantOk  bool
+	}{
+		{"<@U123|jane>", "U123", true},
+		{"<@U123>", "U123", true},
+		{"@jane", "", false},
+		{"<@>", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mention, func(t *testing.T) {
+			got, ok := parseSlackUID(tt.mention)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("parseSlackUID() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+// TestRSVPDeadline ensures meals default to the configured time of day on the meal day unless they override it.
+func TestRSVPDeadline(t *testing.T) {
+	override := time.Date(2023, time.January, 1, 20, 0, 0, 0, time.UTC)
+	date := dinny.Date{Year: 2023, Month: time.January, Day: 2}
+	tests := []struct {
+		name   string
+		config Config
+		meal   dinny.Meal
+		want   time.Time
+	}{
+		{"default", Config{Location: time.UTC}, dinny.Meal{Date: date}, time.Date(2023, time.January, 2, 12, 0, 0, 0, time.UTC)},
+		{"configured", Config{Location: time.UTC, RSVPDeadline: 9*time.Hour + 30*time.Minute}, dinny.Meal{Date: date}, time.Date(2023, time.January, 2, 9, 30, 0, 0, time.UTC)},
+		{"override", Config{Location: time.UTC}, dinny.Meal{Date: date, RSVPDeadline: &override}, override},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &service{config: &tt.config}
+			if got := s.rsvpDeadline(&tt.meal); !got.Equal(tt.want) {
+				t.Errorf("rsvpDeadline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSlashMenuEmptyTitle ensures a menu without a title is rejected before any meal is looked up.
+func TestSlashMenuEmptyTitle(t *testing.T) {
+	s := &service{config: &Config{Location: time.UTC}}
+	for _, text := range []string{"menu 2023-01-02 | with garlic bread", "menu 2023-01-02 | | vegan"} {
+		msg, err := s.slashMenu("U1", text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := msg.Blocks.BlockSet[0].(*slack.SectionBlock).Text.Text
+		if !strings.Contains(got, "needs a title") {
+			t.Errorf("slashMenu(%q) = %q, want a missing title message", text, got)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/slack-go/slack"
+)
+
+// DefaultGuestReactions are the reactions with which members bring guests unless configured otherwise.
+var DefaultGuestReactions = []string{"one", "two", "three", "four", "five"}
+
+// rsvpReactions returns the configured RSVP reactions, or the default ones.
+func (s *service) rsvpReactions() []string {
+	if len(s.config.RSVPReactions) > 0 {
+		return s.config.RSVPReactions
+	}
+	return DefaultRSVPReactions
+}
+
+// guestReactions returns the configured guest reactions, or the default ones.
+func (s *service) guestReactions() []string {
+	if len(s.config.GuestReactions) > 0 {
+		return s.config.GuestReactions
+	}
+	return DefaultGuestReactions
+}
+
+// parseReaction determines whether a reaction is an RSVP or brings guests, ignoring its skin tone.
+// Returns the number of guests, which is 0 for RSVPs, and false if the reaction is neither.
+func (s *service) parseReaction(reaction string) (int64, bool) {
+	if i := strings.Index(reaction, "::skin-tone-"); i >= 0 {
+		reaction = reaction[:i]
+	}
+	for _, r := range s.rsvpReactions() {
+		if r == reaction {
+			return 0, true
+		}
+	}
+	for ii, r := range s.guestReactions() {
+		if r == reaction {
+			return int64(ii + 1), true
+		}
+	}
+	return 0, false
+}
+
+// reactGuests sets the number of guests the member brings to the meal when they add a guest reaction, and removes them when they remove it.
+// Removing a guest reaction other than the latest one leaves the guests alone.
+func (s *service) reactGuests(meal *dinny.Meal, slackUID string, count int64, added bool) error {
+	member, err := s.findOrCreateMember(slackUID)
+	if err != nil {
+		return fmt.Errorf("reactGuests: %w", err)
+	}
+
+	if !added {
+		guests, err := s.attendanceService.ListGuestsByMeal(meal.ID)
+		if err != nil {
+			return fmt.Errorf("reactGuests ListGuestsByMeal: %w", err)
+		}
+		current := int64(0)
+		for _, g := range guests {
+			if g.MemberID == member.ID {
+				current = g.Count
+			}
+		}
+		if current != count {
+			return nil
+		}
+		count = 0
+	}
+
+	err = s.setGuests(meal, member, count)
+	if err != nil {
+		return fmt.Errorf("reactGuests: %w", err)
+	}
+	return nil
+}
+
+// SetGuests sets the number of guests the Slack member brings to the meal on date, just like a guest reaction.
+// Returns ErrMealClosed if the meal has been closed out or its RSVP deadline has passed.
+func (s *service) SetGuests(date dinny.Date, slackUID string, count int64) error {
+	meal, err := s.mealService.FindMealByDate(date)
+	if err != nil {
+		return fmt.Errorf("SetGuests FindMealByDate: %w", err)
+	}
+	if meal.Closed() {
+		return fmt.Errorf("SetGuests: %w", dinny.ErrMealClosed)
+	}
+	if meal.RSVPsClosedAt != nil || time.Now().After(s.rsvpDeadline(meal)) {
+		err = s.closeRSVPs(meal)
+		if err != nil {
+			return fmt.Errorf("SetGuests: %w", err)
+		}
+		return fmt.Errorf("SetGuests: RSVPs closed at %s: %w", s.rsvpDeadline(meal).Format("Mon Jan 2 15:04"), dinny.ErrMealClosed)
+	}
+	member, err := s.findOrCreateMember(slackUID)
+	if err != nil {
+		return fmt.Errorf("SetGuests: %w", err)
+	}
+	err = s.setGuests(meal, member, count)
+	if err != nil {
+		return fmt.Errorf("SetGuests: %w", err)
+	}
+	return nil
+}
+
+// setGuests sets the number of guests the member brings to the meal, lets the cooks know if the headcount was already
+// sent, and edits the 'who's eating' message to show them.
+func (s *service) setGuests(meal *dinny.Meal, member *dinny.Member, count int64) error {
+	err := s.attendanceService.SetGuests(meal.ID, member.ID, count)
+	if err != nil {
+		return fmt.Errorf("setGuests SetGuests: %w", err)
+	}
+	err = s.sendLateChange(meal, fmt.Sprintf("<@%s> now brings %d guests", member.SlackUID, count))
+	if err != nil {
+		return fmt.Errorf("setGuests: %w", err)
+	}
+	err = s.refreshEatingTomorrow(meal.ID)
+	if err != nil {
+		return fmt.Errorf("setGuests: %w", err)
+	}
+	return nil
+}
+
+// rejectLateGuests lets the member know that their guest reaction wasn't counted because the RSVPs of the meal are closed.
+func (s *service) rejectLateGuests(meal *dinny.Meal, slackUID string, channel string) error {
+	err := s.closeRSVPs(meal)
+	if err != nil {
+		return fmt.Errorf("rejectLateGuests: %w", err)
+	}
+	text := fmt.Sprintf("RSVPs for dinner on %s closed at %s, so your guests weren't changed. Please let %s know directly.", meal.Date, s.rsvpDeadline(meal).Format("Mon Jan 2 15:04"), mentionCooks(meal.CookSlackUIDs()))
+	_, err = s.client.PostEphemeral(channel, slackUID, slack.MsgOptionText(text, false))
+	if err != nil {
+		return fmt.Errorf("rejectLateGuests PostEphemeral: %w", err)
+	}
+	return nil
+}
@@ -3,8 +3,9 @@ package slack
 import (
 	"errors"
 	"fmt"
-	"math"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ddritzenhoff/dinny"
@@ -16,26 +17,73 @@ import (
 type Service interface {
 	PostEatingTomorrow() error
 	WeeklyUpdate() error
+	CloseOutMeal(date dinny.Date) error
+	CloseOutPastMeals() error
+	CancelMeal(date dinny.Date, reason string) error
+	SwapCooks(first dinny.Date, second dinny.Date) error
+	SetMenu(date dinny.Date, upd dinny.MealUpdate) error
+	SendHeadcount(date dinny.Date) error
+	CloseRSVPs() error
+	SetRSVPDeadline(date dinny.Date, deadline time.Time) error
+	SetGuests(date dinny.Date, slackUID string, count int64) error
 	ReactionAddedEvent(e *slackevents.ReactionAddedEvent) error
 	ReactionRemovedEvent(e *slackevents.ReactionRemovedEvent) error
+	BlockActions(callback slack.InteractionCallback) error
+	SlashCommand(cmd slack.SlashCommand) (*slack.Msg, error)
 }
 
+// DefaultRSVPDeadline is the default time of day on the meal day after which members can no longer join or leave a meal.
+const DefaultRSVPDeadline = 12 * time.Hour
+
 // Config represents the configuration values to communicate with the slack API.
 type Config struct {
 	Channel       string
 	BotSigningKey string
+
+	// RSVPDeadline is the time of day on the meal day, as the duration since midnight, after which members can no longer join or leave a meal.
+	// Defaults to DefaultRSVPDeadline. Meals may override it with Meal.RSVPDeadline.
+	RSVPDeadline time.Duration
+
+	// Location is the location in which meal days start. Defaults to time.Local.
+	Location *time.Location
+
+	// RSVPReactions are the reactions with which members RSVP to a 'who's eating' message. Their skin-tone variants are accepted as well.
+	// Defaults to DefaultRSVPReactions.
+	RSVPReactions []string
+
+	// GuestReactions are the reactions with which members bring guests to a meal, the first one bringing one guest, the second two and so on.
+	// Defaults to DefaultGuestReactions. At most dinny.MaxGuests reactions may be given.
+	GuestReactions []string
+
+	// WeightedRanking ranks members by meals eaten to cooking credits, which weigh meals by their headcount,
+	// instead of meals eaten to meals cooked in the weekly update and the ratio command.
+	WeightedRanking bool
+
+	// AllTimeRanking ranks members by their counters summed over every season instead of those of the current season
+	// in the weekly update and the ratio command.
+	AllTimeRanking bool
 }
 
+// DefaultRSVPReactions are the reactions with which members RSVP unless configured otherwise.
+var DefaultRSVPReactions = []string{"+1"}
+
 // service represents the implementation of the Service interface.
 type service struct {
-	client        *slack.Client
-	config        *Config
-	mealService   dinny.MealService
-	memberService dinny.MemberService
+	client              *slack.Client
+	config              *Config
+	mealService         dinny.MealService
+	memberService       dinny.MemberService
+	attendanceService   dinny.AttendanceService
+	availabilityService dinny.AvailabilityService
+	dietService         dinny.DietaryProfileService
+	seasonService       dinny.SeasonService
 }
 
 // NewService returns a new instance of slack.Service.
-func NewService(config *Config, mealService dinny.MealService, memberService dinny.MemberService) (*service, error) {
+func NewService(config *Config, mealService dinny.MealService, memberService dinny.MemberService, attendanceService dinny.AttendanceService, availabilityService dinny.AvailabilityService, dietService dinny.DietaryProfileService, seasonService dinny.SeasonService) (*service, error) {
+	if len(config.GuestReactions) > dinny.MaxGuests {
+		return nil, fmt.Errorf("NewService: at most %d guest reactions may be given", dinny.MaxGuests)
+	}
 	client := slack.New(config.BotSigningKey)
 	if client == nil {
 		return nil, fmt.Errorf("NewService: couldn't generate slack client")
@@ -45,13 +93,165 @@ func NewService(config *Config, mealService dinny.MealService, memberService din
 		config,
 		mealService,
 		memberService,
+		attendanceService,
+		availabilityService,
+		dietService,
+		seasonService,
 	}, nil
 }
 
-// isEatingTomorrowBlock creates a 'who's eating' message to be sent into the slack channel.
-func isEatingTomorrowBlock() slack.MsgOption {
+// location returns the location in which meal days start.
+func (s *service) location() *time.Location {
+	if s.config.Location == nil {
+		return time.Local
+	}
+	return s.config.Location
+}
+
+// expired reports whether the day of the meal has passed, so its 'who's eating' message can't be reacted to anymore.
+func (s *service) expired(meal *dinny.Meal) bool {
+	return meal.Expired(time.Now().In(s.location()))
+}
+
+// rsvpDeadline returns when members can no longer join or leave the meal.
+func (s *service) rsvpDeadline(meal *dinny.Meal) time.Time {
+	if meal.RSVPDeadline != nil {
+		return *meal.RSVPDeadline
+	}
+	offset := s.config.RSVPDeadline
+	if offset == 0 {
+		offset = DefaultRSVPDeadline
+	}
+	return time.Date(meal.Date.Year, meal.Date.Month, meal.Date.Day, 0, 0, 0, 0, s.location()).Add(offset)
+}
+
+// Action IDs of the buttons on the 'who's eating' message.
+const (
+	actionEating    = "rsvp_eating"
+	actionNotEating = "rsvp_not_eating"
+)
+
+// isEatingTomorrowBlock creates a 'who's eating' message to be sent into the slack channel. Renders the cook, the RSVP deadline, the eaters and, if set, the menu.
+// Once the RSVPs of the meal are closed, the message says so instead of showing the RSVP buttons.
+func (s *service) isEatingTomorrowBlock(meal *dinny.Meal, eaters []string, count int64) slack.MsgOption {
 	// Header Section
-	headerText := slack.NewTextBlockObject("mrkdwn", "hey <!channel>, please react to this message (:thumbsup:) if you are eating tomorrow", false, false)
+	rsvpReactions, guestReactions := s.rsvpReactions(), s.guestReactions()
+	header := fmt.Sprintf("hey <!channel>, are you eating tomorrow? Let us know with the buttons below (a :%s: reaction works too). Bringing guests? React with how many (:%s: to :%s:)",
+		rsvpReactions[0], guestReactions[0], guestReactions[len(guestReactions)-1])
+	if meal.RSVPsClosedAt != nil {
+		header = fmt.Sprintf(":lock: RSVPs closed for dinner on %s", meal.Date)
+	}
+	headerText := slack.NewTextBlockObject("mrkdwn", header, false, false)
+	headerSection := slack.NewSectionBlock(headerText, nil, nil)
+	blocks := []slack.Block{headerSection}
+
+	// Menu Section
+	if meal.Title != "" || meal.Description != "" {
+		menu := fmt.Sprintf("*%s*", meal.Title)
+		if meal.Title == "" {
+			menu = "*Menu*"
+		}
+		if meal.Description != "" {
+			menu += "\n" + meal.Description
+		}
+		menuText := slack.NewTextBlockObject("mrkdwn", menu, false, false)
+		blocks = append(blocks, slack.NewSectionBlock(menuText, nil, nil))
+	}
+
+	// Cook Section
+	elements := []slack.MixedElement{
+		slack.NewTextBlockObject("mrkdwn", cookLabel(meal.CookSlackUIDs()), false, false),
+	}
+	if meal.RSVPsClosedAt == nil {
+		elements = append(elements, slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("*RSVP by:* %s", s.rsvpDeadline(meal).Format("Mon Jan 2 15:04")), false, false))
+	}
+	if len(meal.Tags) > 0 {
+		tags := make([]string, len(meal.Tags))
+		for ii, tag := range meal.Tags {
+			tags[ii] = fmt.Sprintf("`%s`", tag)
+		}
+		elements = append(elements, slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Tags:* %s", strings.Join(tags, " ")), false, false))
+	}
+	blocks = append(blocks, slack.NewContextBlock("", elements...))
+
+	// Eaters Section
+	eating := "*Eating:* nobody yet"
+	if len(eaters) > 0 {
+		eating = fmt.Sprintf("*Eating (%d):* %s", count, strings.Join(eaters, ", "))
+	}
+	eatingText := slack.NewTextBlockObject("mrkdwn", eating, false, false)
+	blocks = append(blocks, slack.NewSectionBlock(eatingText, nil, nil))
+
+	// Actions Section
+	if meal.RSVPsClosedAt == nil {
+		value := strconv.FormatInt(meal.ID, 10)
+		eatingButton := slack.NewButtonBlockElement(actionEating, value, slack.NewTextBlockObject("plain_text", "I'm eating", false, false)).WithStyle(slack.StylePrimary)
+		notEatingButton := slack.NewButtonBlockElement(actionNotEating, value, slack.NewTextBlockObject("plain_text", "Not eating", false, false))
+		blocks = append(blocks, slack.NewActionBlock("rsvp", eatingButton, notEatingButton))
+	}
+
+	return slack.MsgOptionBlocks(blocks...)
+}
+
+// eatingTomorrowMsg renders the 'who's eating' message of the meal along with its current eaters.
+func (s *service) eatingTomorrowMsg(meal *dinny.Meal) (slack.MsgOption, error) {
+	eaters, count, err := s.eaters(meal)
+	if err != nil {
+		return nil, fmt.Errorf("eatingTomorrowMsg: %w", err)
+	}
+	return s.isEatingTomorrowBlock(meal, eaters, count), nil
+}
+
+// eaters lists the members eating the meal along with the number of guests they bring, and returns the headcount.
+// Unlike roster, it leaves out dietary restrictions as it is shown to the whole channel.
+func (s *service) eaters(meal *dinny.Meal) ([]string, int64, error) {
+	attendances, err := s.attendanceService.ListAttendancesByMeal(meal.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("eaters ListAttendancesByMeal: %w", err)
+	}
+	guests, err := s.attendanceService.ListGuestsByMeal(meal.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("eaters ListGuestsByMeal: %w", err)
+	}
+	guestsByHost := make(map[int64]int64)
+	for _, g := range guests {
+		guestsByHost[g.MemberID] = g.Count
+	}
+
+	var eaters []string
+	for _, a := range attendances {
+		m, err := s.memberService.FindMemberByID(a.MemberID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("eaters FindMemberByID: %w", err)
+		}
+		eater := fmt.Sprintf("<@%s>", m.SlackUID)
+		if n := guestsByHost[a.MemberID]; n > 0 {
+			eater += fmt.Sprintf(" +%d", n)
+			delete(guestsByHost, a.MemberID)
+		}
+		eaters = append(eaters, eater)
+	}
+	// Guests of hosts who aren't eating themselves.
+	for _, g := range guests {
+		if _, ok := guestsByHost[g.MemberID]; !ok {
+			continue
+		}
+		m, err := s.memberService.FindMemberByID(g.MemberID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("eaters FindMemberByID: %w", err)
+		}
+		eaters = append(eaters, fmt.Sprintf("%d guests of <@%s>", g.Count, m.SlackUID))
+	}
+	return eaters, int64(len(attendances)) + dinny.CountGuests(guests), nil
+}
+
+// cancelledMealBlock replaces the 'who's eating' message of a meal which has been cancelled.
+func cancelledMealBlock(meal *dinny.Meal, reason string) slack.MsgOption {
+	text := fmt.Sprintf("~hey <!channel>, are you eating tomorrow?~\n*Cancelled:* dinner on %s will not take place", meal.Date)
+	if reason != "" {
+		text += fmt.Sprintf(" (%s)", reason)
+	}
+	headerText := slack.NewTextBlockObject("mrkdwn", text, false, false)
 	headerSection := slack.NewSectionBlock(headerText, nil, nil)
 
 	return slack.MsgOptionBlocks(
@@ -59,17 +259,56 @@ func isEatingTomorrowBlock() slack.MsgOption {
 	)
 }
 
-// PostEatingTomorrow sends the 'who's eating' messages into the slack channel.
+// mentionCooks mentions everybody cooking a meal, e.g. "<@U1> and <@U2>".
+func mentionCooks(cooks []string) string {
+	mentions := make([]string, len(cooks))
+	for ii, cook := range cooks {
+		mentions[ii] = fmt.Sprintf("<@%s>", cook)
+	}
+	if len(mentions) < 2 {
+		return strings.Join(mentions, "")
+	}
+	return strings.Join(mentions[:len(mentions)-1], ", ") + " and " + mentions[len(mentions)-1]
+}
+
+// cookLabel labels the cooks of a meal in its 'who's eating' message.
+func cookLabel(cooks []string) string {
+	if len(cooks) > 1 {
+		return fmt.Sprintf("*Cooks:* %s", mentionCooks(cooks))
+	}
+	return fmt.Sprintf("*Cook:* %s", mentionCooks(cooks))
+}
+
+// messageCooks sends text to every cook of the meal as a direct message.
+func (s *service) messageCooks(meal *dinny.Meal, text string) error {
+	for _, cook := range meal.CookSlackUIDs() {
+		_, _, err := s.client.PostMessage(cook, slack.MsgOptionText(text, false))
+		if err != nil {
+			return fmt.Errorf("messageCooks PostMessage: %w", err)
+		}
+	}
+	return nil
+}
+
+// PostEatingTomorrow sends the 'who's eating' messages into the slack channel unless tomorrow's meal has been cancelled.
+// Does nothing if there's no meal tomorrow or its message has already been posted.
 func (s *service) PostEatingTomorrow() error {
-	year, month, day := time.Now().AddDate(0, 0, 1).Date()
-	meal, err := s.mealService.FindMealByDate(dinny.Date{Year: year, Month: month, Day: day})
-	if err != nil {
+	tomorrow := dinny.DateOf(time.Now().In(s.location())).AddDays(1)
+	meal, err := s.mealService.FindMealByDate(tomorrow)
+	if errors.Is(err, dinny.ErrNotFound) {
+		return nil
+	} else if err != nil {
 		return fmt.Errorf("PostEatingTomorrow FindMealByDate: %w", err)
 	}
-	if meal.SlackMessageID != "" {
-		return fmt.Errorf("the slack message has already been posted for tomorrow")
+	// Posting again, e.g. when a failed run is retried, is a no-op.
+	if meal.Closed() || meal.SlackMessageID != "" {
+		return nil
 	}
-	_, respTimestamp, err := s.client.PostMessage(s.config.Channel, isEatingTomorrowBlock())
+	msg, err := s.eatingTomorrowMsg(meal)
+	if err != nil {
+		return fmt.Errorf("PostEatingTomorrow: %w", err)
+	}
+	_, respTimestamp, err := s.client.PostMessage(s.config.Channel, msg)
 	if err != nil {
 		return fmt.Errorf("PostEatingTomorrow PostMessage: %w", err)
 	}
@@ -80,38 +319,52 @@ func (s *service) PostEatingTomorrow() error {
 	return nil
 }
 
-// mealsEatenToMealsCooked calculates the meals eaten to meals cooked ratio. Returns infinity for 0 meals cooked and >0 meals eaten.
-func mealsEatenToMealsCooked(mealsEaten int64, mealsCooked int64) float32 {
-	if mealsCooked == 0 {
-		if mealsEaten > 0 {
-			return math.MaxFloat32
-		} else {
-			return 0
-		}
-	} else {
-		return float32(mealsEaten) / float32(mealsCooked)
-	}
-}
-
 // ratioStatus calculates the the meals eaten to meals cooked ratio. Returns a string instead of a float.
-func ratioStatus(mealsEaten int64, mealsCooked int64) string {
-	if mealsCooked == 0 {
+func ratioStatus(mealsEaten int64, mealsCooked float64) string {
+	if mealsCooked <= 0 {
 		if mealsEaten > 0 {
 			return "Infinity! You've eaten but never cooked"
 		} else {
 			return "Neither cooked nor eaten"
 		}
 	} else {
-		return fmt.Sprintf("%.3f", float32(mealsEaten)/float32(mealsCooked))
+		return fmt.Sprintf("%.3f", float64(mealsEaten)/mealsCooked)
 	}
 }
 
-// weeklyUpdateBlock represents a slack message to give meals eaten to meals cooked ratio statuses.
-func weeklyUpdateBlock(members []*dinny.Member) slack.MsgOption {
+// memberRatioStatus returns the ratio status of a member, dividing by the member's cooking credits instead of meals cooked if weighted.
+func memberRatioStatus(member *dinny.Member, weighted bool) string {
+	if weighted {
+		return ratioStatus(member.MealsEaten, member.CookingCredits)
+	}
+	return ratioStatus(member.MealsEaten, member.MealsCooked)
+}
+
+// sortByWorstRatio sorts members from the worst to the best meals eaten to meals cooked ratio, or to cooking credits if weighted.
+func sortByWorstRatio(members []*dinny.Member, weighted bool) {
+	sort.Slice(members, func(ii, jj int) bool {
+		if weighted {
+			return members[ii].WeightedRatio() > members[jj].WeightedRatio()
+		}
+		return members[ii].Ratio() > members[jj].Ratio()
+	})
+}
+
+// weeklyUpdateBlock represents a slack message to give meals eaten to meals cooked ratio statuses, or to cooking credits if weighted,
+// over the current season or of all time.
+func weeklyUpdateBlock(members []*dinny.Member, weighted bool, allTime bool) slack.MsgOption {
 
 	var sectionBlocks []slack.Block
 	// Header Section
-	headerText := slack.NewTextBlockObject("mrkdwn", "dinner rotation members with the *worst* meals eaten to meals cooked ratios:", false, false)
+	scope := "this season"
+	if allTime {
+		scope = "of all time"
+	}
+	header := fmt.Sprintf("dinner rotation members with the *worst* meals eaten to meals cooked ratios %s:", scope)
+	if weighted {
+		header = fmt.Sprintf("dinner rotation members with the *worst* meals eaten to cooking credits ratios %s, weighing meals by how many ate them:", scope)
+	}
+	headerText := slack.NewTextBlockObject("mrkdwn", header, false, false)
 	headerSection := slack.NewSectionBlock(headerText, nil, nil)
 	sectionBlocks = append(sectionBlocks, *headerSection)
 
@@ -122,7 +375,7 @@ func weeklyUpdateBlock(members []*dinny.Member) slack.MsgOption {
 
 		realNameField := slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Real Name:*\n%s", member.FullName), false, false)
 		slackNameField := slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Slack Name:*\n<@%s>", member.SlackUID), false, false)
-		ratioField := slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Ratio Status:*\n%s", ratioStatus(member.MealsEaten, member.MealsCooked)), false, false)
+		ratioField := slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Ratio Status:*\n%s", memberRatioStatus(member, weighted)), false, false)
 
 		fieldSlice := make([]*slack.TextBlockObject, 0)
 		fieldSlice = append(fieldSlice, realNameField)
@@ -135,29 +388,523 @@ func weeklyUpdateBlock(members []*dinny.Member) slack.MsgOption {
 	return slack.MsgOptionBlocks(sectionBlocks...)
 }
 
+// rankedMembers retrieves the members with the counters they are ranked by: those of the current season, or all-time ones if configured.
+func (s *service) rankedMembers() ([]*dinny.Member, error) {
+	if s.config.AllTimeRanking {
+		members, err := s.seasonService.ListAllTimeMembers()
+		if err != nil {
+			return nil, fmt.Errorf("rankedMembers ListAllTimeMembers: %w", err)
+		}
+		return members, nil
+	}
+	members, err := s.memberService.ListMembers()
+	if err != nil {
+		return nil, fmt.Errorf("rankedMembers ListMembers: %w", err)
+	}
+	return members, nil
+}
+
 // WeeklyUpdate sends the weeklyUpdateBlock into Slack.
 func (s *service) WeeklyUpdate() error {
-	members, err := s.memberService.ListMembers()
+	members, err := s.rankedMembers()
 	if err != nil {
-		return fmt.Errorf("WeeklyUpdate ListMembers: %w", err)
+		return fmt.Errorf("WeeklyUpdate: %w", err)
 	}
 
-	sort.Slice(members, func(ii, jj int) bool {
-		return mealsEatenToMealsCooked(members[ii].MealsEaten, members[ii].MealsCooked) > mealsEatenToMealsCooked(members[jj].MealsEaten, members[jj].MealsCooked)
-	})
+	sortByWorstRatio(members, s.config.WeightedRanking)
 
-	_, _, err = s.client.PostMessage(s.config.Channel, weeklyUpdateBlock(members))
+	_, _, err = s.client.PostMessage(s.config.Channel, weeklyUpdateBlock(members, s.config.WeightedRanking, s.config.AllTimeRanking))
 	if err != nil {
 		return fmt.Errorf("WeeklyUpdate PostMessage: %w", err)
 	}
 	return nil
 }
 
-// ReactionAddedEvent adds 1 to the Slack member's meals_eaten if a valid 'is eating' message were liked.
+// CloseOutMeal closes out the meal on date as cooked, crediting its cooks and freezing its eaters.
+// Closing out a day without a meal or a meal which has already been closed out is a no-op.
+func (s *service) CloseOutMeal(date dinny.Date) error {
+	meal, err := s.mealService.FindMealByDate(date)
+	if errors.Is(err, dinny.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("CloseOutMeal FindMealByDate: %w", err)
+	}
+	if meal.Closed() {
+		return nil
+	}
+	err = s.completeMeal(meal)
+	if err != nil {
+		return fmt.Errorf("CloseOutMeal: %w", err)
+	}
+	return nil
+}
+
+// CloseOutPastMeals closes out every meal before today which is still scheduled as cooked, so meals are credited even if
+// closing them out was missed, e.g. because dinnyd was down.
+func (s *service) CloseOutPastMeals() error {
+	meals, err := s.mealService.ListScheduledMealsBefore(dinny.DateOf(time.Now().In(s.location())))
+	if err != nil {
+		return fmt.Errorf("CloseOutPastMeals: %w", err)
+	}
+	for _, meal := range meals {
+		err := s.completeMeal(meal)
+		if err != nil {
+			return fmt.Errorf("CloseOutPastMeals meal on %s: %w", meal.Date, err)
+		}
+	}
+	return nil
+}
+
+// completeMeal closes out the scheduled meal as cooked, crediting its cooks and freezing its eaters.
+func (s *service) completeMeal(meal *dinny.Meal) error {
+	// The cooks may have never reacted to a 'who's eating tomorrow' message, so make sure they can be credited.
+	for _, cook := range meal.CookSlackUIDs() {
+		_, err := s.findOrCreateMember(cook)
+		if err != nil {
+			return fmt.Errorf("completeMeal: %w", err)
+		}
+	}
+	err := s.mealService.CompleteMeal(meal.ID)
+	if err != nil {
+		return fmt.Errorf("completeMeal CompleteMeal: %w", err)
+	}
+	return nil
+}
+
+// CancelMeal cancels the meal on date and lets the channel know. If the 'who's eating' message has already been posted, it is edited to show the cancellation.
+func (s *service) CancelMeal(date dinny.Date, reason string) error {
+	meal, err := s.mealService.FindMealByDate(date)
+	if err != nil {
+		return fmt.Errorf("CancelMeal FindMealByDate: %w", err)
+	}
+	err = s.mealService.CancelMeal(meal.ID)
+	if err != nil {
+		return fmt.Errorf("CancelMeal: %w", err)
+	}
+
+	if meal.SlackMessageID != "" {
+		_, _, _, err = s.client.UpdateMessage(s.config.Channel, meal.SlackMessageID, cancelledMealBlock(meal, reason))
+		if err != nil {
+			return fmt.Errorf("CancelMeal UpdateMessage: %w", err)
+		}
+	}
+	text := fmt.Sprintf("hey <!channel>, dinner on %s cooked by %s has been cancelled", date, mentionCooks(meal.CookSlackUIDs()))
+	if reason != "" {
+		text += fmt.Sprintf(": %s", reason)
+	}
+	_, _, err = s.client.PostMessage(s.config.Channel, slack.MsgOptionText(text, false))
+	if err != nil {
+		return fmt.Errorf("CancelMeal PostMessage: %w", err)
+	}
+	return nil
+}
+
+// SwapCooks swaps the cooks of the meals on the two dates and lets the channel know. Any 'who's eating' messages which have already been posted are edited to show the new cook.
+func (s *service) SwapCooks(first dinny.Date, second dinny.Date) error {
+	err := s.mealService.SwapCooks(first, second)
+	if err != nil {
+		return fmt.Errorf("SwapCooks: %w", err)
+	}
+
+	var cooks []string
+	for _, date := range []dinny.Date{first, second} {
+		meal, err := s.mealService.FindMealByDate(date)
+		if err != nil {
+			return fmt.Errorf("SwapCooks FindMealByDate: %w", err)
+		}
+		cooks = append(cooks, mentionCooks(meal.CookSlackUIDs()))
+		if meal.SlackMessageID == "" {
+			continue
+		}
+		msg, err := s.eatingTomorrowMsg(meal)
+		if err != nil {
+			return fmt.Errorf("SwapCooks: %w", err)
+		}
+		_, _, _, err = s.client.UpdateMessage(s.config.Channel, meal.SlackMessageID, msg)
+		if err != nil {
+			return fmt.Errorf("SwapCooks UpdateMessage: %w", err)
+		}
+	}
+	text := fmt.Sprintf("hey <!channel>, cooks have been swapped: %s now cooking on %s and %s now cooking on %s", cooks[0], first, cooks[1], second)
+	_, _, err = s.client.PostMessage(s.config.Channel, slack.MsgOptionText(text, false))
+	if err != nil {
+		return fmt.Errorf("SwapCooks PostMessage: %w", err)
+	}
+	return nil
+}
+
+// SetMenu sets the menu of the scheduled meal on date. If the 'who's eating' message has already been posted, it is edited to show the new menu.
+// If the tags change, the cooks are warned about eaters whose dietary restrictions the new menu may not cover.
+func (s *service) SetMenu(date dinny.Date, upd dinny.MealUpdate) error {
+	meal, err := s.mealService.FindMealByDate(date)
+	if err != nil {
+		return fmt.Errorf("SetMenu FindMealByDate: %w", err)
+	}
+	if meal.Closed() {
+		return fmt.Errorf("SetMenu: %w", dinny.ErrMealClosed)
+	}
+	err = s.mealService.UpdateMeal(meal.ID, dinny.MealUpdate{Title: upd.Title, Description: upd.Description, Tags: upd.Tags})
+	if err != nil {
+		return fmt.Errorf("SetMenu UpdateMeal: %w", err)
+	}
+	if upd.Tags != nil {
+		meal.Tags = *upd.Tags
+		err = s.warnCookOfDiets(meal)
+		if err != nil {
+			return fmt.Errorf("SetMenu: %w", err)
+		}
+	}
+
+	err = s.refreshEatingTomorrow(meal.ID)
+	if err != nil {
+		return fmt.Errorf("SetMenu: %w", err)
+	}
+	return nil
+}
+
+// SetRSVPDeadline overrides the RSVP deadline of the scheduled meal on date. If the 'who's eating' message has already been posted, it is edited to show the new deadline.
+// Returns ErrMealClosed if the meal or its RSVPs have been closed.
+func (s *service) SetRSVPDeadline(date dinny.Date, deadline time.Time) error {
+	meal, err := s.mealService.FindMealByDate(date)
+	if err != nil {
+		return fmt.Errorf("SetRSVPDeadline FindMealByDate: %w", err)
+	}
+	if meal.Closed() || meal.RSVPsClosedAt != nil {
+		return fmt.Errorf("SetRSVPDeadline: %w", dinny.ErrMealClosed)
+	}
+	err = s.mealService.UpdateMeal(meal.ID, dinny.MealUpdate{RSVPDeadline: &deadline})
+	if err != nil {
+		return fmt.Errorf("SetRSVPDeadline UpdateMeal: %w", err)
+	}
+	err = s.refreshEatingTomorrow(meal.ID)
+	if err != nil {
+		return fmt.Errorf("SetRSVPDeadline: %w", err)
+	}
+	return nil
+}
+
+// refreshEatingTomorrow edits the 'who's eating' message of the meal to reflect its current state, if it has been posted.
+func (s *service) refreshEatingTomorrow(mealID int64) error {
+	meal, err := s.mealService.FindMealByID(mealID)
+	if err != nil {
+		return fmt.Errorf("refreshEatingTomorrow FindMealByID: %w", err)
+	}
+	if meal.SlackMessageID == "" {
+		return nil
+	}
+	msg, err := s.eatingTomorrowMsg(meal)
+	if err != nil {
+		return fmt.Errorf("refreshEatingTomorrow: %w", err)
+	}
+	_, _, _, err = s.client.UpdateMessage(s.config.Channel, meal.SlackMessageID, msg)
+	if err != nil {
+		return fmt.Errorf("refreshEatingTomorrow UpdateMessage: %w", err)
+	}
+	return nil
+}
+
+// roster lists the members eating the meal along with their guests and dietary restrictions, and returns the headcount.
+// Eaters who are marked as not eating on the day of the meal are flagged, as the cooks may want to check with them.
+func (s *service) roster(meal *dinny.Meal, availabilities []*dinny.Availability) ([]string, int64, error) {
+	attendances, err := s.attendanceService.ListAttendancesByMeal(meal.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("roster ListAttendancesByMeal: %w", err)
+	}
+	guests, err := s.attendanceService.ListGuestsByMeal(meal.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("roster ListGuestsByMeal: %w", err)
+	}
+	guestsByHost := make(map[int64]int64)
+	for _, g := range guests {
+		guestsByHost[g.MemberID] = g.Count
+	}
+
+	var lines []string
+	for _, a := range attendances {
+		m, err := s.memberService.FindMemberByID(a.MemberID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("roster FindMemberByID: %w", err)
+		}
+		line := fmt.Sprintf("• <@%s>", m.SlackUID)
+		p, err := s.dietService.FindDietaryProfile(a.MemberID)
+		if err != nil && !errors.Is(err, dinny.ErrNotFound) {
+			return nil, 0, fmt.Errorf("roster FindDietaryProfile: %w", err)
+		}
+		if p != nil {
+			if l := dietLine(m, p, p.Conflicts(meal.Tags)); l != "" {
+				line = l
+			}
+		}
+		if n := guestsByHost[a.MemberID]; n > 0 {
+			line += fmt.Sprintf(" +%d", n)
+			delete(guestsByHost, a.MemberID)
+		}
+		if dinny.EatingConflict(availabilities, a.MemberID, meal.Date) != nil {
+			line += " _(marked as not eating)_"
+		}
+		lines = append(lines, line)
+	}
+	// Guests of hosts who aren't eating themselves.
+	for _, g := range guests {
+		if _, ok := guestsByHost[g.MemberID]; !ok {
+			continue
+		}
+		m, err := s.memberService.FindMemberByID(g.MemberID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("roster FindMemberByID: %w", err)
+		}
+		lines = append(lines, fmt.Sprintf("• %d guests of <@%s>", g.Count, m.SlackUID))
+	}
+	return lines, int64(len(attendances)) + dinny.CountGuests(guests), nil
+}
+
+// notEating mentions the members who are marked as not eating on the day of the meal and haven't RSVPed to it.
+func (s *service) notEating(meal *dinny.Meal, availabilities []*dinny.Availability) ([]string, error) {
+	attendances, err := s.attendanceService.ListAttendancesByMeal(meal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("notEating ListAttendancesByMeal: %w", err)
+	}
+	attending := make(map[int64]bool, len(attendances))
+	for _, a := range attendances {
+		attending[a.MemberID] = true
+	}
+	var mentions []string
+	for _, a := range availabilities {
+		if !a.NotEating || !a.Covers(meal.Date) || attending[a.MemberID] {
+			continue
+		}
+		attending[a.MemberID] = true
+		m, err := s.memberService.FindMemberByID(a.MemberID)
+		if err != nil {
+			return nil, fmt.Errorf("notEating FindMemberByID: %w", err)
+		}
+		mentions = append(mentions, fmt.Sprintf("<@%s>", m.SlackUID))
+	}
+	return mentions, nil
+}
+
+// headcount returns the number of members and guests eating the meal.
+func (s *service) headcount(meal *dinny.Meal) (int64, error) {
+	attendances, err := s.attendanceService.ListAttendancesByMeal(meal.ID)
+	if err != nil {
+		return 0, fmt.Errorf("headcount ListAttendancesByMeal: %w", err)
+	}
+	guests, err := s.attendanceService.ListGuestsByMeal(meal.ID)
+	if err != nil {
+		return 0, fmt.Errorf("headcount ListGuestsByMeal: %w", err)
+	}
+	return int64(len(attendances)) + dinny.CountGuests(guests), nil
+}
+
+// SendHeadcount sends the cooks of the meal on date a roster of who's eating. The headcount is only ever sent once to each cook,
+// after which changes to who's eating are sent to the cooks as late changes.
+// Sending the headcount of a day without a meal or of a meal which has been closed out is a no-op.
+func (s *service) SendHeadcount(date dinny.Date) error {
+	meal, err := s.mealService.FindMealByDate(date)
+	if errors.Is(err, dinny.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("SendHeadcount FindMealByDate: %w", err)
+	}
+	if meal.Closed() {
+		return nil
+	}
+	delivered, err := s.mealService.ListHeadcountDeliveries(meal.ID)
+	if err != nil {
+		return fmt.Errorf("SendHeadcount ListHeadcountDeliveries: %w", err)
+	}
+	sent := make(map[string]bool, len(delivered))
+	for _, cook := range delivered {
+		sent[cook] = true
+	}
+	var cooks []string
+	for _, cook := range meal.CookSlackUIDs() {
+		if !sent[cook] {
+			cooks = append(cooks, cook)
+		}
+	}
+	if len(cooks) == 0 {
+		return nil
+	}
+	availabilities, err := s.availabilityService.ListAvailabilities(meal.Date, meal.Date)
+	if err != nil {
+		return fmt.Errorf("SendHeadcount ListAvailabilities: %w", err)
+	}
+	lines, count, err := s.roster(meal, availabilities)
+	if err != nil {
+		return fmt.Errorf("SendHeadcount: %w", err)
+	}
+	away, err := s.notEating(meal, availabilities)
+	if err != nil {
+		return fmt.Errorf("SendHeadcount: %w", err)
+	}
+
+	text := fmt.Sprintf("*Headcount for %s:* %d eating", date, count)
+	if len(lines) > 0 {
+		text += "\n" + strings.Join(lines, "\n")
+	}
+	if len(away) > 0 {
+		text += fmt.Sprintf("\n*Marked as not eating:* %s", strings.Join(away, ", "))
+	}
+	lateRSVPs, err := s.attendanceService.ListLateRSVPsByMeal(meal.ID)
+	if err != nil {
+		return fmt.Errorf("SendHeadcount ListLateRSVPsByMeal: %w", err)
+	}
+	if len(lateRSVPs) > 0 {
+		text += "\n*After RSVPs closed (not counted):*"
+		for _, l := range lateRSVPs {
+			m, err := s.memberService.FindMemberByID(l.MemberID)
+			if err != nil {
+				return fmt.Errorf("SendHeadcount FindMemberByID: %w", err)
+			}
+			change := "wants to eat"
+			if !l.Eating {
+				change = "isn't eating anymore"
+			}
+			text += fmt.Sprintf("\n• <@%s> %s", m.SlackUID, change)
+		}
+	}
+	if time.Now().Before(s.rsvpDeadline(meal)) {
+		text += "\nI'll let you know about any late changes."
+	}
+
+	// Record who the headcount was sent to as it goes out, so a run retried after a failure only sends it to the cooks who
+	// are missing it. The meal is marked as sent once any cook has it, so they hear about late changes in the meantime.
+	for _, cook := range cooks {
+		_, _, err = s.client.PostMessage(cook, slack.MsgOptionText(text, false))
+		if err != nil {
+			return fmt.Errorf("SendHeadcount PostMessage: %w", err)
+		}
+		err = s.mealService.CreateHeadcountDelivery(meal.ID, cook)
+		if err != nil {
+			return fmt.Errorf("SendHeadcount CreateHeadcountDelivery: %w", err)
+		}
+		_, err = s.mealService.MarkHeadcountSent(meal.ID)
+		if err != nil {
+			return fmt.Errorf("SendHeadcount MarkHeadcountSent: %w", err)
+		}
+	}
+	return nil
+}
+
+// closeRSVPs marks the RSVPs of the meal as closed and edits its 'who's eating' message to say so. Closing them again is a no-op.
+func (s *service) closeRSVPs(meal *dinny.Meal) error {
+	closed, err := s.mealService.CloseRSVPs(meal.ID)
+	if err != nil {
+		return fmt.Errorf("closeRSVPs: %w", err)
+	}
+	if !closed {
+		return nil
+	}
+	err = s.refreshEatingTomorrow(meal.ID)
+	if err != nil {
+		return fmt.Errorf("closeRSVPs: %w", err)
+	}
+	return nil
+}
+
+// CloseRSVPs closes the RSVPs of the recent meals whose RSVP deadline has passed, editing their 'who's eating' messages to say so.
+func (s *service) CloseRSVPs() error {
+	today := dinny.DateOf(time.Now().In(s.location()))
+	meals, err := s.mealService.ListMeals(today.AddDays(-1), today.AddDays(1))
+	if err != nil {
+		return fmt.Errorf("CloseRSVPs ListMeals: %w", err)
+	}
+	for _, meal := range meals {
+		if meal.SlackMessageID == "" || meal.RSVPsClosedAt != nil || meal.Closed() {
+			continue
+		}
+		if time.Now().Before(s.rsvpDeadline(meal)) {
+			continue
+		}
+		err := s.closeRSVPs(meal)
+		if err != nil {
+			return fmt.Errorf("CloseRSVPs: %w", err)
+		}
+	}
+	return nil
+}
+
+// rejectLateRSVP records a reaction or button click made after the RSVP deadline of the meal as a late RSVP instead of changing who's eating,
+// and lets the member know that it wasn't counted.
+func (s *service) rejectLateRSVP(meal *dinny.Meal, slackUID string, channel string, eating bool) error {
+	member, err := s.findOrCreateMember(slackUID)
+	if err != nil {
+		return fmt.Errorf("rejectLateRSVP: %w", err)
+	}
+	err = s.attendanceService.CreateLateRSVP(&dinny.LateRSVP{MealID: meal.ID, MemberID: member.ID, Eating: eating})
+	if err != nil {
+		return fmt.Errorf("rejectLateRSVP CreateLateRSVP: %w", err)
+	}
+	err = s.closeRSVPs(meal)
+	if err != nil {
+		return fmt.Errorf("rejectLateRSVP: %w", err)
+	}
+	if meal.HeadcountSentAt != nil {
+		change := "wants to eat"
+		if !eating {
+			change = "isn't eating anymore"
+		}
+		text := fmt.Sprintf(":warning: *After RSVPs closed for %s:* <@%s> %s (not counted)", meal.Date, slackUID, change)
+		err = s.messageCooks(meal, text)
+		if err != nil {
+			return fmt.Errorf("rejectLateRSVP: %w", err)
+		}
+	}
+	text := fmt.Sprintf("RSVPs for dinner on %s closed at %s, so your RSVP wasn't counted. Please let %s know directly.", meal.Date, s.rsvpDeadline(meal).Format("Mon Jan 2 15:04"), mentionCooks(meal.CookSlackUIDs()))
+	_, err = s.client.PostEphemeral(channel, slackUID, slack.MsgOptionText(text, false))
+	if err != nil {
+		return fmt.Errorf("rejectLateRSVP PostEphemeral: %w", err)
+	}
+	return nil
+}
+
+// sendLateChange lets the cooks know about a change to who's eating the meal after the headcount was sent, e.g. "<@U123> is now eating".
+func (s *service) sendLateChange(meal *dinny.Meal, change string) error {
+	if meal.HeadcountSentAt == nil {
+		return nil
+	}
+	count, err := s.headcount(meal)
+	if err != nil {
+		return fmt.Errorf("sendLateChange: %w", err)
+	}
+	text := fmt.Sprintf(":warning: *Late change for %s:* %s, %d eating now", meal.Date, change, count)
+	err = s.messageCooks(meal, text)
+	if err != nil {
+		return fmt.Errorf("sendLateChange: %w", err)
+	}
+	return nil
+}
+
+// findOrCreateMember retrieves the member with the given Slack UID, creating the member from their Slack profile if they don't exist yet.
+func (s *service) findOrCreateMember(slackUID string) (*dinny.Member, error) {
+	member, err := s.memberService.FindMemberBySlackUID(slackUID)
+	if err == nil {
+		return member, nil
+	} else if !errors.Is(err, dinny.ErrNotFound) {
+		return nil, fmt.Errorf("findOrCreateMember FindMemberBySlackUID: %w", err)
+	}
+	userInfo, err := s.client.GetUserInfo(slackUID)
+	if err != nil {
+		return nil, fmt.Errorf("findOrCreateMember GetUserInfo: %w", err)
+	}
+	member = &dinny.Member{
+		SlackUID: slackUID,
+		FullName: userInfo.RealName,
+	}
+	err = s.memberService.CreateMember(member)
+	if err != nil {
+		return nil, fmt.Errorf("findOrCreateMember CreateMember: %w", err)
+	}
+	return member, nil
+}
+
+// ReactionAddedEvent records the Slack member as attending the meal if a valid 'is eating' message were liked.
 func (s *service) ReactionAddedEvent(e *slackevents.ReactionAddedEvent) error {
-	// Don't bother if the reaction isn't a like
-	if e.Reaction != "+1" {
-		return fmt.Errorf("ReactionAddedEvent +1: got %s in channel %s from user %s", e.Reaction, e.Item.Channel, e.User)
+	// Don't bother if the reaction isn't an RSVP or a number of guests
+	guests, ok := s.parseReaction(e.Reaction)
+	if !ok {
+		return fmt.Errorf("ReactionAddedEvent parseReaction: got %s in channel %s from user %s", e.Reaction, e.Item.Channel, e.User)
 	}
 
 	// check to see whether the reaction was on a 'who's eating tomorrow' post
@@ -168,45 +915,191 @@ func (s *service) ReactionAddedEvent(e *slackevents.ReactionAddedEvent) error {
 	}
 
 	// if the reaction was on an expired 'who's eating tomorrow' post, don't do anything
-	if meal.Expired() {
+	if s.expired(meal) {
 		return fmt.Errorf("ReactionAddedEvent IsEatingMessageExpired: slackMessageID: %s", slackMessageID)
 	}
 
-	// add the member if he doesn't exist yet
-	slackUID := e.User
-	member, err := s.memberService.FindMemberBySlackUID(slackUID)
-	if errors.Is(err, dinny.ErrNotFound) {
-		userInfo, err := s.client.GetUserInfo(slackUID)
+	// reactions after the RSVP deadline don't change who's eating
+	if time.Now().After(s.rsvpDeadline(meal)) {
+		if guests > 0 {
+			err = s.rejectLateGuests(meal, e.User, e.Item.Channel)
+		} else {
+			err = s.rejectLateRSVP(meal, e.User, e.Item.Channel, true)
+		}
 		if err != nil {
-			return fmt.Errorf("ReactionAddedEvent GetUserInfo: %w", err)
+			return fmt.Errorf("ReactionAddedEvent: %w", err)
 		}
-		err = s.memberService.CreateMember(&dinny.Member{
-			SlackUID: slackUID,
-			FullName: userInfo.RealName,
+		return nil
+	}
+
+	if guests > 0 {
+		err := s.reactGuests(meal, e.User, guests, true)
+		if err != nil {
+			return fmt.Errorf("ReactionAddedEvent: %w", err)
+		}
+		return nil
+	}
+
+	err = s.rsvp(meal, e.User, true, dinny.AttendanceSourceReaction)
+	if err != nil {
+		return fmt.Errorf("ReactionAddedEvent: %w", err)
+	}
+	return nil
+}
+
+// rsvp records the Slack member as eating or not eating the meal, lets the cook know if the headcount was already sent
+// and edits the 'who's eating' message to show the new eaters. RSVPing the way the member already did is a no-op.
+func (s *service) rsvp(meal *dinny.Meal, slackUID string, eating bool, source string) error {
+	// add the member if they don't exist yet
+	member, err := s.findOrCreateMember(slackUID)
+	if err != nil {
+		return fmt.Errorf("rsvp: %w", err)
+	}
+
+	attendances, err := s.attendanceService.ListAttendancesByMeal(meal.ID)
+	if err != nil {
+		return fmt.Errorf("rsvp ListAttendancesByMeal: %w", err)
+	}
+	attending := false
+	for _, a := range attendances {
+		if a.MemberID == member.ID {
+			attending = true
+		}
+	}
+	if attending == eating {
+		return nil
+	}
+
+	// record or remove the attendance, which also updates the member's meals eaten
+	if eating {
+		err = s.attendanceService.CreateAttendance(&dinny.Attendance{
+			MealID:   meal.ID,
+			MemberID: member.ID,
+			Source:   source,
 		})
 		if err != nil {
-			return fmt.Errorf("ReactionAddedEvent CreateMember: %w", err)
+			return fmt.Errorf("rsvp CreateAttendance: %w", err)
+		}
+		err = s.sendLateChange(meal, fmt.Sprintf("<@%s> is now eating", member.SlackUID))
+		if err != nil {
+			return fmt.Errorf("rsvp: %w", err)
+		}
+		err = s.warnCookOfDiets(meal)
+		if err != nil {
+			return fmt.Errorf("rsvp: %w", err)
+		}
+	} else {
+		err = s.attendanceService.DeleteAttendance(meal.ID, member.ID)
+		if errors.Is(err, dinny.ErrMealClosed) {
+			// The RSVP was counted towards a season which has been closed since, so it stands.
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("rsvp DeleteAttendance: %w", err)
+		}
+		err = s.sendLateChange(meal, fmt.Sprintf("<@%s> is no longer eating", member.SlackUID))
+		if err != nil {
+			return fmt.Errorf("rsvp: %w", err)
 		}
-	} else if err != nil {
-		return fmt.Errorf("ReactionAddedEvent FindMemberBySlackUID: %w", err)
 	}
 
-	// update the member's meals eaten
-	newMealsEaten := member.MealsEaten + 1
-	err = s.memberService.UpdateMember(member.ID, dinny.MemberUpdate{
-		MealsEaten: &newMealsEaten,
-	})
+	err = s.refreshEatingTomorrow(meal.ID)
 	if err != nil {
-		return fmt.Errorf("ReactionAddedEvent UpdateMember: %w", err)
+		return fmt.Errorf("rsvp: %w", err)
 	}
 	return nil
 }
 
-// ReactionAddedEvent adds 1 to the Slack member's meals_eaten if a valid 'is eating' message were un-liked.
+// warnCookOfDiets sends the cooks of the meal a summary of the dietary restrictions of its eaters if the menu conflicts with
+// those of an eater they haven't been warned about yet. The cooks are warned about each eater once per meal, so rejoining
+// the meal or changing the menu doesn't warn them again about the same eater.
+func (s *service) warnCookOfDiets(meal *dinny.Meal) error {
+	attendances, err := s.attendanceService.ListAttendancesByMeal(meal.ID)
+	if err != nil {
+		return fmt.Errorf("warnCookOfDiets ListAttendancesByMeal: %w", err)
+	}
+	warnedIDs, err := s.dietService.ListDietWarnings(meal.ID)
+	if err != nil {
+		return fmt.Errorf("warnCookOfDiets ListDietWarnings: %w", err)
+	}
+	warned := make(map[int64]bool, len(warnedIDs))
+	for _, id := range warnedIDs {
+		warned[id] = true
+	}
+
+	var lines []string
+	var newMemberIDs []int64
+	var newSlackUIDs []string
+	for _, a := range attendances {
+		p, err := s.dietService.FindDietaryProfile(a.MemberID)
+		if errors.Is(err, dinny.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("warnCookOfDiets FindDietaryProfile: %w", err)
+		}
+		m, err := s.memberService.FindMemberByID(a.MemberID)
+		if err != nil {
+			return fmt.Errorf("warnCookOfDiets FindMemberByID: %w", err)
+		}
+		conflicts := p.Conflicts(meal.Tags)
+		if line := dietLine(m, p, conflicts); line != "" {
+			lines = append(lines, line)
+		}
+		if len(conflicts) > 0 && !warned[m.ID] {
+			newMemberIDs = append(newMemberIDs, m.ID)
+			newSlackUIDs = append(newSlackUIDs, m.SlackUID)
+		}
+	}
+	if len(newMemberIDs) == 0 {
+		return nil
+	}
+
+	verb := "has"
+	if len(newSlackUIDs) > 1 {
+		verb = "have"
+	}
+	text := fmt.Sprintf("heads up, %s %s dietary restrictions your menu on %s may not cover. Restrictions of your eaters so far:\n%s", mentionCooks(newSlackUIDs), verb, meal.Date, strings.Join(lines, "\n"))
+	err = s.messageCooks(meal, text)
+	if err != nil {
+		return fmt.Errorf("warnCookOfDiets: %w", err)
+	}
+	// Only record the warnings once they were sent, so a failed DM is retried on the next change.
+	for _, id := range newMemberIDs {
+		err = s.dietService.CreateDietWarning(meal.ID, id)
+		if err != nil {
+			return fmt.Errorf("warnCookOfDiets CreateDietWarning: %w", err)
+		}
+	}
+	return nil
+}
+
+// dietLine summarizes the dietary profile of a member, marking the conflicting restrictions.
+// Returns an empty string if there is nothing to mention.
+func dietLine(m *dinny.Member, p *dinny.DietaryProfile, conflicts []string) string {
+	var parts []string
+	for _, r := range p.Restrictions {
+		part := r
+		for _, c := range conflicts {
+			if c == r {
+				part = fmt.Sprintf("*%s* :warning:", r)
+			}
+		}
+		parts = append(parts, part)
+	}
+	if p.Allergies != "" {
+		parts = append(parts, fmt.Sprintf("allergies: %s", p.Allergies))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("• <@%s> %s", m.SlackUID, strings.Join(parts, ", "))
+}
+
+// ReactionRemovedEvent removes the Slack member's attendance at the meal if a valid 'is eating' message were un-liked.
 func (s *service) ReactionRemovedEvent(e *slackevents.ReactionRemovedEvent) error {
-	// Don't bother if the reaction isn't a like
-	if e.Reaction != "+1" {
-		return fmt.Errorf("ReactionRemovedEvent +1: got %s in channel %s from user %s", e.Reaction, e.Item.Channel, e.User)
+	// Don't bother if the reaction isn't an RSVP or a number of guests
+	guests, ok := s.parseReaction(e.Reaction)
+	if !ok {
+		return fmt.Errorf("ReactionRemovedEvent parseReaction: got %s in channel %s from user %s", e.Reaction, e.Item.Channel, e.User)
 	}
 
 	// check to see whether the reaction was on a 'who's eating tomorrow' post
@@ -217,40 +1110,70 @@ func (s *service) ReactionRemovedEvent(e *slackevents.ReactionRemovedEvent) erro
 	}
 
 	// if the reaction was on an expired 'who's eating tomorrow' post, don't do anything
-	if meal.Expired() {
+	if s.expired(meal) {
 		return fmt.Errorf("ReactionRemovedEvent IsEatingMessageExpired: slackMessageID: %s", slackMessageID)
 	}
 
-	// add the member if he doesn't exist yet
-	slackUID := e.User
-	member, err := s.memberService.FindMemberBySlackUID(slackUID)
-	if errors.Is(err, dinny.ErrNotFound) {
-		userInfo, err := s.client.GetUserInfo(slackUID)
-		if err != nil {
-			return fmt.Errorf("ReactionRemovedEvent GetUserInfo: %w", err)
+	// reactions after the RSVP deadline don't change who's eating
+	if time.Now().After(s.rsvpDeadline(meal)) {
+		if guests > 0 {
+			err = s.rejectLateGuests(meal, e.User, e.Item.Channel)
+		} else {
+			err = s.rejectLateRSVP(meal, e.User, e.Item.Channel, false)
 		}
-		err = s.memberService.CreateMember(&dinny.Member{
-			SlackUID: slackUID,
-			FullName: userInfo.RealName,
-		})
 		if err != nil {
-			return fmt.Errorf("ReactionRemovedEvent CreateMember: %w", err)
+			return fmt.Errorf("ReactionRemovedEvent: %w", err)
 		}
-	} else if err != nil {
-		return fmt.Errorf("ReactionRemovedEvent FindMemberBySlackUID: %w", err)
+		return nil
 	}
 
-	// update the member's meals eaten
-
-	newMealsEaten := member.MealsEaten + 1
-	if newMealsEaten < 0 {
-		newMealsEaten = 0
+	if guests > 0 {
+		err := s.reactGuests(meal, e.User, guests, false)
+		if err != nil {
+			return fmt.Errorf("ReactionRemovedEvent: %w", err)
+		}
+		return nil
 	}
-	err = s.memberService.UpdateMember(member.ID, dinny.MemberUpdate{
-		MealsEaten: &newMealsEaten,
-	})
+
+	err = s.rsvp(meal, e.User, false, dinny.AttendanceSourceReaction)
 	if err != nil {
-		return fmt.Errorf("ReactionRemovedEvent UpdateMember: %w", err)
+		return fmt.Errorf("ReactionRemovedEvent: %w", err)
+	}
+	return nil
+}
+
+// BlockActions records the Slack member as eating or not eating the meal when they click a button on its 'who's eating' message.
+// Clicks after the RSVP deadline are recorded as late RSVPs, just like reactions.
+func (s *service) BlockActions(callback slack.InteractionCallback) error {
+	for _, action := range callback.ActionCallback.BlockActions {
+		if action.ActionID != actionEating && action.ActionID != actionNotEating {
+			continue
+		}
+		eating := action.ActionID == actionEating
+
+		mealID, err := strconv.ParseInt(action.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("BlockActions ParseInt: %w", err)
+		}
+		meal, err := s.mealService.FindMealByID(mealID)
+		if err != nil {
+			return fmt.Errorf("BlockActions FindMealByID: %w", err)
+		}
+
+		// if the button was on an expired 'who's eating tomorrow' post, don't do anything
+		if s.expired(meal) {
+			return fmt.Errorf("BlockActions IsEatingMessageExpired: mealID: %d", meal.ID)
+		}
+
+		// clicks after the RSVP deadline don't change who's eating
+		if time.Now().After(s.rsvpDeadline(meal)) {
+			err = s.rejectLateRSVP(meal, callback.User.ID, callback.Channel.ID, eating)
+		} else {
+			err = s.rsvp(meal, callback.User.ID, eating, dinny.AttendanceSourceButton)
+		}
+		if err != nil {
+			return fmt.Errorf("BlockActions: %w", err)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,517 @@
+package slack
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/slack-go/slack"
+)
+
+// slackAPI is a fake Slack Web API which records the text and channel of every posted message.
+// Requests fail while down is set and messages to the unreachable channel fail.
+type slackAPI struct {
+	posted      []string
+	channels    []string
+	down        bool
+	unreachable string
+}
+
+func (api *slackAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	r.ParseForm()
+	if api.down || (api.unreachable != "" && r.FormValue("channel") == api.unreachable) {
+		w.Write([]byte(`{"ok":false,"error":"service_unavailable"}`))
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, "/chat.postMessage") {
+		api.posted = append(api.posted, r.FormValue("text"))
+		api.channels = append(api.channels, r.FormValue("channel"))
+	}
+	w.Write([]byte(`{"ok":true,"channel":"C1","ts":"1672531200.000100"}`))
+}
+
+// newTestService returns a service backed by a fresh sqlite database and a fake Slack Web API.
+func newTestService(t *testing.T) (*service, *slackAPI) {
+	t.Helper()
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := gen.New(db)
+	api := &slackAPI{}
+	ts := httptest.NewServer(api)
+	t.Cleanup(ts.Close)
+	return &service{
+		client:              slack.New("xoxb-test", slack.OptionAPIURL(ts.URL+"/")),
+		config:              &Config{Channel: "C1", Location: time.UTC},
+		mealService:         sqlite.NewMealService(queries, db),
+		memberService:       sqlite.NewMemberService(queries, db),
+		attendanceService:   sqlite.NewAttendanceService(queries, db),
+		availabilityService: sqlite.NewAvailabilityService(queries, db),
+		dietService:         sqlite.NewDietaryProfileService(queries, db),
+		seasonService:       sqlite.NewSeasonService(queries, db),
+	}, api
+}
+
+// TestIsEatingTomorrowBlock ensures the 'who's eating' message lists the eaters and only shows the RSVP buttons while RSVPs are open.
+func TestIsEatingTomorrowBlock(t *testing.T) {
+	closedAt := time.Date(2023, time.January, 5, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		meal        *dinny.Meal
+		eaters      []string
+		count       int64
+		want        []string
+		wantButtons bool
+	}{
+		{
+			name:        "open without eaters",
+			meal:        &dinny.Meal{ID: 7, CookSlackUID: "U1", Date: dinny.Date{Year: 2023, Month: time.January, Day: 5}},
+			want:        []string{"nobody yet", "<@U1>"},
+			wantButtons: true,
+		},
+		{
+			name:        "open with eaters",
+			meal:        &dinny.Meal{ID: 7, CookSlackUID: "U1", Date: dinny.Date{Year: 2023, Month: time.January, Day: 5}},
+			eaters:      []string{"<@U2> +2", "<@U3>"},
+			count:       4,
+			want:        []string{"Eating (4):* <@U2> +2, <@U3>"},
+			wantButtons: true,
+		},
+		{
+			name:   "closed",
+			meal:   &dinny.Meal{ID: 7, CookSlackUID: "U1", Date: dinny.Date{Year: 2023, Month: time.January, Day: 5}, RSVPsClosedAt: &closedAt},
+			eaters: []string{"<@U2>"},
+			count:  1,
+			want:   []string{"RSVPs closed", "Eating (1):* <@U2>"},
+		},
+	}
+	s := &service{config: &Config{}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, values, err := slack.UnsafeApplyMsgOptions("", "C1", "", s.isEatingTomorrowBlock(tt.meal, tt.eaters, tt.count))
+			if err != nil {
+				t.Fatalf("UnsafeApplyMsgOptions() error = %v", err)
+			}
+			// The blocks are JSON encoded, which escapes the angle brackets of mentions.
+			blocks := strings.NewReplacer(`\u003c`, "<", `\u003e`, ">").Replace(values.Get("blocks"))
+			for _, want := range tt.want {
+				if !strings.Contains(blocks, want) {
+					t.Errorf("blocks = %s, want to contain %q", blocks, want)
+				}
+			}
+			for _, action := range []string{actionEating, actionNotEating} {
+				if got := strings.Contains(blocks, `"value":"7"`) && strings.Contains(blocks, action); got != tt.wantButtons {
+					t.Errorf("button %s shown = %v, want %v", action, got, tt.wantButtons)
+				}
+			}
+		})
+	}
+}
+
+// TestMentionCooks ensures every cook of a meal is mentioned.
+func TestMentionCooks(t *testing.T) {
+	tests := []struct {
+		cooks []string
+		want  string
+	}{
+		{[]string{"U1"}, "<@U1>"},
+		{[]string{"U1", "U2"}, "<@U1> and <@U2>"},
+		{[]string{"U1", "U2", "U3"}, "<@U1>, <@U2> and <@U3>"},
+	}
+	for _, tt := range tests {
+		if got := mentionCooks(tt.cooks); got != tt.want {
+			t.Errorf("mentionCooks(%v) = %s, want %s", tt.cooks, got, tt.want)
+		}
+	}
+}
+
+// TestParseReaction ensures RSVP and guest reactions are recognized along with their skin-tone variants, using the configured reactions if any.
+func TestParseReaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     Config
+		reaction   string
+		wantGuests int64
+		wantOk     bool
+	}{
+		{"default rsvp", Config{}, "+1", 0, true},
+		{"default rsvp skin tone", Config{}, "+1::skin-tone-2", 0, true},
+		{"default guests", Config{}, "three", 3, true},
+		{"default unknown", Config{}, "heart", 0, false},
+		{"configured rsvp", Config{RSVPReactions: []string{"fork_and_knife", "yum"}}, "yum", 0, true},
+		{"configured rsvp replaces default", Config{RSVPReactions: []string{"yum"}}, "+1", 0, false},
+		{"configured guests", Config{GuestReactions: []string{"bust_in_silhouette", "busts_in_silhouette"}}, "busts_in_silhouette", 2, true},
+		{"configured guests skin tone", Config{GuestReactions: []string{"wave"}}, "wave::skin-tone-5", 1, true},
+		{"configured guests replaces default", Config{GuestReactions: []string{"wave"}}, "two", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &service{config: &tt.config}
+			guests, ok := s.parseReaction(tt.reaction)
+			if guests != tt.wantGuests || ok != tt.wantOk {
+				t.Errorf("parseReaction() = %v, %v, want %v, %v", guests, ok, tt.wantGuests, tt.wantOk)
+			}
+		})
+	}
+}
+
+// TestWarnCookOfDiets ensures the cook is warned about each eater with conflicting restrictions once, including when the
+// menu changes, and that a warning which couldn't be sent is sent on the next change.
+func TestWarnCookOfDiets(t *testing.T) {
+	s, api := newTestService(t)
+	date := dinny.Date{Year: 2023, Month: time.January, Day: 2}
+	if err := s.mealService.AssignCook(date, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := s.mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eaters := make([]*dinny.Member, 3)
+	for ii, uid := range []string{"U2", "U3", "U4"} {
+		eaters[ii] = &dinny.Member{SlackUID: uid, FullName: uid}
+		if err := s.memberService.CreateMember(eaters[ii]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, m := range eaters[:2] {
+		if err := s.dietService.SetDietaryProfile(&dinny.DietaryProfile{MemberID: m.ID, Restrictions: []string{dinny.DietVegan}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.dietService.SetDietaryProfile(&dinny.DietaryProfile{MemberID: eaters[2].ID, Restrictions: []string{dinny.DietNutAllergy}}); err != nil {
+		t.Fatal(err)
+	}
+	join := func(m *dinny.Member) {
+		t.Helper()
+		if err := s.attendanceService.CreateAttendance(&dinny.Attendance{MealID: meal.ID, MemberID: m.ID, Source: dinny.AttendanceSourceManual}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.warnCookOfDiets(meal); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	join(eaters[0])
+	if err := s.warnCookOfDiets(meal); err != nil {
+		t.Fatal(err)
+	}
+	if len(api.posted) != 1 || !strings.HasPrefix(api.posted[0], "heads up, <@U2> has") {
+		t.Fatalf("posted = %q, want one warning about U2", api.posted)
+	}
+
+	api.down = true
+	if err := s.attendanceService.CreateAttendance(&dinny.Attendance{MealID: meal.ID, MemberID: eaters[1].ID, Source: dinny.AttendanceSourceManual}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.warnCookOfDiets(meal); err == nil {
+		t.Fatal("warnCookOfDiets succeeded while slack was down, want error")
+	}
+	api.down = false
+	join(eaters[2])
+	if len(api.posted) != 2 || !strings.HasPrefix(api.posted[1], "heads up, <@U3> has") {
+		t.Fatalf("posted = %q, want a second warning about U3 only", api.posted)
+	}
+
+	tags := []string{dinny.MealTagVegan, dinny.MealTagContainsNuts}
+	if err := s.SetMenu(date, dinny.MealUpdate{Tags: &tags}); err != nil {
+		t.Fatal(err)
+	}
+	if len(api.posted) != 3 || !strings.HasPrefix(api.posted[2], "heads up, <@U4> has") {
+		t.Fatalf("posted = %q, want a third warning about U4 once the menu contains nuts", api.posted)
+	}
+	if err := s.SetMenu(date, dinny.MealUpdate{Tags: &tags}); err != nil {
+		t.Fatal(err)
+	}
+	if len(api.posted) != 3 {
+		t.Errorf("posted = %q, want no further warnings", api.posted)
+	}
+}
+
+// TestSendHeadcount ensures the headcount is only marked as sent once a cook was sent it, so a failed DM is retried.
+func TestSendHeadcount(t *testing.T) {
+	s, api := newTestService(t)
+	date := dinny.Date{Year: 2023, Month: time.January, Day: 2}
+	if err := s.mealService.AssignCook(date, "U1"); err != nil {
+		t.Fatal(err)
+	}
+
+	api.down = true
+	if err := s.SendHeadcount(date); err == nil {
+		t.Fatal("SendHeadcount succeeded while slack was down, want error")
+	}
+	meal, err := s.mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.HeadcountSentAt != nil {
+		t.Errorf("HeadcountSentAt = %v after a failed DM, want nil", meal.HeadcountSentAt)
+	}
+
+	api.down = false
+	for ii := 0; ii < 2; ii++ {
+		if err := s.SendHeadcount(date); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(api.posted) != 1 || !strings.HasPrefix(api.posted[0], "*Headcount for 2023-01-02:* 0 eating") {
+		t.Errorf("posted = %q, want the headcount once", api.posted)
+	}
+	meal, err = s.mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.HeadcountSentAt == nil {
+		t.Error("HeadcountSentAt = nil after the headcount was sent")
+	}
+}
+
+// TestSendHeadcountPartialFailure ensures a retried headcount is only sent to the cooks who didn't get it.
+func TestSendHeadcountPartialFailure(t *testing.T) {
+	s, api := newTestService(t)
+	for _, uid := range []string{"U1", "U2"} {
+		if err := s.memberService.CreateMember(&dinny.Member{SlackUID: uid, FullName: uid}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	date := dinny.Date{Year: 2023, Month: time.January, Day: 2}
+	if err := s.mealService.AssignCooks(date, []string{"U1", "U2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	api.unreachable = "U2"
+	if err := s.SendHeadcount(date); err == nil {
+		t.Fatal("SendHeadcount succeeded while U2 was unreachable, want error")
+	}
+	meal, err := s.mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.HeadcountSentAt == nil {
+		t.Error("HeadcountSentAt = nil after U1 was sent the headcount")
+	}
+
+	api.unreachable = ""
+	for ii := 0; ii < 2; ii++ {
+		if err := s.SendHeadcount(date); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []string{"U1", "U2"}; !reflect.DeepEqual(api.channels, want) {
+		t.Errorf("headcount sent to %q, want %q", api.channels, want)
+	}
+}
+
+// TestCoCookRanking ensures co-cooks who shared a meal rank below a member who cooked one alone, whether or not the
+// ranking is weighted.
+func TestCoCookRanking(t *testing.T) {
+	s, _ := newTestService(t)
+	for _, uid := range []string{"U1", "U2", "U3"} {
+		if err := s.memberService.CreateMember(&dinny.Member{SlackUID: uid, FullName: uid}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	first := dinny.Date{Year: 2023, Month: time.January, Day: 2}
+	if err := s.mealService.AssignCook(first, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.mealService.AssignCooks(first.AddDays(1), []string{"U2", "U3"}); err != nil {
+		t.Fatal(err)
+	}
+	eaten := int64(2)
+	for _, uid := range []string{"U1", "U2", "U3"} {
+		member, err := s.memberService.FindMemberBySlackUID(uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.memberService.UpdateMember(member.ID, dinny.MemberUpdate{MealsEaten: &eaten}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, date := range []dinny.Date{first, first.AddDays(1)} {
+		meal, err := s.mealService.FindMealByDate(date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.mealService.CompleteMeal(meal.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, weighted := range []bool{false, true} {
+		members, err := s.rankedMembers()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sortByWorstRatio(members, weighted)
+		if len(members) != 3 || members[2].SlackUID != "U1" {
+			t.Errorf("weighted %v: ranked %v, want U1 last", weighted, members)
+			continue
+		}
+		for _, m := range members[:2] {
+			if m.Ratio() != 4 || m.WeightedRatio() != 4 {
+				t.Errorf("weighted %v: %s Ratio, WeightedRatio = %v, %v, want 4, 4", weighted, m.SlackUID, m.Ratio(), m.WeightedRatio())
+			}
+		}
+	}
+}
+
+// TestPostEatingTomorrowCancelled ensures no 'who's eating' message is posted for a meal which has been cancelled.
+func TestPostEatingTomorrowCancelled(t *testing.T) {
+	s, api := newTestService(t)
+	tomorrow := dinny.DateOf(time.Now().In(s.location())).AddDays(1)
+	if err := s.mealService.AssignCook(tomorrow, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := s.mealService.FindMealByDate(tomorrow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.mealService.CancelMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PostEatingTomorrow(); err != nil {
+		t.Fatal(err)
+	}
+	if len(api.posted) != 0 {
+		t.Errorf("posted = %q, want nothing", api.posted)
+	}
+}
+
+// TestPostEatingTomorrowNoop ensures there being no meal tomorrow or its message having been posted already isn't an error,
+// so that retries of the job don't fail.
+func TestPostEatingTomorrowNoop(t *testing.T) {
+	s, api := newTestService(t)
+	if err := s.PostEatingTomorrow(); err != nil {
+		t.Errorf("PostEatingTomorrow() without a meal error = %v, want nil", err)
+	}
+	tomorrow := dinny.DateOf(time.Now().In(s.location())).AddDays(1)
+	if err := s.mealService.AssignCook(tomorrow, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	for ii := 0; ii < 2; ii++ {
+		if err := s.PostEatingTomorrow(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(api.posted) != 1 {
+		t.Errorf("posted %d messages, want 1", len(api.posted))
+	}
+}
+
+// TestBlockActionsExpired ensures clicks on the message of a meal whose day has passed are ignored while clicks on a
+// meal next month are recorded, whatever the day of the month is today.
+func TestBlockActionsExpired(t *testing.T) {
+	s, _ := newTestService(t)
+	eater := &dinny.Member{SlackUID: "U2", FullName: "Jane Doe"}
+	if err := s.memberService.CreateMember(eater); err != nil {
+		t.Fatal(err)
+	}
+	year, month, _ := time.Now().Date()
+	lastMonth := dinny.DateOf(time.Date(year, month, 0, 0, 0, 0, 0, time.UTC))
+	nextMonth := dinny.DateOf(time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC))
+	click := func(date dinny.Date) (*dinny.Meal, error) {
+		t.Helper()
+		if err := s.mealService.AssignCook(date, "U1"); err != nil {
+			t.Fatal(err)
+		}
+		meal, err := s.mealService.FindMealByDate(date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var callback slack.InteractionCallback
+		callback.User.ID = eater.SlackUID
+		callback.ActionCallback.BlockActions = []*slack.BlockAction{{ActionID: actionEating, Value: strconv.FormatInt(meal.ID, 10)}}
+		return meal, s.BlockActions(callback)
+	}
+
+	meal, err := click(lastMonth)
+	if err == nil {
+		t.Error("BlockActions on last month's meal succeeded, want error")
+	}
+	if atts, err := s.attendanceService.ListAttendancesByMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	} else if len(atts) != 0 {
+		t.Errorf("len(attendances) = %d on last month's meal, want 0", len(atts))
+	}
+
+	meal, err = click(nextMonth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if atts, err := s.attendanceService.ListAttendancesByMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	} else if len(atts) != 1 {
+		t.Errorf("len(attendances) = %d on next month's meal, want 1", len(atts))
+	}
+}
+
+// TestSetGuests ensures guests set outside of Slack tell the cooks about late changes and are rejected after the RSVP
+// deadline, just like guest reactions.
+func TestSetGuests(t *testing.T) {
+	s, api := newTestService(t)
+	eater := &dinny.Member{SlackUID: "U2", FullName: "Jane Doe"}
+	if err := s.memberService.CreateMember(eater); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.DateOf(time.Now().AddDate(0, 0, 2))
+	if err := s.mealService.AssignCook(date, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SendHeadcount(date); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.SetGuests(date, eater.SlackUID, 2); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := s.mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	guests, err := s.attendanceService.ListGuestsByMeal(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(guests) != 1 || guests[0].Count != 2 {
+		t.Errorf("guests = %+v, want 2 guests of %s", guests, eater.SlackUID)
+	}
+	if len(api.posted) != 2 || !strings.Contains(api.posted[1], "<@U2> now brings 2 guests") {
+		t.Errorf("posted = %q, want the headcount and a late change", api.posted)
+	}
+
+	deadline := time.Now().Add(-time.Minute)
+	if err := s.mealService.UpdateMeal(meal.ID, dinny.MealUpdate{RSVPDeadline: &deadline}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetGuests(date, eater.SlackUID, 3); !errors.Is(err, dinny.ErrMealClosed) {
+		t.Errorf("SetGuests err = %v after the RSVP deadline, want ErrMealClosed", err)
+	}
+	meal, err = s.mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.RSVPsClosedAt == nil {
+		t.Error("RSVPsClosedAt = nil after a late change, want the RSVPs closed")
+	}
+	guests, err = s.attendanceService.ListGuestsByMeal(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(guests) != 1 || guests[0].Count != 2 {
+		t.Errorf("guests = %+v, want the 2 guests from before the deadline", guests)
+	}
+}
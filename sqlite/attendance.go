@@ -0,0 +1,380 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.AttendanceService = (*AttendanceService)(nil)
+
+// AttendanceService represents a service for managing the attendance ledger.
+type AttendanceService struct {
+	query *gen.Queries
+	db    *sql.DB
+
+	// rotationID is the rotation the service is scoped to.
+	rotationID int64
+}
+
+// NewAttendanceService returns a new instance of AttendanceService scoped to the default rotation.
+func NewAttendanceService(query *gen.Queries, db *sql.DB) *AttendanceService {
+	return &AttendanceService{query, db, dinny.DefaultRotationID}
+}
+
+// ForRotation returns a copy of the service scoped to the rotation with the given ID.
+func (as *AttendanceService) ForRotation(rotationID int64) *AttendanceService {
+	scoped := *as
+	scoped.rotationID = rotationID
+	return &scoped
+}
+
+// toDinnyAttendance converts a gen.Attendance to a dinny.Attendance.
+func toDinnyAttendance(a gen.Attendance) *dinny.Attendance {
+	return &dinny.Attendance{
+		ID:        a.ID,
+		MealID:    a.MealID,
+		MemberID:  a.MemberID,
+		Source:    a.Source,
+		CreatedAt: parseTime(a.CreatedAt),
+	}
+}
+
+// checkMealOpen returns ErrMealClosed if the meal has been closed out, which freezes its attendances.
+// Returns ErrNotFound if the meal doesn't belong to the rotation.
+func checkMealOpen(qtx *gen.Queries, rotationID int64, mealID int64) error {
+	m, err := qtx.FindMealByID(context.Background(), gen.FindMealByIDParams{ID: mealID, RotationID: rotationID})
+	if err == sql.ErrNoRows {
+		return dinny.ErrNotFound
+	} else if err != nil {
+		return fmt.Errorf("checkMealOpen FindMealByID: %w", err)
+	}
+	if m.Status != dinny.MealStatusScheduled {
+		return dinny.ErrMealClosed
+	}
+	return nil
+}
+
+// decrementMealsEaten decrements the meals eaten of a member whose attendance was removed.
+// Returns an error instead of going below 0, as the counter must then have drifted from the attendances, see ReconcileMealsEaten.
+func decrementMealsEaten(qtx *gen.Queries, memberID int64) error {
+	n, err := qtx.DecrementMemberMealsEaten(context.Background(), memberID)
+	if err != nil {
+		return fmt.Errorf("decrementMealsEaten DecrementMemberMealsEaten: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("decrementMealsEaten: meals eaten of member %d is already 0", memberID)
+	}
+	return nil
+}
+
+// countedInClosedSeason reports whether an attendance made at createdAt was counted towards a season which has since been closed.
+// Such an attendance is archived with that season, so it mustn't be taken off the meals eaten of the current one.
+func countedInClosedSeason(qtx *gen.Queries, rotationID int64, createdAt string) (bool, error) {
+	season, err := qtx.FindLatestSeason(context.Background(), rotationID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("countedInClosedSeason FindLatestSeason: %w", err)
+	}
+	return createdAt <= season.ClosedAt, nil
+}
+
+// uncountAttendance decrements the meals eaten of the member of an attendance which is about to be removed,
+// unless the attendance was counted towards a closed season.
+func uncountAttendance(qtx *gen.Queries, rotationID int64, a gen.Attendance) error {
+	closed, err := countedInClosedSeason(qtx, rotationID, a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("uncountAttendance: %w", err)
+	}
+	if closed {
+		return nil
+	}
+	err = decrementMealsEaten(qtx, a.MemberID)
+	if err != nil {
+		return fmt.Errorf("uncountAttendance: %w", err)
+	}
+	return nil
+}
+
+// MealsEatenCorrection represents a member whose meals eaten didn't match their attendances.
+type MealsEatenCorrection struct {
+	MemberID   int64
+	RotationID int64
+	SlackUID   string
+	FullName   string
+
+	// From is the member's meals eaten before reconciling and To the number of attendances counted in the current season.
+	From int64
+	To   int64
+}
+
+// ReconcileMealsEaten recomputes the meals eaten of the members of every rotation from their attendances made since the last
+// season was closed and returns the members whose counter was corrected. Counters which were set by hand are overwritten.
+// Nothing is corrected if dryRun is set.
+func ReconcileMealsEaten(db *sql.DB, dryRun bool) ([]*MealsEatenCorrection, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("ReconcileMealsEaten db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := gen.New(tx)
+	counts, err := qtx.ListMemberAttendanceCounts(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("ReconcileMealsEaten ListMemberAttendanceCounts: %w", err)
+	}
+	corrections := []*MealsEatenCorrection{}
+	for _, c := range counts {
+		if c.MealsEaten == c.Attendances {
+			continue
+		}
+		corrections = append(corrections, &MealsEatenCorrection{
+			MemberID:   c.ID,
+			RotationID: c.RotationID,
+			SlackUID:   c.SlackUid,
+			FullName:   c.FullName,
+			From:       c.MealsEaten,
+			To:         c.Attendances,
+		})
+		if dryRun {
+			continue
+		}
+		params := gen.UpdateMemberMealsEatenParams{ID: c.ID, MealsEaten: c.Attendances}
+		err := qtx.UpdateMemberMealsEaten(context.Background(), params)
+		if err != nil {
+			return nil, fmt.Errorf("ReconcileMealsEaten UpdateMemberMealsEaten: %w", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("ReconcileMealsEaten tx.Commit: %w", err)
+	}
+	return corrections, nil
+}
+
+// FindAttendance retrieves the attendance of a member at a meal.
+// Returns ErrNotFound if attendance does not exist.
+func (as *AttendanceService) FindAttendance(mealID int64, memberID int64) (*dinny.Attendance, error) {
+	params := gen.FindAttendanceParams{MealID: mealID, MemberID: memberID}
+	a, err := as.query.FindAttendance(context.Background(), params)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, dinny.ErrNotFound
+		} else {
+			return nil, fmt.Errorf("FindAttendance: %w", err)
+		}
+	}
+	return toDinnyAttendance(a), nil
+}
+
+// ListAttendancesByMeal retrieves every attendance recorded for a meal.
+func (as *AttendanceService) ListAttendancesByMeal(mealID int64) ([]*dinny.Attendance, error) {
+	atts, err := as.query.ListAttendancesByMeal(context.Background(), mealID)
+	if err != nil {
+		return nil, fmt.Errorf("ListAttendancesByMeal: %w", err)
+	}
+	var attendances []*dinny.Attendance
+	for _, a := range atts {
+		attendances = append(attendances, toDinnyAttendance(a))
+	}
+	return attendances, nil
+}
+
+// ListAttendancesByMember retrieves every attendance recorded for a member.
+func (as *AttendanceService) ListAttendancesByMember(memberID int64) ([]*dinny.Attendance, error) {
+	atts, err := as.query.ListAttendancesByMember(context.Background(), memberID)
+	if err != nil {
+		return nil, fmt.Errorf("ListAttendancesByMember: %w", err)
+	}
+	var attendances []*dinny.Attendance
+	for _, a := range atts {
+		attendances = append(attendances, toDinnyAttendance(a))
+	}
+	return attendances, nil
+}
+
+// CreateAttendance records a member as eating a meal and increments the member's meals eaten.
+// Recording an attendance that already exists is a no-op.
+func (as *AttendanceService) CreateAttendance(a *dinny.Attendance) error {
+	tx, err := as.db.Begin()
+	if err != nil {
+		return fmt.Errorf("CreateAttendance db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := as.query.WithTx(tx)
+	if err := checkMealOpen(qtx, as.rotationID, a.MealID); err != nil {
+		return fmt.Errorf("CreateAttendance: %w", err)
+	}
+	params := gen.CreateAttendanceParams{
+		MealID:   a.MealID,
+		MemberID: a.MemberID,
+		Source:   a.Source,
+	}
+	n, err := qtx.CreateAttendance(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CreateAttendance: %w", err)
+	}
+	if n == 0 {
+		return nil
+	}
+	err = qtx.IncrementMemberMealsEaten(context.Background(), a.MemberID)
+	if err != nil {
+		return fmt.Errorf("CreateAttendance IncrementMemberMealsEaten: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("CreateAttendance tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// DeleteAttendance removes a member's attendance at a meal and decrements the member's meals eaten.
+// Deleting an attendance that doesn't exist is a no-op.
+// Returns ErrMealClosed if the meal has been closed out or the attendance was counted towards a season which has been closed.
+func (as *AttendanceService) DeleteAttendance(mealID int64, memberID int64) error {
+	tx, err := as.db.Begin()
+	if err != nil {
+		return fmt.Errorf("DeleteAttendance db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := as.query.WithTx(tx)
+	if err := checkMealOpen(qtx, as.rotationID, mealID); err != nil {
+		return fmt.Errorf("DeleteAttendance: %w", err)
+	}
+	a, err := qtx.FindAttendance(context.Background(), gen.FindAttendanceParams{MealID: mealID, MemberID: memberID})
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("DeleteAttendance FindAttendance: %w", err)
+	}
+	// The attendance is archived with the season it was counted towards, so it can't be removed anymore.
+	closed, err := countedInClosedSeason(qtx, as.rotationID, a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("DeleteAttendance: %w", err)
+	}
+	if closed {
+		return fmt.Errorf("DeleteAttendance counted in a closed season: %w", dinny.ErrMealClosed)
+	}
+	params := gen.DeleteAttendanceParams{MealID: mealID, MemberID: memberID}
+	_, err = qtx.DeleteAttendance(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("DeleteAttendance: %w", err)
+	}
+	err = decrementMealsEaten(qtx, memberID)
+	if err != nil {
+		return fmt.Errorf("DeleteAttendance: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("DeleteAttendance tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// toDinnyLateRSVP converts a gen.LateRsvp to a dinny.LateRSVP.
+func toDinnyLateRSVP(l gen.LateRsvp) *dinny.LateRSVP {
+	return &dinny.LateRSVP{
+		ID:        l.ID,
+		MealID:    l.MealID,
+		MemberID:  l.MemberID,
+		Eating:    l.Eating == 1,
+		CreatedAt: parseTime(l.CreatedAt),
+	}
+}
+
+// ListLateRSVPsByMeal retrieves every late RSVP recorded for a meal.
+func (as *AttendanceService) ListLateRSVPsByMeal(mealID int64) ([]*dinny.LateRSVP, error) {
+	ls, err := as.query.ListLateRSVPsByMeal(context.Background(), mealID)
+	if err != nil {
+		return nil, fmt.Errorf("ListLateRSVPsByMeal: %w", err)
+	}
+	lateRSVPs := make([]*dinny.LateRSVP, len(ls))
+	for ii, l := range ls {
+		lateRSVPs[ii] = toDinnyLateRSVP(l)
+	}
+	return lateRSVPs, nil
+}
+
+// CreateLateRSVP records a member trying to join or leave a meal after its RSVPs closed. Sets the ID of l on success.
+func (as *AttendanceService) CreateLateRSVP(l *dinny.LateRSVP) error {
+	var eating int64
+	if l.Eating {
+		eating = 1
+	}
+	params := gen.CreateLateRSVPParams{MealID: l.MealID, MemberID: l.MemberID, Eating: eating}
+	created, err := as.query.CreateLateRSVP(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CreateLateRSVP: %w", err)
+	}
+	*l = *toDinnyLateRSVP(created)
+	return nil
+}
+
+// toDinnyGuests converts a gen.Guest to a dinny.Guests.
+func toDinnyGuests(g gen.Guest) *dinny.Guests {
+	return &dinny.Guests{
+		MealID:    g.MealID,
+		MemberID:  g.MemberID,
+		Count:     g.Count,
+		UpdatedAt: parseTime(g.UpdatedAt),
+	}
+}
+
+// ListGuestsByMeal retrieves the guests every member brings to a meal.
+func (as *AttendanceService) ListGuestsByMeal(mealID int64) ([]*dinny.Guests, error) {
+	gs, err := as.query.ListGuestsByMeal(context.Background(), mealID)
+	if err != nil {
+		return nil, fmt.Errorf("ListGuestsByMeal: %w", err)
+	}
+	guests := make([]*dinny.Guests, len(gs))
+	for ii, g := range gs {
+		guests[ii] = toDinnyGuests(g)
+	}
+	return guests, nil
+}
+
+// ListGuestsByMember retrieves the guests a member brought to every meal.
+func (as *AttendanceService) ListGuestsByMember(memberID int64) ([]*dinny.Guests, error) {
+	gs, err := as.query.ListGuestsByMember(context.Background(), memberID)
+	if err != nil {
+		return nil, fmt.Errorf("ListGuestsByMember: %w", err)
+	}
+	guests := make([]*dinny.Guests, len(gs))
+	for ii, g := range gs {
+		guests[ii] = toDinnyGuests(g)
+	}
+	return guests, nil
+}
+
+// SetGuests sets the number of guests a member brings to a meal, replacing the previous number. Setting 0 removes the guests.
+// Returns ErrMealClosed if the meal has been closed out.
+func (as *AttendanceService) SetGuests(mealID int64, memberID int64, count int64) error {
+	tx, err := as.db.Begin()
+	if err != nil {
+		return fmt.Errorf("SetGuests db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := as.query.WithTx(tx)
+	if err := checkMealOpen(qtx, as.rotationID, mealID); err != nil {
+		return fmt.Errorf("SetGuests: %w", err)
+	}
+	if count > 0 {
+		params := gen.UpsertGuestsParams{MealID: mealID, MemberID: memberID, Count: count}
+		err = qtx.UpsertGuests(context.Background(), params)
+	} else {
+		params := gen.DeleteGuestsParams{MealID: mealID, MemberID: memberID}
+		err = qtx.DeleteGuests(context.Background(), params)
+	}
+	if err != nil {
+		return fmt.Errorf("SetGuests: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("SetGuests tx.Commit: %w", err)
+	}
+	return nil
+}
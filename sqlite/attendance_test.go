@@ -0,0 +1,251 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// TestAttendanceService ensures attendances keep the member's meals eaten in sync and are idempotent.
+func TestAttendanceService(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+
+	member := &dinny.Member{SlackUID: "U1", FullName: "Jane Doe"}
+	if err := memberService.CreateMember(member); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.CreateMeal(&dinny.Meal{CookSlackUID: "U2", Date: date}); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mealsEaten := func() int64 {
+		m, err := memberService.FindMemberByID(member.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m.MealsEaten
+	}
+
+	a := &dinny.Attendance{MealID: meal.ID, MemberID: member.ID, Source: dinny.AttendanceSourceReaction}
+	for ii := 0; ii < 2; ii++ {
+		if err := attendanceService.CreateAttendance(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := mealsEaten(); got != 1 {
+		t.Errorf("MealsEaten after create = %d, want 1", got)
+	}
+	atts, err := attendanceService.ListAttendancesByMeal(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(atts) != 1 || atts[0].MemberID != member.ID || atts[0].Source != dinny.AttendanceSourceReaction {
+		t.Errorf("ListAttendancesByMeal = %+v, want a single reaction attendance", atts)
+	}
+
+	for ii := 0; ii < 2; ii++ {
+		if err := attendanceService.DeleteAttendance(meal.ID, member.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := mealsEaten(); got != 0 {
+		t.Errorf("MealsEaten after delete = %d, want 0", got)
+	}
+	if _, err := attendanceService.FindAttendance(meal.ID, member.ID); err != dinny.ErrNotFound {
+		t.Errorf("FindAttendance err = %v, want ErrNotFound", err)
+	}
+}
+
+// TestLateRSVPs ensures late RSVPs are recorded apart from the attendances and leave the meals eaten alone.
+func TestLateRSVPs(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+
+	member := &dinny.Member{SlackUID: "U1", FullName: "Jane Doe"}
+	if err := memberService.CreateMember(member); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, "U2"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	late := &dinny.LateRSVP{MealID: meal.ID, MemberID: member.ID, Eating: true}
+	if err := attendanceService.CreateLateRSVP(late); err != nil {
+		t.Fatal(err)
+	}
+	if late.ID == 0 {
+		t.Error("ID = 0, want set")
+	}
+	lateRSVPs, err := attendanceService.ListLateRSVPsByMeal(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lateRSVPs) != 1 || !lateRSVPs[0].Eating || lateRSVPs[0].MemberID != member.ID {
+		t.Errorf("ListLateRSVPsByMeal() = %+v, want the late RSVP", lateRSVPs)
+	}
+	atts, err := attendanceService.ListAttendancesByMeal(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(atts) != 0 {
+		t.Errorf("len(attendances) = %d, want 0", len(atts))
+	}
+}
+
+// TestSetGuests ensures guests replace the previous number, are removed by 0, and leave the host's meals eaten alone.
+func TestSetGuests(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+
+	host := &dinny.Member{SlackUID: "U1", FullName: "Jane Doe"}
+	if err := memberService.CreateMember(host); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, "U2"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		count int64
+		want  int64
+	}{{2, 2}, {3, 3}, {0, 0}} {
+		if err := attendanceService.SetGuests(meal.ID, host.ID, tt.count); err != nil {
+			t.Fatal(err)
+		}
+		guests, err := attendanceService.ListGuestsByMeal(meal.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := dinny.CountGuests(guests); got != tt.want {
+			t.Errorf("SetGuests(%d) guests = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+	m, err := memberService.FindMemberByID(host.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MealsEaten != 0 {
+		t.Errorf("MealsEaten = %d, want 0", m.MealsEaten)
+	}
+
+	if err := attendanceService.SetGuests(meal.ID, host.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.CancelMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := attendanceService.SetGuests(meal.ID, host.ID, 2); !errors.Is(err, dinny.ErrMealClosed) {
+		t.Errorf("SetGuests err = %v, want ErrMealClosed", err)
+	}
+	guests, err := attendanceService.ListGuestsByMember(host.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(guests) != 0 {
+		t.Errorf("len(guests) = %d after cancelling, want 0", len(guests))
+	}
+}
+
+// TestReconcileMealsEaten ensures a meals eaten counter which drifted from the attendances is reported and corrected,
+// and that removing an attendance fails rather than taking a drifted counter below 0.
+func TestReconcileMealsEaten(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+
+	member := &dinny.Member{SlackUID: "U1", FullName: "Jane Doe"}
+	if err := memberService.CreateMember(member); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.CreateMeal(&dinny.Meal{CookSlackUID: "U2", Date: date}); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &dinny.Attendance{MealID: meal.ID, MemberID: member.ID, Source: dinny.AttendanceSourceReaction}
+	if err := attendanceService.CreateAttendance(a); err != nil {
+		t.Fatal(err)
+	}
+	drifted := int64(5)
+	if err := memberService.UpdateMember(member.ID, dinny.MemberUpdate{MealsEaten: &drifted}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		corrections, err := ReconcileMealsEaten(db, dryRun)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(corrections) != 1 || corrections[0].MemberID != member.ID || corrections[0].From != 5 || corrections[0].To != 1 {
+			t.Errorf("ReconcileMealsEaten(%v) = %+v, want U1 from 5 to 1", dryRun, corrections)
+		}
+	}
+	m, err := memberService.FindMemberByID(member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MealsEaten != 1 {
+		t.Errorf("MealsEaten after reconciling = %d, want 1", m.MealsEaten)
+	}
+	if corrections, err := ReconcileMealsEaten(db, false); err != nil || len(corrections) != 0 {
+		t.Errorf("ReconcileMealsEaten() again = %+v, %v, want no corrections", corrections, err)
+	}
+
+	drifted = 0
+	if err := memberService.UpdateMember(member.ID, dinny.MemberUpdate{MealsEaten: &drifted}); err != nil {
+		t.Fatal(err)
+	}
+	if err := attendanceService.DeleteAttendance(meal.ID, member.ID); err == nil {
+		t.Error("DeleteAttendance() taking meals eaten below 0 succeeded")
+	}
+}
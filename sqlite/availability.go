@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.AvailabilityService = (*AvailabilityService)(nil)
+
+// AvailabilityService represents a service for managing member availability.
+type AvailabilityService struct {
+	query *gen.Queries
+	db    *sql.DB
+
+	// rotationID is the rotation the service is scoped to.
+	rotationID int64
+}
+
+// NewAvailabilityService returns a new instance of AvailabilityService scoped to the default rotation.
+func NewAvailabilityService(query *gen.Queries, db *sql.DB) *AvailabilityService {
+	return &AvailabilityService{query, db, dinny.DefaultRotationID}
+}
+
+// ForRotation returns a copy of the service scoped to the rotation with the given ID.
+func (as *AvailabilityService) ForRotation(rotationID int64) *AvailabilityService {
+	scoped := *as
+	scoped.rotationID = rotationID
+	return &scoped
+}
+
+// toDinnyAvailability converts a gen.Availability to a dinny.Availability.
+func toDinnyAvailability(a gen.Availability) (*dinny.Availability, error) {
+	from, err := dinny.ParseDate(a.FromDate)
+	if err != nil {
+		return nil, fmt.Errorf("toDinnyAvailability from: %w", err)
+	}
+	to, err := dinny.ParseDate(a.ToDate)
+	if err != nil {
+		return nil, fmt.Errorf("toDinnyAvailability to: %w", err)
+	}
+	return &dinny.Availability{
+		ID:         a.ID,
+		MemberID:   a.MemberID,
+		From:       from,
+		To:         to,
+		CannotCook: a.CannotCook == 1,
+		NotEating:  a.NotEating == 1,
+		Note:       a.Note,
+	}, nil
+}
+
+// toDinnyAvailabilities converts a slice of gen.Availability to a slice of dinny.Availability.
+func toDinnyAvailabilities(avs []gen.Availability) ([]*dinny.Availability, error) {
+	var availabilities []*dinny.Availability
+	for _, av := range avs {
+		a, err := toDinnyAvailability(av)
+		if err != nil {
+			return nil, err
+		}
+		availabilities = append(availabilities, a)
+	}
+	return availabilities, nil
+}
+
+// ListAvailabilities retrieves the availabilities overlapping the range between from and to, inclusive.
+func (as *AvailabilityService) ListAvailabilities(from dinny.Date, to dinny.Date) ([]*dinny.Availability, error) {
+	params := gen.ListAvailabilitiesParams{
+		FromDate:   from.String(),
+		ToDate:     to.String(),
+		RotationID: as.rotationID,
+	}
+	avs, err := as.query.ListAvailabilities(context.Background(), params)
+	if err != nil {
+		return nil, fmt.Errorf("ListAvailabilities: %w", err)
+	}
+	availabilities, err := toDinnyAvailabilities(avs)
+	if err != nil {
+		return nil, fmt.Errorf("ListAvailabilities: %w", err)
+	}
+	return availabilities, nil
+}
+
+// ListAvailabilitiesByMember retrieves every availability of a member.
+func (as *AvailabilityService) ListAvailabilitiesByMember(memberID int64) ([]*dinny.Availability, error) {
+	avs, err := as.query.ListAvailabilitiesByMember(context.Background(), memberID)
+	if err != nil {
+		return nil, fmt.Errorf("ListAvailabilitiesByMember: %w", err)
+	}
+	availabilities, err := toDinnyAvailabilities(avs)
+	if err != nil {
+		return nil, fmt.Errorf("ListAvailabilitiesByMember: %w", err)
+	}
+	return availabilities, nil
+}
+
+// CreateAvailability creates a new availability. Sets the ID of a on success.
+func (as *AvailabilityService) CreateAvailability(a *dinny.Availability) error {
+	var cannotCook, notEating int64
+	if a.CannotCook {
+		cannotCook = 1
+	}
+	if a.NotEating {
+		notEating = 1
+	}
+	params := gen.CreateAvailabilityParams{
+		MemberID:   a.MemberID,
+		FromDate:   a.From.String(),
+		ToDate:     a.To.String(),
+		CannotCook: cannotCook,
+		NotEating:  notEating,
+		Note:       a.Note,
+	}
+	av, err := as.query.CreateAvailability(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CreateAvailability: %w", err)
+	}
+	a.ID = av.ID
+	return nil
+}
+
+// DeleteAvailability permanently deletes an availability.
+// Returns ErrNotFound if availability does not exist.
+func (as *AvailabilityService) DeleteAvailability(id int64) error {
+	n, err := as.query.DeleteAvailability(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("DeleteAvailability: %w", err)
+	}
+	if n == 0 {
+		return dinny.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.DietaryProfileService = (*DietaryProfileService)(nil)
+
+// DietaryProfileService represents a service for managing the dietary profiles of members.
+type DietaryProfileService struct {
+	query *gen.Queries
+	db    *sql.DB
+
+	// rotationID is the rotation the service is scoped to.
+	rotationID int64
+}
+
+// NewDietaryProfileService returns a new instance of DietaryProfileService scoped to the default rotation.
+func NewDietaryProfileService(query *gen.Queries, db *sql.DB) *DietaryProfileService {
+	return &DietaryProfileService{query, db, dinny.DefaultRotationID}
+}
+
+// ForRotation returns a copy of the service scoped to the rotation with the given ID.
+func (ds *DietaryProfileService) ForRotation(rotationID int64) *DietaryProfileService {
+	scoped := *ds
+	scoped.rotationID = rotationID
+	return &scoped
+}
+
+// toDinnyDietaryProfile converts a gen.DietaryProfile to a dinny.DietaryProfile.
+func toDinnyDietaryProfile(p gen.DietaryProfile) *dinny.DietaryProfile {
+	return &dinny.DietaryProfile{
+		MemberID:     p.MemberID,
+		Restrictions: splitTags(p.Restrictions),
+		Allergies:    p.Allergies,
+	}
+}
+
+// FindDietaryProfile retrieves the dietary profile of a member.
+// Returns ErrNotFound if the member hasn't set one.
+func (ds *DietaryProfileService) FindDietaryProfile(memberID int64) (*dinny.DietaryProfile, error) {
+	p, err := ds.query.FindDietaryProfile(context.Background(), memberID)
+	if err == sql.ErrNoRows {
+		return nil, dinny.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("FindDietaryProfile: %w", err)
+	}
+	return toDinnyDietaryProfile(p), nil
+}
+
+// ListDietaryProfiles retrieves every dietary profile.
+func (ds *DietaryProfileService) ListDietaryProfiles() ([]*dinny.DietaryProfile, error) {
+	ps, err := ds.query.ListDietaryProfiles(context.Background(), ds.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListDietaryProfiles: %w", err)
+	}
+	profiles := make([]*dinny.DietaryProfile, len(ps))
+	for ii, p := range ps {
+		profiles[ii] = toDinnyDietaryProfile(p)
+	}
+	return profiles, nil
+}
+
+// SetDietaryProfile creates or replaces the dietary profile of p.MemberID.
+func (ds *DietaryProfileService) SetDietaryProfile(p *dinny.DietaryProfile) error {
+	params := gen.UpsertDietaryProfileParams{
+		MemberID:     p.MemberID,
+		Restrictions: strings.Join(p.Restrictions, ","),
+		Allergies:    p.Allergies,
+	}
+	err := ds.query.UpsertDietaryProfile(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("SetDietaryProfile: %w", err)
+	}
+	return nil
+}
+
+// ListDietWarnings retrieves the IDs of the members whose dietary restrictions the cooks of the meal were warned about.
+func (ds *DietaryProfileService) ListDietWarnings(mealID int64) ([]int64, error) {
+	ids, err := ds.query.ListDietWarningsByMeal(context.Background(), mealID)
+	if err != nil {
+		return nil, fmt.Errorf("ListDietWarnings: %w", err)
+	}
+	return ids, nil
+}
+
+// CreateDietWarning records that the cooks of the meal were warned about the dietary restrictions of the member.
+// Recording the same warning twice is a no-op.
+func (ds *DietaryProfileService) CreateDietWarning(mealID int64, memberID int64) error {
+	err := ds.query.CreateDietWarning(context.Background(), gen.CreateDietWarningParams{MealID: mealID, MemberID: memberID})
+	if err != nil {
+		return fmt.Errorf("CreateDietWarning: %w", err)
+	}
+	return nil
+}
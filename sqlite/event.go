@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.ProcessedEventService = (*ProcessedEventService)(nil)
+
+// ProcessedEventService represents a service for remembering which Slack events have been processed.
+type ProcessedEventService struct {
+	query *gen.Queries
+	db    *sql.DB
+}
+
+// NewProcessedEventService returns a new instance of ProcessedEventService.
+func NewProcessedEventService(query *gen.Queries, db *sql.DB) *ProcessedEventService {
+	return &ProcessedEventService{query, db}
+}
+
+// CreateProcessedEvent records the Slack event with the given event_id as processed.
+// Returns false if the event has already been recorded.
+func (ps *ProcessedEventService) CreateProcessedEvent(eventID string) (bool, error) {
+	n, err := ps.query.CreateProcessedEvent(context.Background(), eventID)
+	if err != nil {
+		return false, fmt.Errorf("CreateProcessedEvent: %w", err)
+	}
+	return n > 0, nil
+}
+
+// DeleteProcessedEvent forgets the Slack event with the given event_id, so that a retry of it is processed again.
+func (ps *ProcessedEventService) DeleteProcessedEvent(eventID string) error {
+	err := ps.query.DeleteProcessedEvent(context.Background(), eventID)
+	if err != nil {
+		return fmt.Errorf("DeleteProcessedEvent: %w", err)
+	}
+	return nil
+}
+
+// DeleteProcessedEventsBefore forgets the events processed before t and returns how many were forgotten.
+func (ps *ProcessedEventService) DeleteProcessedEventsBefore(t time.Time) (int64, error) {
+	n, err := ps.query.DeleteProcessedEventsBefore(context.Background(), formatTime(t))
+	if err != nil {
+		return 0, fmt.Errorf("DeleteProcessedEventsBefore: %w", err)
+	}
+	return n, nil
+}
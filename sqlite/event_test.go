@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// TestProcessedEventService ensures events are only recorded once and are forgotten after their retention or when deleted.
+func TestProcessedEventService(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	eventService := NewProcessedEventService(gen.New(db), db)
+
+	for _, want := range []bool{true, false} {
+		fresh, err := eventService.CreateProcessedEvent("Ev1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fresh != want {
+			t.Errorf("CreateProcessedEvent() = %v, want %v", fresh, want)
+		}
+	}
+
+	n, err := eventService.DeleteProcessedEventsBefore(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("DeleteProcessedEventsBefore() = %d, want 0 for an event within the retention", n)
+	}
+	n, err = eventService.DeleteProcessedEventsBefore(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("DeleteProcessedEventsBefore() = %d, want 1", n)
+	}
+
+	fresh, err := eventService.CreateProcessedEvent("Ev1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fresh {
+		t.Errorf("CreateProcessedEvent() = false after the event was forgotten, want true")
+	}
+
+	if err := eventService.DeleteProcessedEvent("Ev1"); err != nil {
+		t.Fatal(err)
+	}
+	fresh, err = eventService.CreateProcessedEvent("Ev1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fresh {
+		t.Errorf("CreateProcessedEvent() = false after the event was deleted, want true")
+	}
+}
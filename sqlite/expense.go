@@ -0,0 +1,234 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.ExpenseService = (*ExpenseService)(nil)
+
+// ExpenseService represents a service for managing grocery expenses and payments.
+type ExpenseService struct {
+	query *gen.Queries
+	db    *sql.DB
+
+	// rotationID is the rotation the service is scoped to.
+	rotationID int64
+}
+
+// NewExpenseService returns a new instance of ExpenseService scoped to the default rotation.
+func NewExpenseService(query *gen.Queries, db *sql.DB) *ExpenseService {
+	return &ExpenseService{query, db, dinny.DefaultRotationID}
+}
+
+// ForRotation returns a copy of the service scoped to the rotation with the given ID.
+func (es *ExpenseService) ForRotation(rotationID int64) *ExpenseService {
+	scoped := *es
+	scoped.rotationID = rotationID
+	return &scoped
+}
+
+// toDinnyExpense converts a gen.Expense to a dinny.Expense.
+func toDinnyExpense(e gen.Expense) *dinny.Expense {
+	return &dinny.Expense{
+		ID:          e.ID,
+		MealID:      e.MealID,
+		MemberID:    e.MemberID,
+		AmountCents: e.AmountCents,
+		Note:        e.Note,
+		CreatedAt:   parseTime(e.CreatedAt),
+	}
+}
+
+// ListExpensesByMeal retrieves every expense logged for a meal.
+func (es *ExpenseService) ListExpensesByMeal(mealID int64) ([]*dinny.Expense, error) {
+	exps, err := es.query.ListExpensesByMeal(context.Background(), mealID)
+	if err != nil {
+		return nil, fmt.Errorf("ListExpensesByMeal: %w", err)
+	}
+	var expenses []*dinny.Expense
+	for _, e := range exps {
+		expenses = append(expenses, toDinnyExpense(e))
+	}
+	return expenses, nil
+}
+
+// FindExpenseByID retrieves an expense by ID.
+// Returns ErrNotFound if the expense doesn't belong to the rotation.
+func (es *ExpenseService) FindExpenseByID(id int64) (*dinny.Expense, error) {
+	e, err := es.query.FindExpenseByID(context.Background(), gen.FindExpenseByIDParams{ID: id, RotationID: es.rotationID})
+	if err == sql.ErrNoRows {
+		return nil, dinny.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("FindExpenseByID: %w", err)
+	}
+	return toDinnyExpense(e), nil
+}
+
+// CreateExpense logs a grocery cost against a meal. Sets the ID of e on success.
+// Returns ErrNotFound if the meal doesn't belong to the rotation.
+func (es *ExpenseService) CreateExpense(e *dinny.Expense) error {
+	tx, err := es.db.Begin()
+	if err != nil {
+		return fmt.Errorf("CreateExpense db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := es.query.WithTx(tx)
+	if _, err := qtx.FindMealByID(context.Background(), gen.FindMealByIDParams{ID: e.MealID, RotationID: es.rotationID}); err == sql.ErrNoRows {
+		return dinny.ErrNotFound
+	} else if err != nil {
+		return fmt.Errorf("CreateExpense FindMealByID: %w", err)
+	}
+	params := gen.CreateExpenseParams{
+		MealID:      e.MealID,
+		MemberID:    e.MemberID,
+		AmountCents: e.AmountCents,
+		Note:        e.Note,
+	}
+	created, err := qtx.CreateExpense(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CreateExpense: %w", err)
+	}
+	*e = *toDinnyExpense(created)
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("CreateExpense tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpense permanently deletes an expense logged by mistake.
+// Returns ErrNotFound if the expense doesn't belong to the rotation.
+func (es *ExpenseService) DeleteExpense(id int64) error {
+	tx, err := es.db.Begin()
+	if err != nil {
+		return fmt.Errorf("DeleteExpense db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := es.query.WithTx(tx)
+	if _, err := qtx.FindExpenseByID(context.Background(), gen.FindExpenseByIDParams{ID: id, RotationID: es.rotationID}); err == sql.ErrNoRows {
+		return dinny.ErrNotFound
+	} else if err != nil {
+		return fmt.Errorf("DeleteExpense FindExpenseByID: %w", err)
+	}
+	_, err = qtx.DeleteExpense(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("DeleteExpense: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("DeleteExpense tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// CreatePayment records a member paying another member. Sets the ID of p on success.
+// Returns ErrNotFound if either member doesn't belong to the rotation and an error if a member pays themselves.
+func (es *ExpenseService) CreatePayment(p *dinny.Payment) error {
+	if p.FromMemberID == p.ToMemberID {
+		return fmt.Errorf("CreatePayment: member %d can't pay themselves", p.FromMemberID)
+	}
+	tx, err := es.db.Begin()
+	if err != nil {
+		return fmt.Errorf("CreatePayment db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := es.query.WithTx(tx)
+	for _, memberID := range []int64{p.FromMemberID, p.ToMemberID} {
+		if _, err := qtx.FindMemberByID(context.Background(), gen.FindMemberByIDParams{ID: memberID, RotationID: es.rotationID}); err == sql.ErrNoRows {
+			return dinny.ErrNotFound
+		} else if err != nil {
+			return fmt.Errorf("CreatePayment FindMemberByID: %w", err)
+		}
+	}
+	params := gen.CreatePaymentParams{
+		FromMemberID: p.FromMemberID,
+		ToMemberID:   p.ToMemberID,
+		AmountCents:  p.AmountCents,
+		Note:         p.Note,
+	}
+	created, err := qtx.CreatePayment(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CreatePayment: %w", err)
+	}
+	p.ID = created.ID
+	p.CreatedAt = parseTime(created.CreatedAt)
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("CreatePayment tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// ListBalances retrieves the balance of every member, splitting each expense across the eaters of its meal.
+// The members paying for an expense are owed it, the eaters owe their shares and payments move money between the two.
+func (es *ExpenseService) ListBalances() ([]*dinny.Balance, error) {
+	tx, err := es.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("ListBalances db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := es.query.WithTx(tx)
+
+	mems, err := qtx.ListMembers(context.Background(), es.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListBalances ListMembers: %w", err)
+	}
+	cents := make(map[int64]int64, len(mems))
+
+	exps, err := qtx.ListExpenses(context.Background(), es.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListBalances ListExpenses: %w", err)
+	}
+	portions := make(map[int64]map[int64]int64)
+	for _, e := range exps {
+		if _, ok := portions[e.MealID]; !ok {
+			atts, err := qtx.ListAttendancesByMeal(context.Background(), e.MealID)
+			if err != nil {
+				return nil, fmt.Errorf("ListBalances ListAttendancesByMeal: %w", err)
+			}
+			gs, err := qtx.ListGuestsByMeal(context.Background(), e.MealID)
+			if err != nil {
+				return nil, fmt.Errorf("ListBalances ListGuestsByMeal: %w", err)
+			}
+			attendances := make([]*dinny.Attendance, len(atts))
+			for ii, a := range atts {
+				attendances[ii] = toDinnyAttendance(a)
+			}
+			guests := make([]*dinny.Guests, len(gs))
+			for ii, g := range gs {
+				guests[ii] = toDinnyGuests(g)
+			}
+			portions[e.MealID] = dinny.Portions(attendances, guests)
+		}
+		cents[e.MemberID] += e.AmountCents
+		for memberID, share := range dinny.SplitCost(e.AmountCents, portions[e.MealID]) {
+			cents[memberID] -= share
+		}
+	}
+
+	pays, err := qtx.ListPayments(context.Background(), es.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListBalances ListPayments: %w", err)
+	}
+	for _, p := range pays {
+		cents[p.FromMemberID] += p.AmountCents
+		cents[p.ToMemberID] -= p.AmountCents
+	}
+
+	balances := make([]*dinny.Balance, len(mems))
+	for ii, m := range mems {
+		balances[ii] = &dinny.Balance{
+			MemberID: m.ID,
+			SlackUID: m.SlackUid,
+			FullName: m.FullName,
+			Cents:    cents[m.ID],
+		}
+	}
+	return balances, nil
+}
@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// TestListBalances ensures expenses are split across a meal's eaters and their guests, and payments settle the balances.
+func TestListBalances(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+	expenseService := NewExpenseService(queries, db)
+
+	var members []*dinny.Member
+	for _, uid := range []string{"U1", "U2", "U3"} {
+		m := &dinny.Member{SlackUID: uid, FullName: uid}
+		if err := memberService.CreateMember(m); err != nil {
+			t.Fatal(err)
+		}
+		members = append(members, m)
+	}
+	cook, eater, host := members[0], members[1], members[2]
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, cook.SlackUID); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range members {
+		a := &dinny.Attendance{MealID: meal.ID, MemberID: m.ID, Source: dinny.AttendanceSourceManual}
+		if err := attendanceService.CreateAttendance(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := attendanceService.SetGuests(meal.ID, host.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := expenseService.CreateExpense(&dinny.Expense{MealID: meal.ID + 1, MemberID: cook.ID, AmountCents: 100}); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("CreateExpense() of a missing meal err = %v, want ErrNotFound", err)
+	}
+	if err := expenseService.CreateExpense(&dinny.Expense{MealID: meal.ID, MemberID: cook.ID, AmountCents: 2000, Note: "groceries"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := expenseService.CreatePayment(&dinny.Payment{FromMemberID: host.ID, ToMemberID: cook.ID, AmountCents: 1000}); err != nil {
+		t.Fatal(err)
+	}
+
+	balances, err := expenseService.ListBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Four portions of 5.00: the cook paid 20.00 and ate one, the host ate two and paid back 10.00.
+	want := map[int64]int64{cook.ID: 500, eater.ID: -500, host.ID: 0}
+	if len(balances) != len(want) {
+		t.Fatalf("len(balances) = %d, want %d", len(balances), len(want))
+	}
+	for _, b := range balances {
+		if b.Cents != want[b.MemberID] {
+			t.Errorf("balance of %s = %d, want %d", b.SlackUID, b.Cents, want[b.MemberID])
+		}
+	}
+}
+
+// TestDeleteExpense ensures an expense logged by mistake no longer counts towards the balances once deleted.
+func TestDeleteExpense(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	expenseService := NewExpenseService(queries, db)
+
+	cook := &dinny.Member{SlackUID: "U1", FullName: "U1"}
+	if err := memberService.CreateMember(cook); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, cook.SlackUID); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := &dinny.Expense{MealID: meal.ID, MemberID: cook.ID, AmountCents: 2000}
+	if err := expenseService.CreateExpense(e); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := expenseService.ForRotation(2).DeleteExpense(e.ID); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("DeleteExpense() from another rotation err = %v, want ErrNotFound", err)
+	}
+	if err := expenseService.DeleteExpense(e.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := expenseService.FindExpenseByID(e.ID); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("FindExpenseByID() err = %v, want ErrNotFound", err)
+	}
+	if err := expenseService.DeleteExpense(e.ID); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("DeleteExpense() twice err = %v, want ErrNotFound", err)
+	}
+	balances, err := expenseService.ListBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 1 || balances[0].Cents != 0 {
+		t.Errorf("balances = %+v, want the cook settled", balances)
+	}
+}
+
+// TestCreatePaymentInvalid ensures members can't pay themselves or members who don't exist.
+func TestCreatePaymentInvalid(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	expenseService := NewExpenseService(queries, db)
+
+	m := &dinny.Member{SlackUID: "U1", FullName: "U1"}
+	if err := memberService.CreateMember(m); err != nil {
+		t.Fatal(err)
+	}
+	if err := expenseService.CreatePayment(&dinny.Payment{FromMemberID: m.ID, ToMemberID: m.ID, AmountCents: 100}); err == nil {
+		t.Error("CreatePayment() to themselves succeeded, want error")
+	}
+	if err := expenseService.CreatePayment(&dinny.Payment{FromMemberID: m.ID, ToMemberID: m.ID + 1, AmountCents: 100}); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("CreatePayment() to a missing member err = %v, want ErrNotFound", err)
+	}
+	if err := expenseService.ForRotation(2).CreatePayment(&dinny.Payment{FromMemberID: m.ID, ToMemberID: m.ID + 1, AmountCents: 100}); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("CreatePayment() from another rotation err = %v, want ErrNotFound", err)
+	}
+}
@@ -8,25 +8,159 @@ import (
 	"database/sql"
 )
 
+type Attendance struct {
+	ID        int64
+	MealID    int64
+	MemberID  int64
+	Source    string
+	CreatedAt string
+}
+
+type Availability struct {
+	ID         int64
+	MemberID   int64
+	FromDate   string
+	ToDate     string
+	CannotCook int64
+	NotEating  int64
+	Note       string
+	CreatedAt  string
+}
+
+type DietaryProfile struct {
+	MemberID     int64
+	Restrictions string
+	Allergies    string
+	UpdatedAt    string
+}
+
+type DietWarning struct {
+	MealID    int64
+	MemberID  int64
+	CreatedAt string
+}
+
+type Expense struct {
+	ID          int64
+	MealID      int64
+	MemberID    int64
+	AmountCents int64
+	Note        string
+	CreatedAt   string
+}
+
+type Guest struct {
+	MealID    int64
+	MemberID  int64
+	Count     int64
+	UpdatedAt string
+}
+
+type HeadcountDelivery struct {
+	MealID    int64
+	SlackUid  string
+	CreatedAt string
+}
+
+type JobRun struct {
+	Name          string
+	LastRunAt     string
+	LastSuccessAt sql.NullString
+	LastError     string
+}
+
+type LateRsvp struct {
+	ID        int64
+	MealID    int64
+	MemberID  int64
+	Eating    int64
+	CreatedAt string
+}
+
 type Meal struct {
+	ID              int64
+	CookSlackUid    string
+	Year            int64
+	Month           int64
+	Day             int64
+	Description     sql.NullString
+	SlackMessageID  sql.NullString
+	CreatedAt       string
+	UpdatedAt       string
+	Status          string
+	ClosedAt        sql.NullString
+	Title           string
+	Tags            string
+	HeadcountSentAt sql.NullString
+	RsvpDeadline    sql.NullString
+	RsvpsClosedAt   sql.NullString
+	RotationID      int64
+}
+
+type MealCook struct {
+	MealID   int64
+	SlackUid string
+	Position int64
+}
+
+type Member struct {
 	ID             int64
-	CookSlackUid   string
-	Year           int64
-	Month          int64
-	Day            int64
-	Description    sql.NullString
-	SlackMessageID sql.NullString
+	SlackUid       string
+	FullName       string
+	MealsEaten     int64
+	MealsCooked    float64
+	Leader         int64
 	CreatedAt      string
 	UpdatedAt      string
+	RotationID     int64
+	CookingCredits float64
 }
 
-type Member struct {
-	ID          int64
-	SlackUid    string
-	FullName    string
-	MealsEaten  int64
-	MealsCooked int64
-	Leader      int64
-	CreatedAt   string
-	UpdatedAt   string
+type Payment struct {
+	ID           int64
+	FromMemberID int64
+	ToMemberID   int64
+	AmountCents  int64
+	Note         string
+	CreatedAt    string
+}
+
+type ProcessedEvent struct {
+	EventID     string
+	ProcessedAt string
+}
+
+type Rotation struct {
+	ID           int64
+	Name         string
+	SlackChannel string
+	CreatedAt    string
+	UpdatedAt    string
+}
+
+type Season struct {
+	ID         int64
+	RotationID int64
+	Name       string
+	FromDate   string
+	ToDate     string
+	ClosedAt   string
+}
+
+type SeasonStanding struct {
+	SeasonID       int64
+	MemberID       int64
+	MealsEaten     int64
+	MealsCooked    float64
+	CookingCredits float64
+}
+
+type Token struct {
+	ID        int64
+	MemberID  int64
+	Name      string
+	Scope     string
+	Hash      string
+	CreatedAt string
+	RevokedAt sql.NullString
 }
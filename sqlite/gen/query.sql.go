@@ -10,30 +10,253 @@ import (
 	"database/sql"
 )
 
+const averageCookedMealHeadcount = `-- name: AverageCookedMealHeadcount :one
+SELECT CAST(COALESCE(AVG(
+    (SELECT count(*) FROM attendances WHERE attendances.meal_id = meals.id) +
+    (SELECT COALESCE(SUM(guests.count), 0) FROM guests WHERE guests.meal_id = meals.id)
+), 0) AS REAL) AS average_headcount
+FROM meals
+WHERE rotation_id = ? AND status = ?
+`
+
+type AverageCookedMealHeadcountParams struct {
+	RotationID int64
+	Status     string
+}
+
+func (q *Queries) AverageCookedMealHeadcount(ctx context.Context, arg AverageCookedMealHeadcountParams) (float64, error) {
+	row := q.db.QueryRowContext(ctx, averageCookedMealHeadcount, arg.RotationID, arg.Status)
+	var average_headcount float64
+	err := row.Scan(&average_headcount)
+	return average_headcount, err
+}
+
+const closeMeal = `-- name: CloseMeal :execrows
+UPDATE meals
+set status = ?, closed_at = datetime('now'), updated_at = datetime('now')
+WHERE id = ? AND status = 'scheduled'
+`
+
+type CloseMealParams struct {
+	Status string
+	ID     int64
+}
+
+func (q *Queries) CloseMeal(ctx context.Context, arg CloseMealParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, closeMeal, arg.Status, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const closeMealRSVPs = `-- name: CloseMealRSVPs :execrows
+UPDATE meals
+set rsvps_closed_at = datetime('now'), updated_at = datetime('now')
+WHERE id = ? AND rsvps_closed_at IS NULL
+`
+
+func (q *Queries) CloseMealRSVPs(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, closeMealRSVPs, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const countMealsByDate = `-- name: CountMealsByDate :one
-SELECT count(*) FROM meals WHERE year = ? AND month = ? AND day = ?
+SELECT count(*) FROM meals WHERE year = ? AND month = ? AND day = ? AND rotation_id = ?
 `
 
 type CountMealsByDateParams struct {
-	Year  int64
-	Month int64
-	Day   int64
+	Year       int64
+	Month      int64
+	Day        int64
+	RotationID int64
 }
 
 func (q *Queries) CountMealsByDate(ctx context.Context, arg CountMealsByDateParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countMealsByDate, arg.Year, arg.Month, arg.Day)
+	row := q.db.QueryRowContext(ctx, countMealsByDate,
+		arg.Year,
+		arg.Month,
+		arg.Day,
+		arg.RotationID,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
+const createAttendance = `-- name: CreateAttendance :execrows
+INSERT INTO attendances (
+    meal_id, member_id, source
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (meal_id, member_id) DO NOTHING
+`
+
+type CreateAttendanceParams struct {
+	MealID   int64
+	MemberID int64
+	Source   string
+}
+
+func (q *Queries) CreateAttendance(ctx context.Context, arg CreateAttendanceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createAttendance, arg.MealID, arg.MemberID, arg.Source)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createAvailability = `-- name: CreateAvailability :one
+INSERT INTO availabilities (
+    member_id, from_date, to_date, cannot_cook, not_eating, note
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+RETURNING id, member_id, from_date, to_date, cannot_cook, not_eating, note, created_at
+`
+
+type CreateAvailabilityParams struct {
+	MemberID   int64
+	FromDate   string
+	ToDate     string
+	CannotCook int64
+	NotEating  int64
+	Note       string
+}
+
+func (q *Queries) CreateAvailability(ctx context.Context, arg CreateAvailabilityParams) (Availability, error) {
+	row := q.db.QueryRowContext(ctx, createAvailability,
+		arg.MemberID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.CannotCook,
+		arg.NotEating,
+		arg.Note,
+	)
+	var i Availability
+	err := row.Scan(
+		&i.ID,
+		&i.MemberID,
+		&i.FromDate,
+		&i.ToDate,
+		&i.CannotCook,
+		&i.NotEating,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createDietWarning = `-- name: CreateDietWarning :exec
+INSERT OR IGNORE INTO diet_warnings (
+    meal_id, member_id
+) VALUES (
+    ?, ?
+)
+`
+
+type CreateDietWarningParams struct {
+	MealID   int64
+	MemberID int64
+}
+
+func (q *Queries) CreateDietWarning(ctx context.Context, arg CreateDietWarningParams) error {
+	_, err := q.db.ExecContext(ctx, createDietWarning, arg.MealID, arg.MemberID)
+	return err
+}
+
+const createExpense = `-- name: CreateExpense :one
+INSERT INTO expenses (
+    meal_id, member_id, amount_cents, note
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING id, meal_id, member_id, amount_cents, note, created_at
+`
+
+type CreateExpenseParams struct {
+	MealID      int64
+	MemberID    int64
+	AmountCents int64
+	Note        string
+}
+
+func (q *Queries) CreateExpense(ctx context.Context, arg CreateExpenseParams) (Expense, error) {
+	row := q.db.QueryRowContext(ctx, createExpense,
+		arg.MealID,
+		arg.MemberID,
+		arg.AmountCents,
+		arg.Note,
+	)
+	var i Expense
+	err := row.Scan(
+		&i.ID,
+		&i.MealID,
+		&i.MemberID,
+		&i.AmountCents,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createHeadcountDelivery = `-- name: CreateHeadcountDelivery :exec
+INSERT OR IGNORE INTO headcount_deliveries (
+    meal_id, slack_uid
+) VALUES (
+    ?, ?
+)
+`
+
+type CreateHeadcountDeliveryParams struct {
+	MealID   int64
+	SlackUid string
+}
+
+func (q *Queries) CreateHeadcountDelivery(ctx context.Context, arg CreateHeadcountDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, createHeadcountDelivery, arg.MealID, arg.SlackUid)
+	return err
+}
+
+const createLateRSVP = `-- name: CreateLateRSVP :one
+INSERT INTO late_rsvps (
+    meal_id, member_id, eating
+) VALUES (
+    ?, ?, ?
+)
+RETURNING id, meal_id, member_id, eating, created_at
+`
+
+type CreateLateRSVPParams struct {
+	MealID   int64
+	MemberID int64
+	Eating   int64
+}
+
+func (q *Queries) CreateLateRSVP(ctx context.Context, arg CreateLateRSVPParams) (LateRsvp, error) {
+	row := q.db.QueryRowContext(ctx, createLateRSVP, arg.MealID, arg.MemberID, arg.Eating)
+	var i LateRsvp
+	err := row.Scan(
+		&i.ID,
+		&i.MealID,
+		&i.MemberID,
+		&i.Eating,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createMeal = `-- name: CreateMeal :one
 INSERT INTO meals (
-    cook_slack_uid, year, month, day
+    cook_slack_uid, year, month, day, rotation_id
 ) VALUES (
-    ?, ?, ?, ?
+    ?, ?, ?, ?, ?
 )
-RETURNING id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at
+RETURNING id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at, status, closed_at, title, tags, headcount_sent_at, rsvp_deadline, rsvps_closed_at, rotation_id
 `
 
 type CreateMealParams struct {
@@ -41,6 +264,7 @@ type CreateMealParams struct {
 	Year         int64
 	Month        int64
 	Day          int64
+	RotationID   int64
 }
 
 func (q *Queries) CreateMeal(ctx context.Context, arg CreateMealParams) (Meal, error) {
@@ -49,6 +273,7 @@ func (q *Queries) CreateMeal(ctx context.Context, arg CreateMealParams) (Meal, e
 		arg.Year,
 		arg.Month,
 		arg.Day,
+		arg.RotationID,
 	)
 	var i Meal
 	err := row.Scan(
@@ -61,27 +286,60 @@ func (q *Queries) CreateMeal(ctx context.Context, arg CreateMealParams) (Meal, e
 		&i.SlackMessageID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Title,
+		&i.Tags,
+		&i.HeadcountSentAt,
+		&i.RsvpDeadline,
+		&i.RsvpsClosedAt,
+		&i.RotationID,
 	)
 	return i, err
 }
 
+const createMealCook = `-- name: CreateMealCook :exec
+INSERT INTO meal_cooks (
+    meal_id, slack_uid, position
+) VALUES (
+    ?, ?, ?
+)
+`
+
+type CreateMealCookParams struct {
+	MealID   int64
+	SlackUid string
+	Position int64
+}
+
+func (q *Queries) CreateMealCook(ctx context.Context, arg CreateMealCookParams) error {
+	_, err := q.db.ExecContext(ctx, createMealCook, arg.MealID, arg.SlackUid, arg.Position)
+	return err
+}
+
 const createMember = `-- name: CreateMember :one
 INSERT INTO members (
-    slack_uid, full_name, leader
+    slack_uid, full_name, leader, rotation_id
 ) VALUES (
-    ?, ?, ?
+    ?, ?, ?, ?
 )
-RETURNING id, slack_uid, full_name, meals_eaten, meals_cooked, leader, created_at, updated_at
+RETURNING id, slack_uid, full_name, meals_eaten, meals_cooked, leader, created_at, updated_at, rotation_id, cooking_credits
 `
 
 type CreateMemberParams struct {
-	SlackUid string
-	FullName string
-	Leader   int64
+	SlackUid   string
+	FullName   string
+	Leader     int64
+	RotationID int64
 }
 
 func (q *Queries) CreateMember(ctx context.Context, arg CreateMemberParams) (Member, error) {
-	row := q.db.QueryRowContext(ctx, createMember, arg.SlackUid, arg.FullName, arg.Leader)
+	row := q.db.QueryRowContext(ctx, createMember,
+		arg.SlackUid,
+		arg.FullName,
+		arg.Leader,
+		arg.RotationID,
+	)
 	var i Member
 	err := row.Scan(
 		&i.ID,
@@ -92,167 +350,1595 @@ func (q *Queries) CreateMember(ctx context.Context, arg CreateMemberParams) (Mem
 		&i.Leader,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RotationID,
+		&i.CookingCredits,
 	)
 	return i, err
 }
 
-const deleteMeal = `-- name: DeleteMeal :exec
-DELETE FROM meals
-WHERE id = ?
+const createPayment = `-- name: CreatePayment :one
+INSERT INTO payments (
+    from_member_id, to_member_id, amount_cents, note
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING id, from_member_id, to_member_id, amount_cents, note, created_at
 `
 
-func (q *Queries) DeleteMeal(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteMeal, id)
-	return err
+type CreatePaymentParams struct {
+	FromMemberID int64
+	ToMemberID   int64
+	AmountCents  int64
+	Note         string
 }
 
-const deleteMember = `-- name: DeleteMember :exec
-DELETE FROM members
-WHERE id = ?
+func (q *Queries) CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error) {
+	row := q.db.QueryRowContext(ctx, createPayment,
+		arg.FromMemberID,
+		arg.ToMemberID,
+		arg.AmountCents,
+		arg.Note,
+	)
+	var i Payment
+	err := row.Scan(
+		&i.ID,
+		&i.FromMemberID,
+		&i.ToMemberID,
+		&i.AmountCents,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createProcessedEvent = `-- name: CreateProcessedEvent :execrows
+INSERT INTO processed_events (
+    event_id
+) VALUES (
+    ?
+)
+ON CONFLICT (event_id) DO NOTHING
 `
 
-func (q *Queries) DeleteMember(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteMember, id)
-	return err
+func (q *Queries) CreateProcessedEvent(ctx context.Context, eventID string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createProcessedEvent, eventID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const findMealByDate = `-- name: FindMealByDate :one
-SELECT id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at FROM meals
-WHERE year = ? AND month = ? AND day = ? LIMIT 1
+const createRotation = `-- name: CreateRotation :one
+INSERT INTO rotations (
+    name, slack_channel
+) VALUES (
+    ?, ?
+)
+RETURNING id, name, slack_channel, created_at, updated_at
 `
 
-type FindMealByDateParams struct {
-	Year  int64
-	Month int64
-	Day   int64
+type CreateRotationParams struct {
+	Name         string
+	SlackChannel string
 }
 
-func (q *Queries) FindMealByDate(ctx context.Context, arg FindMealByDateParams) (Meal, error) {
-	row := q.db.QueryRowContext(ctx, findMealByDate, arg.Year, arg.Month, arg.Day)
-	var i Meal
+func (q *Queries) CreateRotation(ctx context.Context, arg CreateRotationParams) (Rotation, error) {
+	row := q.db.QueryRowContext(ctx, createRotation, arg.Name, arg.SlackChannel)
+	var i Rotation
 	err := row.Scan(
 		&i.ID,
-		&i.CookSlackUid,
-		&i.Year,
-		&i.Month,
-		&i.Day,
-		&i.Description,
-		&i.SlackMessageID,
+		&i.Name,
+		&i.SlackChannel,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const findMealByID = `-- name: FindMealByID :one
-SELECT id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at FROM meals
-WHERE id = ? LIMIT 1
+const createSeason = `-- name: CreateSeason :one
+INSERT INTO seasons (
+    rotation_id, name, from_date, to_date
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING id, rotation_id, name, from_date, to_date, closed_at
+`
+
+type CreateSeasonParams struct {
+	RotationID int64
+	Name       string
+	FromDate   string
+	ToDate     string
+}
+
+func (q *Queries) CreateSeason(ctx context.Context, arg CreateSeasonParams) (Season, error) {
+	row := q.db.QueryRowContext(ctx, createSeason,
+		arg.RotationID,
+		arg.Name,
+		arg.FromDate,
+		arg.ToDate,
+	)
+	var i Season
+	err := row.Scan(
+		&i.ID,
+		&i.RotationID,
+		&i.Name,
+		&i.FromDate,
+		&i.ToDate,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const createSeasonStanding = `-- name: CreateSeasonStanding :exec
+INSERT INTO season_standings (
+    season_id, member_id, meals_eaten, meals_cooked, cooking_credits
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+`
+
+type CreateSeasonStandingParams struct {
+	SeasonID       int64
+	MemberID       int64
+	MealsEaten     int64
+	MealsCooked    float64
+	CookingCredits float64
+}
+
+func (q *Queries) CreateSeasonStanding(ctx context.Context, arg CreateSeasonStandingParams) error {
+	_, err := q.db.ExecContext(ctx, createSeasonStanding,
+		arg.SeasonID,
+		arg.MemberID,
+		arg.MealsEaten,
+		arg.MealsCooked,
+		arg.CookingCredits,
+	)
+	return err
+}
+
+const createToken = `-- name: CreateToken :one
+INSERT INTO tokens (
+    member_id, name, scope, hash
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING id, member_id, name, scope, hash, created_at, revoked_at
+`
+
+type CreateTokenParams struct {
+	MemberID int64
+	Name     string
+	Scope    string
+	Hash     string
+}
+
+func (q *Queries) CreateToken(ctx context.Context, arg CreateTokenParams) (Token, error) {
+	row := q.db.QueryRowContext(ctx, createToken,
+		arg.MemberID,
+		arg.Name,
+		arg.Scope,
+		arg.Hash,
+	)
+	var i Token
+	err := row.Scan(
+		&i.ID,
+		&i.MemberID,
+		&i.Name,
+		&i.Scope,
+		&i.Hash,
+		&i.CreatedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const creditMemberMealCookedBySlackUID = `-- name: CreditMemberMealCookedBySlackUID :execrows
+UPDATE members
+set meals_cooked = meals_cooked + ?, cooking_credits = cooking_credits + ?, updated_at = datetime('now')
+WHERE slack_uid = ? AND rotation_id = ?
+`
+
+type CreditMemberMealCookedBySlackUIDParams struct {
+	MealsCooked    float64
+	CookingCredits float64
+	SlackUid       string
+	RotationID     int64
+}
+
+func (q *Queries) CreditMemberMealCookedBySlackUID(ctx context.Context, arg CreditMemberMealCookedBySlackUIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, creditMemberMealCookedBySlackUID, arg.MealsCooked, arg.CookingCredits, arg.SlackUid, arg.RotationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const decrementMemberMealsEaten = `-- name: DecrementMemberMealsEaten :execrows
+UPDATE members
+set meals_eaten = meals_eaten - 1, updated_at = datetime('now')
+WHERE id = ? AND meals_eaten > 0
+`
+
+func (q *Queries) DecrementMemberMealsEaten(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, decrementMemberMealsEaten, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteAttendance = `-- name: DeleteAttendance :execrows
+DELETE FROM attendances
+WHERE meal_id = ? AND member_id = ?
+`
+
+type DeleteAttendanceParams struct {
+	MealID   int64
+	MemberID int64
+}
+
+func (q *Queries) DeleteAttendance(ctx context.Context, arg DeleteAttendanceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAttendance, arg.MealID, arg.MemberID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteAttendancesByMeal = `-- name: DeleteAttendancesByMeal :exec
+DELETE FROM attendances
+WHERE meal_id = ?
+`
+
+func (q *Queries) DeleteAttendancesByMeal(ctx context.Context, mealID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteAttendancesByMeal, mealID)
+	return err
+}
+
+const deleteAvailability = `-- name: DeleteAvailability :execrows
+DELETE FROM availabilities
+WHERE id = ?
+`
+
+func (q *Queries) DeleteAvailability(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAvailability, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteDietWarningsByMeal = `-- name: DeleteDietWarningsByMeal :exec
+DELETE FROM diet_warnings
+WHERE meal_id = ?
+`
+
+func (q *Queries) DeleteDietWarningsByMeal(ctx context.Context, mealID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteDietWarningsByMeal, mealID)
+	return err
+}
+
+const deleteExpense = `-- name: DeleteExpense :execrows
+DELETE FROM expenses
+WHERE id = ?
+`
+
+func (q *Queries) DeleteExpense(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpense, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGuests = `-- name: DeleteGuests :exec
+DELETE FROM guests
+WHERE meal_id = ? AND member_id = ?
+`
+
+type DeleteGuestsParams struct {
+	MealID   int64
+	MemberID int64
+}
+
+func (q *Queries) DeleteGuests(ctx context.Context, arg DeleteGuestsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGuests, arg.MealID, arg.MemberID)
+	return err
+}
+
+const deleteGuestsByMeal = `-- name: DeleteGuestsByMeal :exec
+DELETE FROM guests
+WHERE meal_id = ?
+`
+
+func (q *Queries) DeleteGuestsByMeal(ctx context.Context, mealID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteGuestsByMeal, mealID)
+	return err
+}
+
+const deleteHeadcountDeliveriesByMeal = `-- name: DeleteHeadcountDeliveriesByMeal :exec
+DELETE FROM headcount_deliveries
+WHERE meal_id = ?
+`
+
+func (q *Queries) DeleteHeadcountDeliveriesByMeal(ctx context.Context, mealID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteHeadcountDeliveriesByMeal, mealID)
+	return err
+}
+
+const deleteLateRSVPsByMeal = `-- name: DeleteLateRSVPsByMeal :exec
+DELETE FROM late_rsvps
+WHERE meal_id = ?
+`
+
+func (q *Queries) DeleteLateRSVPsByMeal(ctx context.Context, mealID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteLateRSVPsByMeal, mealID)
+	return err
+}
+
+const deleteMeal = `-- name: DeleteMeal :exec
+DELETE FROM meals
+WHERE id = ?
+`
+
+func (q *Queries) DeleteMeal(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMeal, id)
+	return err
+}
+
+const deleteMealCooks = `-- name: DeleteMealCooks :exec
+DELETE FROM meal_cooks
+WHERE meal_id = ?
+`
+
+func (q *Queries) DeleteMealCooks(ctx context.Context, mealID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMealCooks, mealID)
+	return err
+}
+
+const deleteMember = `-- name: DeleteMember :exec
+DELETE FROM members
+WHERE id = ?
+`
+
+func (q *Queries) DeleteMember(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMember, id)
+	return err
+}
+
+const deleteProcessedEvent = `-- name: DeleteProcessedEvent :exec
+DELETE FROM processed_events
+WHERE event_id = ?
+`
+
+func (q *Queries) DeleteProcessedEvent(ctx context.Context, eventID string) error {
+	_, err := q.db.ExecContext(ctx, deleteProcessedEvent, eventID)
+	return err
+}
+
+const deleteProcessedEventsBefore = `-- name: DeleteProcessedEventsBefore :execrows
+DELETE FROM processed_events
+WHERE processed_at < ?
+`
+
+func (q *Queries) DeleteProcessedEventsBefore(ctx context.Context, processedAt string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteProcessedEventsBefore, processedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const findAttendance = `-- name: FindAttendance :one
+SELECT id, meal_id, member_id, source, created_at FROM attendances
+WHERE meal_id = ? AND member_id = ? LIMIT 1
+`
+
+type FindAttendanceParams struct {
+	MealID   int64
+	MemberID int64
+}
+
+func (q *Queries) FindAttendance(ctx context.Context, arg FindAttendanceParams) (Attendance, error) {
+	row := q.db.QueryRowContext(ctx, findAttendance, arg.MealID, arg.MemberID)
+	var i Attendance
+	err := row.Scan(
+		&i.ID,
+		&i.MealID,
+		&i.MemberID,
+		&i.Source,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const findDietaryProfile = `-- name: FindDietaryProfile :one
+SELECT member_id, restrictions, allergies, updated_at FROM dietary_profiles
+WHERE member_id = ? LIMIT 1
+`
+
+func (q *Queries) FindDietaryProfile(ctx context.Context, memberID int64) (DietaryProfile, error) {
+	row := q.db.QueryRowContext(ctx, findDietaryProfile, memberID)
+	var i DietaryProfile
+	err := row.Scan(
+		&i.MemberID,
+		&i.Restrictions,
+		&i.Allergies,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findExpenseByID = `-- name: FindExpenseByID :one
+SELECT expenses.id, expenses.meal_id, expenses.member_id, expenses.amount_cents, expenses.note, expenses.created_at FROM expenses
+JOIN meals ON meals.id = expenses.meal_id
+WHERE expenses.id = ? AND meals.rotation_id = ? LIMIT 1
+`
+
+type FindExpenseByIDParams struct {
+	ID         int64
+	RotationID int64
+}
+
+func (q *Queries) FindExpenseByID(ctx context.Context, arg FindExpenseByIDParams) (Expense, error) {
+	row := q.db.QueryRowContext(ctx, findExpenseByID, arg.ID, arg.RotationID)
+	var i Expense
+	err := row.Scan(
+		&i.ID,
+		&i.MealID,
+		&i.MemberID,
+		&i.AmountCents,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const findJobRun = `-- name: FindJobRun :one
+SELECT name, last_run_at, last_success_at, last_error FROM job_runs
+WHERE name = ? LIMIT 1
+`
+
+func (q *Queries) FindJobRun(ctx context.Context, name string) (JobRun, error) {
+	row := q.db.QueryRowContext(ctx, findJobRun, name)
+	var i JobRun
+	err := row.Scan(
+		&i.Name,
+		&i.LastRunAt,
+		&i.LastSuccessAt,
+		&i.LastError,
+	)
+	return i, err
+}
+
+const findLatestSeason = `-- name: FindLatestSeason :one
+SELECT id, rotation_id, name, from_date, to_date, closed_at FROM seasons
+WHERE rotation_id = ?
+ORDER BY to_date DESC, id DESC LIMIT 1
+`
+
+func (q *Queries) FindLatestSeason(ctx context.Context, rotationID int64) (Season, error) {
+	row := q.db.QueryRowContext(ctx, findLatestSeason, rotationID)
+	var i Season
+	err := row.Scan(
+		&i.ID,
+		&i.RotationID,
+		&i.Name,
+		&i.FromDate,
+		&i.ToDate,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const findMealByDate = `-- name: FindMealByDate :one
+SELECT id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at, status, closed_at, title, tags, headcount_sent_at, rsvp_deadline, rsvps_closed_at, rotation_id FROM meals
+WHERE year = ? AND month = ? AND day = ? AND rotation_id = ? LIMIT 1
+`
+
+type FindMealByDateParams struct {
+	Year       int64
+	Month      int64
+	Day        int64
+	RotationID int64
+}
+
+func (q *Queries) FindMealByDate(ctx context.Context, arg FindMealByDateParams) (Meal, error) {
+	row := q.db.QueryRowContext(ctx, findMealByDate,
+		arg.Year,
+		arg.Month,
+		arg.Day,
+		arg.RotationID,
+	)
+	var i Meal
+	err := row.Scan(
+		&i.ID,
+		&i.CookSlackUid,
+		&i.Year,
+		&i.Month,
+		&i.Day,
+		&i.Description,
+		&i.SlackMessageID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Title,
+		&i.Tags,
+		&i.HeadcountSentAt,
+		&i.RsvpDeadline,
+		&i.RsvpsClosedAt,
+		&i.RotationID,
+	)
+	return i, err
+}
+
+const findMealByID = `-- name: FindMealByID :one
+SELECT id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at, status, closed_at, title, tags, headcount_sent_at, rsvp_deadline, rsvps_closed_at, rotation_id FROM meals
+WHERE id = ? AND rotation_id = ? LIMIT 1
+`
+
+type FindMealByIDParams struct {
+	ID         int64
+	RotationID int64
+}
+
+func (q *Queries) FindMealByID(ctx context.Context, arg FindMealByIDParams) (Meal, error) {
+	row := q.db.QueryRowContext(ctx, findMealByID, arg.ID, arg.RotationID)
+	var i Meal
+	err := row.Scan(
+		&i.ID,
+		&i.CookSlackUid,
+		&i.Year,
+		&i.Month,
+		&i.Day,
+		&i.Description,
+		&i.SlackMessageID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Title,
+		&i.Tags,
+		&i.HeadcountSentAt,
+		&i.RsvpDeadline,
+		&i.RsvpsClosedAt,
+		&i.RotationID,
+	)
+	return i, err
+}
+
+const findMealBySlackMessageID = `-- name: FindMealBySlackMessageID :one
+SELECT id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at, status, closed_at, title, tags, headcount_sent_at, rsvp_deadline, rsvps_closed_at, rotation_id FROM meals
+WHERE slack_message_id = ? AND rotation_id = ? LIMIT 1
+`
+
+type FindMealBySlackMessageIDParams struct {
+	SlackMessageID sql.NullString
+	RotationID     int64
+}
+
+func (q *Queries) FindMealBySlackMessageID(ctx context.Context, arg FindMealBySlackMessageIDParams) (Meal, error) {
+	row := q.db.QueryRowContext(ctx, findMealBySlackMessageID, arg.SlackMessageID, arg.RotationID)
+	var i Meal
+	err := row.Scan(
+		&i.ID,
+		&i.CookSlackUid,
+		&i.Year,
+		&i.Month,
+		&i.Day,
+		&i.Description,
+		&i.SlackMessageID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.ClosedAt,
+		&i.Title,
+		&i.Tags,
+		&i.HeadcountSentAt,
+		&i.RsvpDeadline,
+		&i.RsvpsClosedAt,
+		&i.RotationID,
+	)
+	return i, err
+}
+
+const findMemberByID = `-- name: FindMemberByID :one
+SELECT id, slack_uid, full_name, meals_eaten, meals_cooked, leader, created_at, updated_at, rotation_id, cooking_credits FROM members
+WHERE id = ? AND rotation_id = ? LIMIT 1
+`
+
+type FindMemberByIDParams struct {
+	ID         int64
+	RotationID int64
+}
+
+func (q *Queries) FindMemberByID(ctx context.Context, arg FindMemberByIDParams) (Member, error) {
+	row := q.db.QueryRowContext(ctx, findMemberByID, arg.ID, arg.RotationID)
+	var i Member
+	err := row.Scan(
+		&i.ID,
+		&i.SlackUid,
+		&i.FullName,
+		&i.MealsEaten,
+		&i.MealsCooked,
+		&i.Leader,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RotationID,
+		&i.CookingCredits,
+	)
+	return i, err
+}
+
+const findMemberBySlackUID = `-- name: FindMemberBySlackUID :one
+SELECT id, slack_uid, full_name, meals_eaten, meals_cooked, leader, created_at, updated_at, rotation_id, cooking_credits FROM members
+WHERE slack_uid = ? AND rotation_id = ? LIMIT 1
+`
+
+type FindMemberBySlackUIDParams struct {
+	SlackUid   string
+	RotationID int64
+}
+
+func (q *Queries) FindMemberBySlackUID(ctx context.Context, arg FindMemberBySlackUIDParams) (Member, error) {
+	row := q.db.QueryRowContext(ctx, findMemberBySlackUID, arg.SlackUid, arg.RotationID)
+	var i Member
+	err := row.Scan(
+		&i.ID,
+		&i.SlackUid,
+		&i.FullName,
+		&i.MealsEaten,
+		&i.MealsCooked,
+		&i.Leader,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RotationID,
+		&i.CookingCredits,
+	)
+	return i, err
+}
+
+const findRotationByID = `-- name: FindRotationByID :one
+SELECT id, name, slack_channel, created_at, updated_at FROM rotations
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) FindRotationByID(ctx context.Context, id int64) (Rotation, error) {
+	row := q.db.QueryRowContext(ctx, findRotationByID, id)
+	var i Rotation
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.SlackChannel,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findRotationByName = `-- name: FindRotationByName :one
+SELECT id, name, slack_channel, created_at, updated_at FROM rotations
+WHERE name = ? LIMIT 1
+`
+
+func (q *Queries) FindRotationByName(ctx context.Context, name string) (Rotation, error) {
+	row := q.db.QueryRowContext(ctx, findRotationByName, name)
+	var i Rotation
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.SlackChannel,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findSeasonByID = `-- name: FindSeasonByID :one
+SELECT id, rotation_id, name, from_date, to_date, closed_at FROM seasons
+WHERE id = ? AND rotation_id = ? LIMIT 1
+`
+
+type FindSeasonByIDParams struct {
+	ID         int64
+	RotationID int64
+}
+
+func (q *Queries) FindSeasonByID(ctx context.Context, arg FindSeasonByIDParams) (Season, error) {
+	row := q.db.QueryRowContext(ctx, findSeasonByID, arg.ID, arg.RotationID)
+	var i Season
+	err := row.Scan(
+		&i.ID,
+		&i.RotationID,
+		&i.Name,
+		&i.FromDate,
+		&i.ToDate,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const findTokenByHash = `-- name: FindTokenByHash :one
+SELECT id, member_id, name, scope, hash, created_at, revoked_at FROM tokens
+WHERE hash = ? AND revoked_at IS NULL LIMIT 1
+`
+
+func (q *Queries) FindTokenByHash(ctx context.Context, hash string) (Token, error) {
+	row := q.db.QueryRowContext(ctx, findTokenByHash, hash)
+	var i Token
+	err := row.Scan(
+		&i.ID,
+		&i.MemberID,
+		&i.Name,
+		&i.Scope,
+		&i.Hash,
+		&i.CreatedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const incrementMemberMealsEaten = `-- name: IncrementMemberMealsEaten :exec
+UPDATE members
+set meals_eaten = meals_eaten + 1, updated_at = datetime('now')
+WHERE id = ?
+`
+
+func (q *Queries) IncrementMemberMealsEaten(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, incrementMemberMealsEaten, id)
+	return err
+}
+
+const listAttendancesByMeal = `-- name: ListAttendancesByMeal :many
+SELECT id, meal_id, member_id, source, created_at FROM attendances
+WHERE meal_id = ?
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListAttendancesByMeal(ctx context.Context, mealID int64) ([]Attendance, error) {
+	rows, err := q.db.QueryContext(ctx, listAttendancesByMeal, mealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attendance
+	for rows.Next() {
+		var i Attendance
+		if err := rows.Scan(
+			&i.ID,
+			&i.MealID,
+			&i.MemberID,
+			&i.Source,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttendancesByMember = `-- name: ListAttendancesByMember :many
+SELECT id, meal_id, member_id, source, created_at FROM attendances
+WHERE member_id = ?
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListAttendancesByMember(ctx context.Context, memberID int64) ([]Attendance, error) {
+	rows, err := q.db.QueryContext(ctx, listAttendancesByMember, memberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attendance
+	for rows.Next() {
+		var i Attendance
+		if err := rows.Scan(
+			&i.ID,
+			&i.MealID,
+			&i.MemberID,
+			&i.Source,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAvailabilities = `-- name: ListAvailabilities :many
+SELECT availabilities.id, availabilities.member_id, availabilities.from_date, availabilities.to_date, availabilities.cannot_cook, availabilities.not_eating, availabilities.note, availabilities.created_at FROM availabilities
+JOIN members ON members.id = availabilities.member_id
+WHERE availabilities.from_date <= ? AND availabilities.to_date >= ? AND members.rotation_id = ?
+ORDER BY availabilities.from_date ASC, availabilities.id ASC
+`
+
+type ListAvailabilitiesParams struct {
+	ToDate     string
+	FromDate   string
+	RotationID int64
+}
+
+func (q *Queries) ListAvailabilities(ctx context.Context, arg ListAvailabilitiesParams) ([]Availability, error) {
+	rows, err := q.db.QueryContext(ctx, listAvailabilities, arg.ToDate, arg.FromDate, arg.RotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Availability
+	for rows.Next() {
+		var i Availability
+		if err := rows.Scan(
+			&i.ID,
+			&i.MemberID,
+			&i.FromDate,
+			&i.ToDate,
+			&i.CannotCook,
+			&i.NotEating,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAvailabilitiesByMember = `-- name: ListAvailabilitiesByMember :many
+SELECT id, member_id, from_date, to_date, cannot_cook, not_eating, note, created_at FROM availabilities
+WHERE member_id = ?
+ORDER BY from_date ASC, id ASC
+`
+
+func (q *Queries) ListAvailabilitiesByMember(ctx context.Context, memberID int64) ([]Availability, error) {
+	rows, err := q.db.QueryContext(ctx, listAvailabilitiesByMember, memberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Availability
+	for rows.Next() {
+		var i Availability
+		if err := rows.Scan(
+			&i.ID,
+			&i.MemberID,
+			&i.FromDate,
+			&i.ToDate,
+			&i.CannotCook,
+			&i.NotEating,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDietaryProfiles = `-- name: ListDietaryProfiles :many
+SELECT dietary_profiles.member_id, dietary_profiles.restrictions, dietary_profiles.allergies, dietary_profiles.updated_at FROM dietary_profiles
+JOIN members ON members.id = dietary_profiles.member_id
+WHERE members.rotation_id = ?
+ORDER BY dietary_profiles.member_id
+`
+
+func (q *Queries) ListDietaryProfiles(ctx context.Context, rotationID int64) ([]DietaryProfile, error) {
+	rows, err := q.db.QueryContext(ctx, listDietaryProfiles, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DietaryProfile
+	for rows.Next() {
+		var i DietaryProfile
+		if err := rows.Scan(
+			&i.MemberID,
+			&i.Restrictions,
+			&i.Allergies,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDietWarningsByMeal = `-- name: ListDietWarningsByMeal :many
+SELECT member_id FROM diet_warnings
+WHERE meal_id = ?
+ORDER BY member_id ASC
+`
+
+func (q *Queries) ListDietWarningsByMeal(ctx context.Context, mealID int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listDietWarningsByMeal, mealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var member_id int64
+		if err := rows.Scan(&member_id); err != nil {
+			return nil, err
+		}
+		items = append(items, member_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpenses = `-- name: ListExpenses :many
+SELECT expenses.id, expenses.meal_id, expenses.member_id, expenses.amount_cents, expenses.note, expenses.created_at FROM expenses
+JOIN meals ON meals.id = expenses.meal_id
+WHERE meals.rotation_id = ?
+ORDER BY expenses.id ASC
+`
+
+func (q *Queries) ListExpenses(ctx context.Context, rotationID int64) ([]Expense, error) {
+	rows, err := q.db.QueryContext(ctx, listExpenses, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Expense
+	for rows.Next() {
+		var i Expense
+		if err := rows.Scan(
+			&i.ID,
+			&i.MealID,
+			&i.MemberID,
+			&i.AmountCents,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpensesByMeal = `-- name: ListExpensesByMeal :many
+SELECT id, meal_id, member_id, amount_cents, note, created_at FROM expenses
+WHERE meal_id = ?
+ORDER BY id ASC
+`
+
+func (q *Queries) ListExpensesByMeal(ctx context.Context, mealID int64) ([]Expense, error) {
+	rows, err := q.db.QueryContext(ctx, listExpensesByMeal, mealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Expense
+	for rows.Next() {
+		var i Expense
+		if err := rows.Scan(
+			&i.ID,
+			&i.MealID,
+			&i.MemberID,
+			&i.AmountCents,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGuestsByMeal = `-- name: ListGuestsByMeal :many
+SELECT meal_id, member_id, count, updated_at FROM guests
+WHERE meal_id = ?
+ORDER BY member_id ASC
+`
+
+func (q *Queries) ListGuestsByMeal(ctx context.Context, mealID int64) ([]Guest, error) {
+	rows, err := q.db.QueryContext(ctx, listGuestsByMeal, mealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Guest
+	for rows.Next() {
+		var i Guest
+		if err := rows.Scan(
+			&i.MealID,
+			&i.MemberID,
+			&i.Count,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGuestsByMember = `-- name: ListGuestsByMember :many
+SELECT meal_id, member_id, count, updated_at FROM guests
+WHERE member_id = ?
+ORDER BY meal_id ASC
+`
+
+func (q *Queries) ListGuestsByMember(ctx context.Context, memberID int64) ([]Guest, error) {
+	rows, err := q.db.QueryContext(ctx, listGuestsByMember, memberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Guest
+	for rows.Next() {
+		var i Guest
+		if err := rows.Scan(
+			&i.MealID,
+			&i.MemberID,
+			&i.Count,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHeadcountDeliveriesByMeal = `-- name: ListHeadcountDeliveriesByMeal :many
+SELECT slack_uid FROM headcount_deliveries
+WHERE meal_id = ?
+ORDER BY slack_uid ASC
+`
+
+func (q *Queries) ListHeadcountDeliveriesByMeal(ctx context.Context, mealID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listHeadcountDeliveriesByMeal, mealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var slack_uid string
+		if err := rows.Scan(&slack_uid); err != nil {
+			return nil, err
+		}
+		items = append(items, slack_uid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLateRSVPsByMeal = `-- name: ListLateRSVPsByMeal :many
+SELECT id, meal_id, member_id, eating, created_at FROM late_rsvps
+WHERE meal_id = ?
+ORDER BY id ASC
+`
+
+func (q *Queries) ListLateRSVPsByMeal(ctx context.Context, mealID int64) ([]LateRsvp, error) {
+	rows, err := q.db.QueryContext(ctx, listLateRSVPsByMeal, mealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LateRsvp
+	for rows.Next() {
+		var i LateRsvp
+		if err := rows.Scan(
+			&i.ID,
+			&i.MealID,
+			&i.MemberID,
+			&i.Eating,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMealCooks = `-- name: ListMealCooks :many
+SELECT slack_uid FROM meal_cooks
+WHERE meal_id = ?
+ORDER BY position ASC
+`
+
+func (q *Queries) ListMealCooks(ctx context.Context, mealID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listMealCooks, mealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var slack_uid string
+		if err := rows.Scan(&slack_uid); err != nil {
+			return nil, err
+		}
+		items = append(items, slack_uid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMeals = `-- name: ListMeals :many
+SELECT id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at, status, closed_at, title, tags, headcount_sent_at, rsvp_deadline, rsvps_closed_at, rotation_id FROM meals
+WHERE (year * 10000 + month * 100 + day) BETWEEN ? AND ? AND rotation_id = ?
+ORDER BY year ASC, month ASC, day ASC
+`
+
+type ListMealsParams struct {
+	FromDate   int64
+	ToDate     int64
+	RotationID int64
+}
+
+func (q *Queries) ListMeals(ctx context.Context, arg ListMealsParams) ([]Meal, error) {
+	rows, err := q.db.QueryContext(ctx, listMeals, arg.FromDate, arg.ToDate, arg.RotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Meal
+	for rows.Next() {
+		var i Meal
+		if err := rows.Scan(
+			&i.ID,
+			&i.CookSlackUid,
+			&i.Year,
+			&i.Month,
+			&i.Day,
+			&i.Description,
+			&i.SlackMessageID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.ClosedAt,
+			&i.Title,
+			&i.Tags,
+			&i.HeadcountSentAt,
+			&i.RsvpDeadline,
+			&i.RsvpsClosedAt,
+			&i.RotationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMemberAttendanceCounts = `-- name: ListMemberAttendanceCounts :many
+SELECT members.id, members.slack_uid, members.full_name, members.rotation_id, members.meals_eaten,
+    CAST((SELECT count(*) FROM attendances
+        WHERE attendances.member_id = members.id
+        AND attendances.created_at > COALESCE((SELECT max(seasons.closed_at) FROM seasons WHERE seasons.rotation_id = members.rotation_id), '')
+    ) AS INTEGER) AS attendances
+FROM members
+ORDER BY members.rotation_id, members.id
+`
+
+type ListMemberAttendanceCountsRow struct {
+	ID          int64
+	SlackUid    string
+	FullName    string
+	RotationID  int64
+	MealsEaten  int64
+	Attendances int64
+}
+
+func (q *Queries) ListMemberAttendanceCounts(ctx context.Context) ([]ListMemberAttendanceCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMemberAttendanceCounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMemberAttendanceCountsRow
+	for rows.Next() {
+		var i ListMemberAttendanceCountsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SlackUid,
+			&i.FullName,
+			&i.RotationID,
+			&i.MealsEaten,
+			&i.Attendances,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMembers = `-- name: ListMembers :many
+SELECT id, slack_uid, full_name, meals_eaten, meals_cooked, leader, created_at, updated_at, rotation_id, cooking_credits FROM members
+WHERE rotation_id = ?
+ORDER BY meals_cooked ASC, meals_eaten DESC
+`
+
+func (q *Queries) ListMembers(ctx context.Context, rotationID int64) ([]Member, error) {
+	rows, err := q.db.QueryContext(ctx, listMembers, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Member
+	for rows.Next() {
+		var i Member
+		if err := rows.Scan(
+			&i.ID,
+			&i.SlackUid,
+			&i.FullName,
+			&i.MealsEaten,
+			&i.MealsCooked,
+			&i.Leader,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RotationID,
+			&i.CookingCredits,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPayments = `-- name: ListPayments :many
+SELECT payments.id, payments.from_member_id, payments.to_member_id, payments.amount_cents, payments.note, payments.created_at FROM payments
+JOIN members ON members.id = payments.from_member_id
+WHERE members.rotation_id = ?
+ORDER BY payments.id ASC
+`
+
+func (q *Queries) ListPayments(ctx context.Context, rotationID int64) ([]Payment, error) {
+	rows, err := q.db.QueryContext(ctx, listPayments, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Payment
+	for rows.Next() {
+		var i Payment
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromMemberID,
+			&i.ToMemberID,
+			&i.AmountCents,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRotations = `-- name: ListRotations :many
+SELECT id, name, slack_channel, created_at, updated_at FROM rotations
+ORDER BY id ASC
+`
+
+func (q *Queries) ListRotations(ctx context.Context) ([]Rotation, error) {
+	rows, err := q.db.QueryContext(ctx, listRotations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Rotation
+	for rows.Next() {
+		var i Rotation
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.SlackChannel,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledMealsBefore = `-- name: ListScheduledMealsBefore :many
+SELECT id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at, status, closed_at, title, tags, headcount_sent_at, rsvp_deadline, rsvps_closed_at, rotation_id FROM meals
+WHERE (year * 10000 + month * 100 + day) < ? AND status = ? AND rotation_id = ?
+ORDER BY year ASC, month ASC, day ASC
 `
 
-func (q *Queries) FindMealByID(ctx context.Context, id int64) (Meal, error) {
-	row := q.db.QueryRowContext(ctx, findMealByID, id)
-	var i Meal
-	err := row.Scan(
-		&i.ID,
-		&i.CookSlackUid,
-		&i.Year,
-		&i.Month,
-		&i.Day,
-		&i.Description,
-		&i.SlackMessageID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type ListScheduledMealsBeforeParams struct {
+	BeforeDate int64
+	Status     string
+	RotationID int64
 }
 
-const findMealBySlackMessageID = `-- name: FindMealBySlackMessageID :one
-SELECT id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at FROM meals
-WHERE slack_message_id = ? LIMIT 1
+func (q *Queries) ListScheduledMealsBefore(ctx context.Context, arg ListScheduledMealsBeforeParams) ([]Meal, error) {
+	rows, err := q.db.QueryContext(ctx, listScheduledMealsBefore, arg.BeforeDate, arg.Status, arg.RotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Meal
+	for rows.Next() {
+		var i Meal
+		if err := rows.Scan(
+			&i.ID,
+			&i.CookSlackUid,
+			&i.Year,
+			&i.Month,
+			&i.Day,
+			&i.Description,
+			&i.SlackMessageID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.ClosedAt,
+			&i.Title,
+			&i.Tags,
+			&i.HeadcountSentAt,
+			&i.RsvpDeadline,
+			&i.RsvpsClosedAt,
+			&i.RotationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeasons = `-- name: ListSeasons :many
+SELECT id, rotation_id, name, from_date, to_date, closed_at FROM seasons
+WHERE rotation_id = ?
+ORDER BY to_date ASC, id ASC
 `
 
-func (q *Queries) FindMealBySlackMessageID(ctx context.Context, slackMessageID sql.NullString) (Meal, error) {
-	row := q.db.QueryRowContext(ctx, findMealBySlackMessageID, slackMessageID)
-	var i Meal
-	err := row.Scan(
-		&i.ID,
-		&i.CookSlackUid,
-		&i.Year,
-		&i.Month,
-		&i.Day,
-		&i.Description,
-		&i.SlackMessageID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) ListSeasons(ctx context.Context, rotationID int64) ([]Season, error) {
+	rows, err := q.db.QueryContext(ctx, listSeasons, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Season
+	for rows.Next() {
+		var i Season
+		if err := rows.Scan(
+			&i.ID,
+			&i.RotationID,
+			&i.Name,
+			&i.FromDate,
+			&i.ToDate,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const findMemberByID = `-- name: FindMemberByID :one
-SELECT id, slack_uid, full_name, meals_eaten, meals_cooked, leader, created_at, updated_at FROM members
-WHERE id = ? LIMIT 1
+const listSeasonStandings = `-- name: ListSeasonStandings :many
+SELECT season_id, member_id, meals_eaten, meals_cooked, cooking_credits FROM season_standings
+WHERE season_id = ?
+ORDER BY member_id ASC
 `
 
-func (q *Queries) FindMemberByID(ctx context.Context, id int64) (Member, error) {
-	row := q.db.QueryRowContext(ctx, findMemberByID, id)
-	var i Member
-	err := row.Scan(
-		&i.ID,
-		&i.SlackUid,
-		&i.FullName,
-		&i.MealsEaten,
-		&i.MealsCooked,
-		&i.Leader,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) ListSeasonStandings(ctx context.Context, seasonID int64) ([]SeasonStanding, error) {
+	rows, err := q.db.QueryContext(ctx, listSeasonStandings, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SeasonStanding
+	for rows.Next() {
+		var i SeasonStanding
+		if err := rows.Scan(
+			&i.SeasonID,
+			&i.MemberID,
+			&i.MealsEaten,
+			&i.MealsCooked,
+			&i.CookingCredits,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const findMemberBySlackUID = `-- name: FindMemberBySlackUID :one
-SELECT id, slack_uid, full_name, meals_eaten, meals_cooked, leader, created_at, updated_at FROM members
-WHERE slack_uid = ? LIMIT 1
+const listSeasonStandingsByRotation = `-- name: ListSeasonStandingsByRotation :many
+SELECT season_standings.season_id, season_standings.member_id, season_standings.meals_eaten, season_standings.meals_cooked, season_standings.cooking_credits FROM season_standings
+JOIN seasons ON seasons.id = season_standings.season_id
+WHERE seasons.rotation_id = ?
+ORDER BY season_standings.season_id ASC, season_standings.member_id ASC
 `
 
-func (q *Queries) FindMemberBySlackUID(ctx context.Context, slackUid string) (Member, error) {
-	row := q.db.QueryRowContext(ctx, findMemberBySlackUID, slackUid)
-	var i Member
-	err := row.Scan(
-		&i.ID,
-		&i.SlackUid,
-		&i.FullName,
-		&i.MealsEaten,
-		&i.MealsCooked,
-		&i.Leader,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) ListSeasonStandingsByRotation(ctx context.Context, rotationID int64) ([]SeasonStanding, error) {
+	rows, err := q.db.QueryContext(ctx, listSeasonStandingsByRotation, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SeasonStanding
+	for rows.Next() {
+		var i SeasonStanding
+		if err := rows.Scan(
+			&i.SeasonID,
+			&i.MemberID,
+			&i.MealsEaten,
+			&i.MealsCooked,
+			&i.CookingCredits,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const listMembers = `-- name: ListMembers :many
-SELECT id, slack_uid, full_name, meals_eaten, meals_cooked, leader, created_at, updated_at FROM members
-ORDER BY meals_cooked ASC, meals_eaten DESC
+const listTokens = `-- name: ListTokens :many
+SELECT tokens.id, tokens.member_id, tokens.name, tokens.scope, tokens.hash, tokens.created_at, tokens.revoked_at FROM tokens
+JOIN members ON members.id = tokens.member_id
+WHERE members.rotation_id = ?
+ORDER BY tokens.id ASC
 `
 
-func (q *Queries) ListMembers(ctx context.Context) ([]Member, error) {
-	rows, err := q.db.QueryContext(ctx, listMembers)
+func (q *Queries) ListTokens(ctx context.Context, rotationID int64) ([]Token, error) {
+	rows, err := q.db.QueryContext(ctx, listTokens, rotationID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Member
+	var items []Token
 	for rows.Next() {
-		var i Member
+		var i Token
 		if err := rows.Scan(
 			&i.ID,
-			&i.SlackUid,
-			&i.FullName,
-			&i.MealsEaten,
-			&i.MealsCooked,
-			&i.Leader,
+			&i.MemberID,
+			&i.Name,
+			&i.Scope,
+			&i.Hash,
 			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.RevokedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -267,6 +1953,65 @@ func (q *Queries) ListMembers(ctx context.Context) ([]Member, error) {
 	return items, nil
 }
 
+const markMealHeadcountSent = `-- name: MarkMealHeadcountSent :execrows
+UPDATE meals
+set headcount_sent_at = datetime('now'), updated_at = datetime('now')
+WHERE id = ? AND headcount_sent_at IS NULL
+`
+
+func (q *Queries) MarkMealHeadcountSent(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markMealHeadcountSent, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reopenMeal = `-- name: ReopenMeal :execrows
+UPDATE meals
+set status = 'scheduled', closed_at = NULL, slack_message_id = NULL, headcount_sent_at = NULL, rsvps_closed_at = NULL, updated_at = datetime('now')
+WHERE id = ? AND status = 'cancelled'
+`
+
+func (q *Queries) ReopenMeal(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reopenMeal, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resetMemberCounters = `-- name: ResetMemberCounters :exec
+UPDATE members
+set meals_eaten = 0, meals_cooked = 0, cooking_credits = 0, updated_at = datetime('now')
+WHERE rotation_id = ?
+`
+
+func (q *Queries) ResetMemberCounters(ctx context.Context, rotationID int64) error {
+	_, err := q.db.ExecContext(ctx, resetMemberCounters, rotationID)
+	return err
+}
+
+const revokeToken = `-- name: RevokeToken :execrows
+UPDATE tokens
+set revoked_at = datetime('now')
+WHERE id = ? AND revoked_at IS NULL
+AND member_id IN (SELECT id FROM members WHERE rotation_id = ?)
+`
+
+type RevokeTokenParams struct {
+	ID         int64
+	RotationID int64
+}
+
+func (q *Queries) RevokeToken(ctx context.Context, arg RevokeTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeToken, arg.ID, arg.RotationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateMealDescription = `-- name: UpdateMealDescription :exec
 UPDATE meals
 set description = ?, updated_at = datetime('now')
@@ -283,6 +2028,22 @@ func (q *Queries) UpdateMealDescription(ctx context.Context, arg UpdateMealDescr
 	return err
 }
 
+const updateMealRSVPDeadline = `-- name: UpdateMealRSVPDeadline :exec
+UPDATE meals
+set rsvp_deadline = ?, updated_at = datetime('now')
+WHERE id = ?
+`
+
+type UpdateMealRSVPDeadlineParams struct {
+	RsvpDeadline sql.NullString
+	ID           int64
+}
+
+func (q *Queries) UpdateMealRSVPDeadline(ctx context.Context, arg UpdateMealRSVPDeadlineParams) error {
+	_, err := q.db.ExecContext(ctx, updateMealRSVPDeadline, arg.RsvpDeadline, arg.ID)
+	return err
+}
+
 const updateMealSlackMessageID = `-- name: UpdateMealSlackMessageID :exec
 UPDATE meals
 set slack_message_id = ?, updated_at = datetime('now')
@@ -315,6 +2076,38 @@ func (q *Queries) UpdateMealSlackUID(ctx context.Context, arg UpdateMealSlackUID
 	return err
 }
 
+const updateMealTags = `-- name: UpdateMealTags :exec
+UPDATE meals
+set tags = ?, updated_at = datetime('now')
+WHERE id = ?
+`
+
+type UpdateMealTagsParams struct {
+	Tags string
+	ID   int64
+}
+
+func (q *Queries) UpdateMealTags(ctx context.Context, arg UpdateMealTagsParams) error {
+	_, err := q.db.ExecContext(ctx, updateMealTags, arg.Tags, arg.ID)
+	return err
+}
+
+const updateMealTitle = `-- name: UpdateMealTitle :exec
+UPDATE meals
+set title = ?, updated_at = datetime('now')
+WHERE id = ?
+`
+
+type UpdateMealTitleParams struct {
+	Title string
+	ID    int64
+}
+
+func (q *Queries) UpdateMealTitle(ctx context.Context, arg UpdateMealTitleParams) error {
+	_, err := q.db.ExecContext(ctx, updateMealTitle, arg.Title, arg.ID)
+	return err
+}
+
 const updateMemberLeaderStatus = `-- name: UpdateMemberLeaderStatus :exec
 UPDATE members
 set leader = ?, updated_at = datetime('now')
@@ -338,7 +2131,7 @@ WHERE id = ?
 `
 
 type UpdateMemberMealsCookedParams struct {
-	MealsCooked int64
+	MealsCooked float64
 	ID          int64
 }
 
@@ -362,3 +2155,90 @@ func (q *Queries) UpdateMemberMealsEaten(ctx context.Context, arg UpdateMemberMe
 	_, err := q.db.ExecContext(ctx, updateMemberMealsEaten, arg.MealsEaten, arg.ID)
 	return err
 }
+
+const updateRotationSlackChannel = `-- name: UpdateRotationSlackChannel :exec
+UPDATE rotations
+set slack_channel = ?, updated_at = datetime('now')
+WHERE id = ?
+`
+
+type UpdateRotationSlackChannelParams struct {
+	SlackChannel string
+	ID           int64
+}
+
+func (q *Queries) UpdateRotationSlackChannel(ctx context.Context, arg UpdateRotationSlackChannelParams) error {
+	_, err := q.db.ExecContext(ctx, updateRotationSlackChannel, arg.SlackChannel, arg.ID)
+	return err
+}
+
+const upsertDietaryProfile = `-- name: UpsertDietaryProfile :exec
+INSERT INTO dietary_profiles (
+    member_id, restrictions, allergies
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (member_id) DO UPDATE
+SET restrictions = excluded.restrictions, allergies = excluded.allergies, updated_at = datetime('now')
+`
+
+type UpsertDietaryProfileParams struct {
+	MemberID     int64
+	Restrictions string
+	Allergies    string
+}
+
+func (q *Queries) UpsertDietaryProfile(ctx context.Context, arg UpsertDietaryProfileParams) error {
+	_, err := q.db.ExecContext(ctx, upsertDietaryProfile, arg.MemberID, arg.Restrictions, arg.Allergies)
+	return err
+}
+
+const upsertGuests = `-- name: UpsertGuests :exec
+INSERT INTO guests (
+    meal_id, member_id, count
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (meal_id, member_id) DO UPDATE
+SET count = excluded.count, updated_at = datetime('now')
+`
+
+type UpsertGuestsParams struct {
+	MealID   int64
+	MemberID int64
+	Count    int64
+}
+
+func (q *Queries) UpsertGuests(ctx context.Context, arg UpsertGuestsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertGuests, arg.MealID, arg.MemberID, arg.Count)
+	return err
+}
+
+const upsertJobRun = `-- name: UpsertJobRun :exec
+INSERT INTO job_runs (
+    name, last_run_at, last_success_at, last_error
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (name) DO UPDATE SET
+    last_run_at = excluded.last_run_at,
+    last_success_at = excluded.last_success_at,
+    last_error = excluded.last_error
+`
+
+type UpsertJobRunParams struct {
+	Name          string
+	LastRunAt     string
+	LastSuccessAt sql.NullString
+	LastError     string
+}
+
+func (q *Queries) UpsertJobRun(ctx context.Context, arg UpsertJobRunParams) error {
+	_, err := q.db.ExecContext(ctx, upsertJobRun,
+		arg.Name,
+		arg.LastRunAt,
+		arg.LastSuccessAt,
+		arg.LastError,
+	)
+	return err
+}
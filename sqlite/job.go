@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.JobRunService = (*JobRunService)(nil)
+
+// JobRunService represents a service for persisting when scheduled jobs last ran.
+type JobRunService struct {
+	query *gen.Queries
+	db    *sql.DB
+}
+
+// NewJobRunService returns a new instance of JobRunService.
+func NewJobRunService(query *gen.Queries, db *sql.DB) *JobRunService {
+	return &JobRunService{query, db}
+}
+
+// FindJobRun retrieves the last run of a job by name.
+// Returns ErrNotFound if the job has never run.
+func (js *JobRunService) FindJobRun(name string) (*dinny.JobRun, error) {
+	r, err := js.query.FindJobRun(context.Background(), name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, dinny.ErrNotFound
+		} else {
+			return nil, fmt.Errorf("FindJobRun: %w", err)
+		}
+	}
+	run := &dinny.JobRun{
+		Name:      r.Name,
+		LastRunAt: parseTime(r.LastRunAt),
+		LastError: r.LastError,
+	}
+	if r.LastSuccessAt.Valid {
+		successAt := parseTime(r.LastSuccessAt.String)
+		run.LastSuccessAt = &successAt
+	}
+	return run, nil
+}
+
+// UpsertJobRun creates or replaces the last run of a job.
+func (js *JobRunService) UpsertJobRun(run *dinny.JobRun) error {
+	params := gen.UpsertJobRunParams{
+		Name:      run.Name,
+		LastRunAt: formatTime(run.LastRunAt),
+		LastError: run.LastError,
+	}
+	if run.LastSuccessAt != nil {
+		params.LastSuccessAt = sql.NullString{String: formatTime(*run.LastSuccessAt), Valid: true}
+	}
+	err := js.query.UpsertJobRun(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("UpsertJobRun: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ddritzenhoff/dinny"
@@ -13,19 +14,40 @@ import (
 // Ensure service implements interface.
 var _ dinny.MealService = (*MealService)(nil)
 
-// MemberService represents a service for managing members.
+// MealService represents a service for managing meals.
 type MealService struct {
 	query *gen.Queries
 	db    *sql.DB
+
+	// rotationID is the rotation the service is scoped to.
+	rotationID int64
+
+	// CreditFormula determines the credit a cook gets for a meal when it is completed. Defaults to one credit per meal.
+	CreditFormula dinny.CreditFormula
 }
 
-// NewMemberService returns a new instance of MemberService.
+// NewMealService returns a new instance of MealService scoped to the default rotation.
 func NewMealService(query *gen.Queries, db *sql.DB) *MealService {
-	return &MealService{query, db}
+	return &MealService{query: query, db: db, rotationID: dinny.DefaultRotationID}
+}
+
+// ForRotation returns a copy of the service scoped to the rotation with the given ID.
+func (ms *MealService) ForRotation(rotationID int64) *MealService {
+	scoped := *ms
+	scoped.rotationID = rotationID
+	return &scoped
 }
 
-// toDindinMeal converts a gen.Meal to a dinny.Meal
-func toDindinMeal(m gen.Meal) *dinny.Meal {
+// splitTags splits the comma separated tags of a meal as they are stored.
+func splitTags(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
+
+// toDindinMeal converts a gen.Meal and its cooks to a dinny.Meal
+func toDindinMeal(m gen.Meal, cooks []string) *dinny.Meal {
 	var desc string
 	var smid string
 	if m.Description.Valid {
@@ -39,7 +61,7 @@ func toDindinMeal(m gen.Meal) *dinny.Meal {
 		smid = ""
 	}
 
-	return &dinny.Meal{
+	meal := &dinny.Meal{
 		ID:           m.ID,
 		CookSlackUID: m.CookSlackUid,
 		Date: dinny.Date{
@@ -47,15 +69,98 @@ func toDindinMeal(m gen.Meal) *dinny.Meal {
 			Month: time.Month(m.Month),
 			Day:   int(m.Day),
 		},
+		Title:          m.Title,
 		Description:    desc,
+		Tags:           splitTags(m.Tags),
 		SlackMessageID: smid,
+		Status:         m.Status,
+		RotationID:     m.RotationID,
+		Cooks:          cooks,
+	}
+	if m.HeadcountSentAt.Valid {
+		sentAt := parseTime(m.HeadcountSentAt.String)
+		meal.HeadcountSentAt = &sentAt
+	}
+	if m.RsvpDeadline.Valid {
+		deadline := parseTime(m.RsvpDeadline.String)
+		meal.RSVPDeadline = &deadline
+	}
+	if m.RsvpsClosedAt.Valid {
+		closedAt := parseTime(m.RsvpsClosedAt.String)
+		meal.RSVPsClosedAt = &closedAt
+	}
+	return meal
+}
+
+// loadMeal converts a gen.Meal to a dinny.Meal along with its cooks.
+func loadMeal(q *gen.Queries, m gen.Meal) (*dinny.Meal, error) {
+	cooks, err := q.ListMealCooks(context.Background(), m.ID)
+	if err != nil {
+		return nil, fmt.Errorf("loadMeal ListMealCooks: %w", err)
+	}
+	return toDindinMeal(m, cooks), nil
+}
+
+// setMealCooks replaces the cooks of the meal with the given ID. The first cook is the lead cook.
+func setMealCooks(qtx *gen.Queries, id int64, cooks []string) error {
+	if len(cooks) == 0 {
+		return fmt.Errorf("setMealCooks: meal %d needs a cook", id)
+	}
+	params := gen.UpdateMealSlackUIDParams{
+		ID:           id,
+		CookSlackUid: cooks[0],
+	}
+	err := qtx.UpdateMealSlackUID(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("setMealCooks UpdateMealSlackUID: %w", err)
+	}
+	err = qtx.DeleteMealCooks(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("setMealCooks DeleteMealCooks: %w", err)
+	}
+	for ii, cook := range cooks {
+		arg := gen.CreateMealCookParams{
+			MealID:   id,
+			SlackUid: cook,
+			Position: int64(ii),
+		}
+		err := qtx.CreateMealCook(context.Background(), arg)
+		if err != nil {
+			return fmt.Errorf("setMealCooks CreateMealCook: %w", err)
+		}
+	}
+	return nil
+}
+
+// reopenMeal schedules a cancelled meal again. Its 'who's eating' message, headcount, late RSVPs and diet warnings are
+// reset, so it is announced again like a new meal. Meals which haven't been cancelled are left alone.
+func reopenMeal(qtx *gen.Queries, id int64) error {
+	n, err := qtx.ReopenMeal(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("reopenMeal: %w", err)
+	}
+	if n == 0 {
+		return nil
+	}
+	err = qtx.DeleteLateRSVPsByMeal(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("reopenMeal DeleteLateRSVPsByMeal: %w", err)
+	}
+	err = qtx.DeleteDietWarningsByMeal(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("reopenMeal DeleteDietWarningsByMeal: %w", err)
 	}
+	err = qtx.DeleteHeadcountDeliveriesByMeal(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("reopenMeal DeleteHeadcountDeliveriesByMeal: %w", err)
+	}
+	return nil
 }
 
 // FindMealByID retrieves a meal by ID.
 // Returns ErrNotFound if meal does not exist.
 func (ms *MealService) FindMealByID(id int64) (*dinny.Meal, error) {
-	m, err := ms.query.FindMealByID(context.Background(), id)
+	m, err := ms.query.FindMealByID(context.Background(), gen.FindMealByIDParams{ID: id, RotationID: ms.rotationID})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, dinny.ErrNotFound
@@ -63,16 +168,17 @@ func (ms *MealService) FindMealByID(id int64) (*dinny.Meal, error) {
 			return nil, fmt.Errorf("FindMealByID: %w", err)
 		}
 	}
-	return toDindinMeal(m), nil
+	return loadMeal(ms.query, m)
 }
 
 // FindMealByDate retrieves a meal by Date.
 // Returns ErrNotFound if meal does not exist.
 func (ms *MealService) FindMealByDate(date dinny.Date) (*dinny.Meal, error) {
 	params := gen.FindMealByDateParams{
-		Year:  int64(date.Year),
-		Month: int64(date.Month),
-		Day:   int64(date.Day),
+		Year:       int64(date.Year),
+		Month:      int64(date.Month),
+		Day:        int64(date.Day),
+		RotationID: ms.rotationID,
 	}
 	m, err := ms.query.FindMealByDate(context.Background(), params)
 	if err != nil {
@@ -82,17 +188,20 @@ func (ms *MealService) FindMealByDate(date dinny.Date) (*dinny.Meal, error) {
 			return nil, fmt.Errorf("FindMealByDate: %w", err)
 		}
 	}
-	return toDindinMeal(m), nil
+	return loadMeal(ms.query, m)
 }
 
 // FindMealBySlackMessageID retrieves a meal by SlackMessageID.
 // Returns ErrNotFound if meal does not exist.
 func (ms *MealService) FindMealBySlackMessageID(slackMessageID string) (*dinny.Meal, error) {
-	param := sql.NullString{
-		String: slackMessageID,
-		Valid:  true,
+	params := gen.FindMealBySlackMessageIDParams{
+		SlackMessageID: sql.NullString{
+			String: slackMessageID,
+			Valid:  true,
+		},
+		RotationID: ms.rotationID,
 	}
-	m, err := ms.query.FindMealBySlackMessageID(context.Background(), param)
+	m, err := ms.query.FindMealBySlackMessageID(context.Background(), params)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, dinny.ErrNotFound
@@ -100,29 +209,397 @@ func (ms *MealService) FindMealBySlackMessageID(slackMessageID string) (*dinny.M
 			return nil, fmt.Errorf("FindMealBySlackMessageID: %w", err)
 		}
 	}
-	return toDindinMeal(m), nil
+	return loadMeal(ms.query, m)
+}
+
+// dateKey converts a date into the YYYYMMDD integer used to query date ranges.
+func dateKey(d dinny.Date) int64 {
+	return int64(d.Year)*10000 + int64(d.Month)*100 + int64(d.Day)
+}
+
+// ListMeals retrieves the meals between from and to, inclusive, ordered by date.
+func (ms *MealService) ListMeals(from dinny.Date, to dinny.Date) ([]*dinny.Meal, error) {
+	params := gen.ListMealsParams{
+		FromDate:   dateKey(from),
+		ToDate:     dateKey(to),
+		RotationID: ms.rotationID,
+	}
+	rows, err := ms.query.ListMeals(context.Background(), params)
+	if err != nil {
+		return nil, fmt.Errorf("ListMeals: %w", err)
+	}
+	var meals []*dinny.Meal
+	for _, m := range rows {
+		meal, err := loadMeal(ms.query, m)
+		if err != nil {
+			return nil, fmt.Errorf("ListMeals: %w", err)
+		}
+		meals = append(meals, meal)
+	}
+	return meals, nil
+}
+
+// ListScheduledMealsBefore retrieves the meals before date which haven't been closed out yet, ordered by date.
+func (ms *MealService) ListScheduledMealsBefore(date dinny.Date) ([]*dinny.Meal, error) {
+	params := gen.ListScheduledMealsBeforeParams{
+		BeforeDate: dateKey(date),
+		Status:     dinny.MealStatusScheduled,
+		RotationID: ms.rotationID,
+	}
+	rows, err := ms.query.ListScheduledMealsBefore(context.Background(), params)
+	if err != nil {
+		return nil, fmt.Errorf("ListScheduledMealsBefore: %w", err)
+	}
+	var meals []*dinny.Meal
+	for _, m := range rows {
+		meal, err := loadMeal(ms.query, m)
+		if err != nil {
+			return nil, fmt.Errorf("ListScheduledMealsBefore: %w", err)
+		}
+		meals = append(meals, meal)
+	}
+	return meals, nil
 }
 
-// CreateMeal creates a new meal.
+// CreateMeal creates a new meal cooked by m.Cooks, or m.CookSlackUID alone if it has no cooks.
+// A meal which was cancelled on the same date is reopened with the new cooks instead.
 func (ms *MealService) CreateMeal(m *dinny.Meal) error {
-	arg := gen.CreateMealParams{
-		CookSlackUid: m.CookSlackUID,
-		Year:         int64(m.Date.Year),
-		Month:        int64(m.Date.Month),
-		Day:          int64(m.Date.Day),
+	tx, err := ms.db.Begin()
+	if err != nil {
+		return fmt.Errorf("CreateMeal db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ms.query.WithTx(tx)
+	cooks := m.CookSlackUIDs()
+	if len(cooks) == 0 {
+		return fmt.Errorf("CreateMeal: meal on %s needs a cook", m.Date)
+	}
+	params := gen.FindMealByDateParams{
+		Year:       int64(m.Date.Year),
+		Month:      int64(m.Date.Month),
+		Day:        int64(m.Date.Day),
+		RotationID: ms.rotationID,
+	}
+	existing, err := qtx.FindMealByDate(context.Background(), params)
+	if err == sql.ErrNoRows {
+		arg := gen.CreateMealParams{
+			CookSlackUid: cooks[0],
+			Year:         int64(m.Date.Year),
+			Month:        int64(m.Date.Month),
+			Day:          int64(m.Date.Day),
+			RotationID:   ms.rotationID,
+		}
+		existing, err = qtx.CreateMeal(context.Background(), arg)
+		if err != nil {
+			return fmt.Errorf("CreateMeal: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("CreateMeal FindMealByDate: %w", err)
+	} else if existing.Status != dinny.MealStatusCancelled {
+		return fmt.Errorf("CreateMeal: there already is a meal on %s", m.Date)
+	} else {
+		err = reopenMeal(qtx, existing.ID)
+		if err != nil {
+			return fmt.Errorf("CreateMeal: %w", err)
+		}
 	}
-	_, err := ms.query.CreateMeal(context.Background(), arg)
+	err = setMealCooks(qtx, existing.ID, cooks)
 	if err != nil {
 		return fmt.Errorf("CreateMeal: %w", err)
 	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("CreateMeal tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// AssignCook sets the sole cook of the meal on date, creating the meal if it doesn't exist yet and reopening it if it was cancelled.
+func (ms *MealService) AssignCook(date dinny.Date, cookSlackUID string) error {
+	return ms.AssignCooks(date, []string{cookSlackUID})
+}
+
+// AssignCooks sets the cooks of the meal on date, the first being the lead cook, creating the meal if it doesn't exist yet
+// and reopening it if it was cancelled.
+// Returns ErrMealClosed if the meal has already been cooked.
+func (ms *MealService) AssignCooks(date dinny.Date, cookSlackUIDs []string) error {
+	if len(cookSlackUIDs) == 0 {
+		return fmt.Errorf("AssignCooks: meal on %s needs a cook", date)
+	}
+	tx, err := ms.db.Begin()
+	if err != nil {
+		return fmt.Errorf("AssignCooks db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ms.query.WithTx(tx)
+	params := gen.FindMealByDateParams{
+		Year:       int64(date.Year),
+		Month:      int64(date.Month),
+		Day:        int64(date.Day),
+		RotationID: ms.rotationID,
+	}
+	m, err := qtx.FindMealByDate(context.Background(), params)
+	if err == sql.ErrNoRows {
+		arg := gen.CreateMealParams{
+			CookSlackUid: cookSlackUIDs[0],
+			Year:         int64(date.Year),
+			Month:        int64(date.Month),
+			Day:          int64(date.Day),
+			RotationID:   ms.rotationID,
+		}
+		m, err = qtx.CreateMeal(context.Background(), arg)
+		if err != nil {
+			return fmt.Errorf("AssignCooks CreateMeal: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("AssignCooks FindMealByDate: %w", err)
+	} else if m.Status == dinny.MealStatusCooked {
+		return fmt.Errorf("AssignCooks meal on %s: %w", date, dinny.ErrMealClosed)
+	}
+	err = reopenMeal(qtx, m.ID)
+	if err != nil {
+		return fmt.Errorf("AssignCooks: %w", err)
+	}
+	err = setMealCooks(qtx, m.ID, cookSlackUIDs)
+	if err != nil {
+		return fmt.Errorf("AssignCooks: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("AssignCooks tx.Commit: %w", err)
+	}
 	return nil
 }
 
-// UpdateMeal updates a meal object.
+// CompleteMeal closes out a scheduled meal as cooked and credits each cook with an equal share of the meal cooked and of its cooking credit.
+// Completing a meal which has already been closed out is a no-op, so a meal is never credited twice.
+// Returns ErrNotFound if the meal or its cook does not exist.
+func (ms *MealService) CompleteMeal(id int64) error {
+	tx, err := ms.db.Begin()
+	if err != nil {
+		return fmt.Errorf("CompleteMeal db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ms.query.WithTx(tx)
+	m, err := qtx.FindMealByID(context.Background(), gen.FindMealByIDParams{ID: id, RotationID: ms.rotationID})
+	if err == sql.ErrNoRows {
+		return dinny.ErrNotFound
+	} else if err != nil {
+		return fmt.Errorf("CompleteMeal FindMealByID: %w", err)
+	}
+	params := gen.CloseMealParams{ID: id, Status: dinny.MealStatusCooked}
+	n, err := qtx.CloseMeal(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CompleteMeal CloseMeal: %w", err)
+	}
+	if n == 0 {
+		// Already closed out.
+		return nil
+	}
+	credit, err := ms.cookingCredit(qtx, id)
+	if err != nil {
+		return fmt.Errorf("CompleteMeal: %w", err)
+	}
+	cooks, err := qtx.ListMealCooks(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("CompleteMeal ListMealCooks: %w", err)
+	}
+	if len(cooks) == 0 {
+		cooks = []string{m.CookSlackUid}
+	}
+	// Co-cooks split the meal and its credit evenly, so they rank the same whether or not the ranking is weighted.
+	for _, cook := range cooks {
+		creditParams := gen.CreditMemberMealCookedBySlackUIDParams{
+			MealsCooked:    1 / float64(len(cooks)),
+			CookingCredits: credit / float64(len(cooks)),
+			SlackUid:       cook,
+			RotationID:     ms.rotationID,
+		}
+		n, err := qtx.CreditMemberMealCookedBySlackUID(context.Background(), creditParams)
+		if err != nil {
+			return fmt.Errorf("CompleteMeal CreditMemberMealCookedBySlackUID: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("CompleteMeal cook %s: %w", cook, dinny.ErrNotFound)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("CompleteMeal tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// cookingCredit returns the credit for cooking the meal with the given ID according to the credit formula.
+// The meal must already be closed out as cooked, so it counts towards the average headcount.
+func (ms *MealService) cookingCredit(qtx *gen.Queries, id int64) (float64, error) {
+	if ms.CreditFormula.Name == "" || ms.CreditFormula.Name == dinny.CreditFormulaFlat {
+		return 1, nil
+	}
+	atts, err := qtx.ListAttendancesByMeal(context.Background(), id)
+	if err != nil {
+		return 0, fmt.Errorf("cookingCredit ListAttendancesByMeal: %w", err)
+	}
+	gs, err := qtx.ListGuestsByMeal(context.Background(), id)
+	if err != nil {
+		return 0, fmt.Errorf("cookingCredit ListGuestsByMeal: %w", err)
+	}
+	headcount := int64(len(atts))
+	for _, g := range gs {
+		headcount += g.Count
+	}
+	params := gen.AverageCookedMealHeadcountParams{RotationID: ms.rotationID, Status: dinny.MealStatusCooked}
+	average, err := qtx.AverageCookedMealHeadcount(context.Background(), params)
+	if err != nil {
+		return 0, fmt.Errorf("cookingCredit AverageCookedMealHeadcount: %w", err)
+	}
+	return ms.CreditFormula.Credit(headcount, average), nil
+}
+
+// CancelMeal closes out a scheduled meal as cancelled. Its attendances and guests are removed and the eaters' meals eaten are decremented,
+// unless an attendance was counted towards a closed season.
+// Returns ErrNotFound if the meal does not exist and ErrMealClosed if it has already been closed out.
+func (ms *MealService) CancelMeal(id int64) error {
+	tx, err := ms.db.Begin()
+	if err != nil {
+		return fmt.Errorf("CancelMeal db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ms.query.WithTx(tx)
+	if _, err := qtx.FindMealByID(context.Background(), gen.FindMealByIDParams{ID: id, RotationID: ms.rotationID}); err == sql.ErrNoRows {
+		return dinny.ErrNotFound
+	} else if err != nil {
+		return fmt.Errorf("CancelMeal FindMealByID: %w", err)
+	}
+	params := gen.CloseMealParams{ID: id, Status: dinny.MealStatusCancelled}
+	n, err := qtx.CloseMeal(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CancelMeal CloseMeal: %w", err)
+	}
+	if n == 0 {
+		return dinny.ErrMealClosed
+	}
+
+	// Nobody ate a cancelled meal.
+	atts, err := qtx.ListAttendancesByMeal(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("CancelMeal ListAttendancesByMeal: %w", err)
+	}
+	for _, a := range atts {
+		err := uncountAttendance(qtx, ms.rotationID, a)
+		if err != nil {
+			return fmt.Errorf("CancelMeal: %w", err)
+		}
+	}
+	err = qtx.DeleteAttendancesByMeal(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("CancelMeal DeleteAttendancesByMeal: %w", err)
+	}
+	err = qtx.DeleteGuestsByMeal(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("CancelMeal DeleteGuestsByMeal: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("CancelMeal tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// SwapCooks atomically swaps the cooks, co-cooks included, of the scheduled meals on the two dates.
+// Returns ErrNotFound if either meal does not exist and ErrMealClosed if either has been closed out.
+func (ms *MealService) SwapCooks(first dinny.Date, second dinny.Date) error {
+	tx, err := ms.db.Begin()
+	if err != nil {
+		return fmt.Errorf("SwapCooks db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ms.query.WithTx(tx)
+	var meals []gen.Meal
+	for _, date := range []dinny.Date{first, second} {
+		params := gen.FindMealByDateParams{
+			Year:       int64(date.Year),
+			Month:      int64(date.Month),
+			Day:        int64(date.Day),
+			RotationID: ms.rotationID,
+		}
+		m, err := qtx.FindMealByDate(context.Background(), params)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("SwapCooks meal on %s: %w", date, dinny.ErrNotFound)
+		} else if err != nil {
+			return fmt.Errorf("SwapCooks FindMealByDate: %w", err)
+		}
+		if m.Status != dinny.MealStatusScheduled {
+			return fmt.Errorf("SwapCooks meal on %s: %w", date, dinny.ErrMealClosed)
+		}
+		meals = append(meals, m)
+	}
+	var cooks [][]string
+	for _, m := range meals {
+		cs, err := qtx.ListMealCooks(context.Background(), m.ID)
+		if err != nil {
+			return fmt.Errorf("SwapCooks ListMealCooks: %w", err)
+		}
+		cooks = append(cooks, cs)
+	}
+	for ii, m := range meals {
+		err := setMealCooks(qtx, m.ID, cooks[1-ii])
+		if err != nil {
+			return fmt.Errorf("SwapCooks: %w", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("SwapCooks tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// MarkHeadcountSent records that the cooks were sent the headcount of the meal.
+// Reports false if the headcount had already been marked as sent.
+func (ms *MealService) MarkHeadcountSent(id int64) (bool, error) {
+	n, err := ms.query.MarkMealHeadcountSent(context.Background(), id)
+	if err != nil {
+		return false, fmt.Errorf("MarkHeadcountSent: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ListHeadcountDeliveries retrieves the Slack UIDs of the cooks who were sent the headcount of the meal.
+func (ms *MealService) ListHeadcountDeliveries(mealID int64) ([]string, error) {
+	uids, err := ms.query.ListHeadcountDeliveriesByMeal(context.Background(), mealID)
+	if err != nil {
+		return nil, fmt.Errorf("ListHeadcountDeliveries: %w", err)
+	}
+	return uids, nil
+}
+
+// CreateHeadcountDelivery records that the cook with the given Slack UID was sent the headcount of the meal.
+// Recording it again is a no-op.
+func (ms *MealService) CreateHeadcountDelivery(mealID int64, slackUID string) error {
+	err := ms.query.CreateHeadcountDelivery(context.Background(), gen.CreateHeadcountDeliveryParams{MealID: mealID, SlackUid: slackUID})
+	if err != nil {
+		return fmt.Errorf("CreateHeadcountDelivery: %w", err)
+	}
+	return nil
+}
+
+// CloseRSVPs records that the RSVPs of the meal were closed.
+// Reports false if they had already been closed.
+func (ms *MealService) CloseRSVPs(id int64) (bool, error) {
+	n, err := ms.query.CloseMealRSVPs(context.Background(), id)
+	if err != nil {
+		return false, fmt.Errorf("CloseRSVPs: %w", err)
+	}
+	return n > 0, nil
+}
+
+// UpdateMeal updates a meal object. Returns ErrMealClosed if the cook of a meal which has already been cooked is changed.
 func (ms *MealService) UpdateMeal(id int64, upd dinny.MealUpdate) error {
 	tx, err := ms.db.Begin()
 	if err != nil {
-		return fmt.Errorf("UpdateMember db.Begin: %w", err)
+		return fmt.Errorf("UpdateMeal db.Begin: %w", err)
 	}
 	defer tx.Rollback()
 	qtx := ms.query.WithTx(tx)
@@ -138,14 +615,46 @@ func (ms *MealService) UpdateMeal(id int64, upd dinny.MealUpdate) error {
 		}
 	}
 
+	if upd.Title != nil {
+		params := gen.UpdateMealTitleParams{ID: id, Title: *upd.Title}
+		err := qtx.UpdateMealTitle(context.Background(), params)
+		if err != nil {
+			return fmt.Errorf("UpdateMeal UpdateMealTitle: %w", err)
+		}
+	}
+
+	if upd.Tags != nil {
+		params := gen.UpdateMealTagsParams{ID: id, Tags: strings.Join(*upd.Tags, ",")}
+		err := qtx.UpdateMealTags(context.Background(), params)
+		if err != nil {
+			return fmt.Errorf("UpdateMeal UpdateMealTags: %w", err)
+		}
+	}
+
+	if upd.RSVPDeadline != nil {
+		s := sql.NullString{
+			String: formatTime(*upd.RSVPDeadline),
+			Valid:  true,
+		}
+		params := gen.UpdateMealRSVPDeadlineParams{ID: id, RsvpDeadline: s}
+		err := qtx.UpdateMealRSVPDeadline(context.Background(), params)
+		if err != nil {
+			return fmt.Errorf("UpdateMeal UpdateMealRSVPDeadline: %w", err)
+		}
+	}
+
 	if upd.ChefSlackUID != nil {
-		params := gen.UpdateMealSlackUIDParams{
-			ID:           id,
-			CookSlackUid: *upd.ChefSlackUID,
+		m, err := qtx.FindMealByID(context.Background(), gen.FindMealByIDParams{ID: id, RotationID: ms.rotationID})
+		if err == sql.ErrNoRows {
+			return dinny.ErrNotFound
+		} else if err != nil {
+			return fmt.Errorf("UpdateMeal FindMealByID: %w", err)
+		} else if m.Status == dinny.MealStatusCooked {
+			return fmt.Errorf("UpdateMeal meal %d: %w", id, dinny.ErrMealClosed)
 		}
-		err := qtx.UpdateMealSlackUID(context.Background(), params)
+		err = setMealCooks(qtx, id, []string{*upd.ChefSlackUID})
 		if err != nil {
-			return fmt.Errorf("UpdateMeal UpdateMealSlackUID: %w", err)
+			return fmt.Errorf("UpdateMeal: %w", err)
 		}
 	}
 
@@ -168,11 +677,42 @@ func (ms *MealService) UpdateMeal(id int64, upd dinny.MealUpdate) error {
 	return nil
 }
 
-// DeleteMeal permanently deletes a meal.
+// DeleteMeal permanently deletes a meal. Its attendances are removed and the eaters' meals eaten are decremented,
+// unless an attendance was counted towards a closed season.
+// Returns ErrNotFound if the meal does not exist and ErrMealClosed if it has been cooked, as its cooks have been credited for it.
 func (ms *MealService) DeleteMeal(id int64) error {
-	err := ms.query.DeleteMeal(context.Background(), id)
+	tx, err := ms.db.Begin()
+	if err != nil {
+		return fmt.Errorf("DeleteMeal db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ms.query.WithTx(tx)
+	m, err := qtx.FindMealByID(context.Background(), gen.FindMealByIDParams{ID: id, RotationID: ms.rotationID})
+	if err == sql.ErrNoRows {
+		return dinny.ErrNotFound
+	} else if err != nil {
+		return fmt.Errorf("DeleteMeal FindMealByID: %w", err)
+	}
+	if m.Status == dinny.MealStatusCooked {
+		return fmt.Errorf("DeleteMeal meal %d: %w", id, dinny.ErrMealClosed)
+	}
+	atts, err := qtx.ListAttendancesByMeal(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("DeleteMeal ListAttendancesByMeal: %w", err)
+	}
+	for _, a := range atts {
+		err := uncountAttendance(qtx, ms.rotationID, a)
+		if err != nil {
+			return fmt.Errorf("DeleteMeal: %w", err)
+		}
+	}
+	err = qtx.DeleteMeal(context.Background(), id)
 	if err != nil {
 		return fmt.Errorf("DeleteMeal: %w", err)
 	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("DeleteMeal tx.Commit: %w", err)
+	}
 	return nil
 }
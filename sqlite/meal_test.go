@@ -0,0 +1,590 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// TestCompleteMeal ensures completing a meal credits the cook exactly once and freezes its attendances.
+func TestCompleteMeal(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+
+	cook := &dinny.Member{SlackUID: "U1", FullName: "Jane Doe"}
+	if err := memberService.CreateMember(cook); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, cook.SlackUID); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.Status != dinny.MealStatusScheduled {
+		t.Errorf("Status = %s, want %s", meal.Status, dinny.MealStatusScheduled)
+	}
+
+	for ii := 0; ii < 2; ii++ {
+		if err := mealService.CompleteMeal(meal.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m, err := memberService.FindMemberByID(cook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MealsCooked != 1 {
+		t.Errorf("MealsCooked = %v, want 1", m.MealsCooked)
+	}
+
+	a := &dinny.Attendance{MealID: meal.ID, MemberID: cook.ID, Source: dinny.AttendanceSourceManual}
+	if err := attendanceService.CreateAttendance(a); !errors.Is(err, dinny.ErrMealClosed) {
+		t.Errorf("CreateAttendance err = %v, want ErrMealClosed", err)
+	}
+}
+
+// TestCancelMeal ensures cancelling a meal uncredits its eaters and refuses to cancel it twice.
+func TestCancelMeal(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+
+	eater := &dinny.Member{SlackUID: "U1", FullName: "Jane Doe"}
+	if err := memberService.CreateMember(eater); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, "U2"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &dinny.Attendance{MealID: meal.ID, MemberID: eater.ID, Source: dinny.AttendanceSourceManual}
+	if err := attendanceService.CreateAttendance(a); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mealService.CancelMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.CancelMeal(meal.ID); !errors.Is(err, dinny.ErrMealClosed) {
+		t.Errorf("CancelMeal err = %v, want ErrMealClosed", err)
+	}
+	m, err := memberService.FindMemberByID(eater.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MealsEaten != 0 {
+		t.Errorf("MealsEaten = %d, want 0", m.MealsEaten)
+	}
+	atts, err := attendanceService.ListAttendancesByMeal(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(atts) != 0 {
+		t.Errorf("len(attendances) = %d, want 0", len(atts))
+	}
+	meal, err = mealService.FindMealByID(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.Status != dinny.MealStatusCancelled {
+		t.Errorf("Status = %s, want %s", meal.Status, dinny.MealStatusCancelled)
+	}
+}
+
+// TestReopenCancelledMeal ensures assigning cooks to a cancelled meal's date schedules it again with a fresh message.
+func TestReopenCancelledMeal(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	mealService := NewMealService(queries, db)
+
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgID := "1672531200.000100"
+	if err := mealService.UpdateMeal(meal.ID, dinny.MealUpdate{SlackMessageID: &msgID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mealService.CloseRSVPs(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.CancelMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mealService.AssignCook(date, "U2"); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.ID != meal.ID {
+		t.Errorf("ID = %d, want %d", reopened.ID, meal.ID)
+	}
+	if reopened.Status != dinny.MealStatusScheduled {
+		t.Errorf("Status = %s, want %s", reopened.Status, dinny.MealStatusScheduled)
+	}
+	if reopened.CookSlackUID != "U2" {
+		t.Errorf("CookSlackUID = %s, want U2", reopened.CookSlackUID)
+	}
+	if reopened.SlackMessageID != "" || reopened.RSVPsClosedAt != nil {
+		t.Errorf("meal = %+v, want no message or closed RSVPs", reopened)
+	}
+
+	if err := mealService.CancelMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.CreateMeal(&dinny.Meal{Date: date, CookSlackUID: "U3"}); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err = mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Status != dinny.MealStatusScheduled || reopened.CookSlackUID != "U3" {
+		t.Errorf("Status, CookSlackUID = %s, %s, want %s, U3", reopened.Status, reopened.CookSlackUID, dinny.MealStatusScheduled)
+	}
+	if err := mealService.CreateMeal(&dinny.Meal{Date: date, CookSlackUID: "U1"}); err == nil {
+		t.Error("CreateMeal on a scheduled date succeeded, want error")
+	}
+}
+
+// TestDeleteMeal ensures deleting a meal uncredits its eaters along with their attendances and that cooked meals can't be deleted.
+func TestDeleteMeal(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+
+	eater := &dinny.Member{SlackUID: "U1", FullName: "Jane Doe"}
+	if err := memberService.CreateMember(eater); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, "U2"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &dinny.Attendance{MealID: meal.ID, MemberID: eater.ID, Source: dinny.AttendanceSourceManual}
+	if err := attendanceService.CreateAttendance(a); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mealService.DeleteMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+	m, err := memberService.FindMemberByID(eater.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MealsEaten != 0 {
+		t.Errorf("MealsEaten = %d, want 0", m.MealsEaten)
+	}
+	if _, err := mealService.FindMealByID(meal.ID); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("FindMealByID err = %v, want ErrNotFound", err)
+	}
+
+	if err := mealService.AssignCook(date.AddDays(1), "U1"); err != nil {
+		t.Fatal(err)
+	}
+	cooked, err := mealService.FindMealByDate(date.AddDays(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.CompleteMeal(cooked.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.DeleteMeal(cooked.ID); !errors.Is(err, dinny.ErrMealClosed) {
+		t.Errorf("DeleteMeal() of a cooked meal err = %v, want ErrMealClosed", err)
+	}
+}
+
+// TestListScheduledMealsBefore ensures only the meals before the date which haven't been closed out are listed.
+func TestListScheduledMealsBefore(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+
+	if err := memberService.CreateMember(&dinny.Member{SlackUID: "U1", FullName: "Jane Doe"}); err != nil {
+		t.Fatal(err)
+	}
+	dates := []dinny.Date{
+		{Year: 2022, Month: 12, Day: 30},
+		{Year: 2022, Month: 12, Day: 31},
+		{Year: 2023, Month: 1, Day: 1},
+		{Year: 2023, Month: 1, Day: 2},
+		{Year: 2023, Month: 1, Day: 3},
+	}
+	var ids []int64
+	for _, date := range dates {
+		if err := mealService.AssignCook(date, "U1"); err != nil {
+			t.Fatal(err)
+		}
+		meal, err := mealService.FindMealByDate(date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, meal.ID)
+	}
+	if err := mealService.CompleteMeal(ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.CancelMeal(ids[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	meals, err := mealService.ListScheduledMealsBefore(dates[4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []dinny.Date
+	for _, m := range meals {
+		got = append(got, m.Date)
+	}
+	if want := []dinny.Date{dates[0], dates[3]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListScheduledMealsBefore() = %v, want %v", got, want)
+	}
+}
+
+// TestSwapCooks ensures the cooks of two scheduled meals are swapped and closed meals are left alone.
+func TestSwapCooks(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	mealService := NewMealService(queries, db)
+
+	first := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	second := dinny.Date{Year: 2023, Month: 1, Day: 3}
+	third := dinny.Date{Year: 2023, Month: 1, Day: 4}
+	for date, uid := range map[dinny.Date]string{first: "U1", second: "U2", third: "U3"} {
+		if err := mealService.AssignCook(date, uid); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := mealService.SwapCooks(first, second); err != nil {
+		t.Fatal(err)
+	}
+	for date, want := range map[dinny.Date]string{first: "U2", second: "U1"} {
+		meal, err := mealService.FindMealByDate(date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meal.CookSlackUID != want {
+			t.Errorf("%s CookSlackUID = %s, want %s", date, meal.CookSlackUID, want)
+		}
+	}
+
+	meal, err := mealService.FindMealByDate(third)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.CancelMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.SwapCooks(first, third); !errors.Is(err, dinny.ErrMealClosed) {
+		t.Errorf("SwapCooks err = %v, want ErrMealClosed", err)
+	}
+	if err := mealService.SwapCooks(first, dinny.Date{Year: 2023, Month: 2, Day: 1}); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("SwapCooks err = %v, want ErrNotFound", err)
+	}
+}
+
+// TestUpdateMealMenu ensures the title, description, and tags of a meal round trip.
+func TestUpdateMealMenu(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	mealService := NewMealService(gen.New(db), db)
+
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meal.Tags) != 0 {
+		t.Errorf("Tags = %v, want none", meal.Tags)
+	}
+
+	title, description := "Lasagna", "with garlic bread"
+	tags := []string{dinny.MealTagVegetarian, dinny.MealTagContainsNuts}
+	upd := dinny.MealUpdate{Title: &title, Description: &description, Tags: &tags}
+	if err := mealService.UpdateMeal(meal.ID, upd); err != nil {
+		t.Fatal(err)
+	}
+	meal, err = mealService.FindMealByID(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.Title != title || meal.Description != description {
+		t.Errorf("menu = %q, %q, want %q, %q", meal.Title, meal.Description, title, description)
+	}
+	if !reflect.DeepEqual(meal.Tags, tags) {
+		t.Errorf("Tags = %v, want %v", meal.Tags, tags)
+	}
+}
+
+// TestMarkHeadcountSent ensures the headcount of a meal is only marked as sent once.
+func TestMarkHeadcountSent(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	mealService := NewMealService(gen.New(db), db)
+
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.HeadcountSentAt != nil {
+		t.Errorf("HeadcountSentAt = %v, want nil", meal.HeadcountSentAt)
+	}
+
+	for ii, want := range []bool{true, false} {
+		sent, err := mealService.MarkHeadcountSent(meal.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sent != want {
+			t.Errorf("MarkHeadcountSent #%d = %v, want %v", ii, sent, want)
+		}
+	}
+	meal, err = mealService.FindMealByID(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.HeadcountSentAt == nil || meal.HeadcountSentAt.IsZero() {
+		t.Errorf("HeadcountSentAt = %v, want set", meal.HeadcountSentAt)
+	}
+}
+
+// TestCloseRSVPs ensures the RSVP deadline of a meal round trips and its RSVPs are only closed once.
+func TestCloseRSVPs(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	mealService := NewMealService(gen.New(db), db)
+
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	if err := mealService.AssignCook(date, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Date(2023, time.January, 2, 14, 30, 0, 0, time.UTC)
+	if err := mealService.UpdateMeal(meal.ID, dinny.MealUpdate{RSVPDeadline: &deadline}); err != nil {
+		t.Fatal(err)
+	}
+
+	for ii, want := range []bool{true, false} {
+		closed, err := mealService.CloseRSVPs(meal.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if closed != want {
+			t.Errorf("CloseRSVPs #%d = %v, want %v", ii, closed, want)
+		}
+	}
+	meal, err = mealService.FindMealByID(meal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.RSVPDeadline == nil || !meal.RSVPDeadline.Equal(deadline) {
+		t.Errorf("RSVPDeadline = %v, want %v", meal.RSVPDeadline, deadline)
+	}
+	if meal.RSVPsClosedAt == nil {
+		t.Error("RSVPsClosedAt = nil, want set")
+	}
+}
+
+// TestCompleteMealCredits ensures cooks are credited by headcount relative to the average cooked meal.
+func TestCompleteMealCredits(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	mealService.CreditFormula = dinny.CreditFormula{Name: dinny.CreditFormulaAverage}
+	attendanceService := NewAttendanceService(queries, db)
+
+	var members []*dinny.Member
+	for _, uid := range []string{"U1", "U2", "U3"} {
+		m := &dinny.Member{SlackUID: uid, FullName: uid}
+		if err := memberService.CreateMember(m); err != nil {
+			t.Fatal(err)
+		}
+		members = append(members, m)
+	}
+	meals := []struct {
+		date   dinny.Date
+		cook   *dinny.Member
+		eaters int
+		guests int64
+		want   float64
+	}{
+		// The first meal is the average meal.
+		{dinny.Date{Year: 2023, Month: 1, Day: 2}, members[0], 2, 0, 1},
+		// Six eaters against an average of four.
+		{dinny.Date{Year: 2023, Month: 1, Day: 3}, members[1], 3, 3, 1.5},
+	}
+	for _, tt := range meals {
+		if err := mealService.AssignCook(tt.date, tt.cook.SlackUID); err != nil {
+			t.Fatal(err)
+		}
+		meal, err := mealService.FindMealByDate(tt.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range members[:tt.eaters] {
+			a := &dinny.Attendance{MealID: meal.ID, MemberID: m.ID, Source: dinny.AttendanceSourceManual}
+			if err := attendanceService.CreateAttendance(a); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tt.guests > 0 {
+			if err := attendanceService.SetGuests(meal.ID, members[0].ID, tt.guests); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := mealService.CompleteMeal(meal.ID); err != nil {
+			t.Fatal(err)
+		}
+		cook, err := memberService.FindMemberByID(tt.cook.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cook.CookingCredits != tt.want || cook.MealsCooked != 1 {
+			t.Errorf("CookingCredits, MealsCooked = %v, %v, want %v, 1", cook.CookingCredits, cook.MealsCooked, tt.want)
+		}
+	}
+}
+
+// TestCoCooks ensures co-cooks are kept in order, move together when swapped, split their meal and its credit
+// and can't be reassigned once the meal is cooked.
+func TestCoCooks(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+
+	for _, uid := range []string{"U1", "U2", "U3"} {
+		if err := memberService.CreateMember(&dinny.Member{SlackUID: uid, FullName: uid}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	first := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	second := dinny.Date{Year: 2023, Month: 1, Day: 3}
+	if err := mealService.AssignCooks(first, []string{"U2", "U1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.AssignCook(second, "U3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.SwapCooks(first, second); err != nil {
+		t.Fatal(err)
+	}
+	for date, want := range map[dinny.Date][]string{first: {"U3"}, second: {"U2", "U1"}} {
+		meal, err := mealService.FindMealByDate(date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(meal.Cooks, want) || meal.CookSlackUID != want[0] {
+			t.Errorf("%s Cooks, CookSlackUID = %v, %s, want %v, %s", date, meal.Cooks, meal.CookSlackUID, want, want[0])
+		}
+	}
+
+	meal, err := mealService.FindMealByDate(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.CompleteMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, uid := range []string{"U1", "U2"} {
+		cook, err := memberService.FindMemberBySlackUID(uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cook.CookingCredits != 0.5 || cook.MealsCooked != 0.5 {
+			t.Errorf("%s CookingCredits, MealsCooked = %v, %v, want 0.5, 0.5", uid, cook.CookingCredits, cook.MealsCooked)
+		}
+	}
+	if err := mealService.AssignCooks(second, []string{"U3"}); !errors.Is(err, dinny.ErrMealClosed) {
+		t.Errorf("AssignCooks() on a cooked meal error = %v, want ErrMealClosed", err)
+	}
+	chef := "U3"
+	if err := mealService.UpdateMeal(meal.ID, dinny.MealUpdate{ChefSlackUID: &chef}); !errors.Is(err, dinny.ErrMealClosed) {
+		t.Errorf("UpdateMeal() of the cook of a cooked meal error = %v, want ErrMealClosed", err)
+	}
+}
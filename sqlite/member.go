@@ -16,17 +16,27 @@ var _ dinny.MemberService = (*MemberService)(nil)
 type MemberService struct {
 	query *gen.Queries
 	db    *sql.DB
+
+	// rotationID is the rotation the service is scoped to.
+	rotationID int64
 }
 
-// NewMemberService returns a new instance of MemberService.
+// NewMemberService returns a new instance of MemberService scoped to the default rotation.
 func NewMemberService(query *gen.Queries, db *sql.DB) *MemberService {
-	return &MemberService{query, db}
+	return &MemberService{query, db, dinny.DefaultRotationID}
+}
+
+// ForRotation returns a copy of the service scoped to the rotation with the given ID.
+func (ms *MemberService) ForRotation(rotationID int64) *MemberService {
+	scoped := *ms
+	scoped.rotationID = rotationID
+	return &scoped
 }
 
 // Retrieves a member by ID
 // Returns ErrNotFound if meal does not exist.
 func (ms *MemberService) FindMemberByID(id int64) (*dinny.Member, error) {
-	m, err := ms.query.FindMemberByID(context.Background(), id)
+	m, err := ms.query.FindMemberByID(context.Background(), gen.FindMemberByIDParams{ID: id, RotationID: ms.rotationID})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, dinny.ErrNotFound
@@ -36,19 +46,21 @@ func (ms *MemberService) FindMemberByID(id int64) (*dinny.Member, error) {
 	}
 	isLeader := m.Leader == 1
 	return &dinny.Member{
-		ID:          m.ID,
-		SlackUID:    m.SlackUid,
-		FullName:    m.FullName,
-		MealsEaten:  m.MealsEaten,
-		MealsCooked: m.MealsCooked,
-		Leader:      isLeader,
+		ID:             m.ID,
+		SlackUID:       m.SlackUid,
+		FullName:       m.FullName,
+		MealsEaten:     m.MealsEaten,
+		MealsCooked:    m.MealsCooked,
+		Leader:         isLeader,
+		RotationID:     m.RotationID,
+		CookingCredits: m.CookingCredits,
 	}, nil
 }
 
 // Retrieves a member by SlackID
 // Returns ErrNotFound if meal does not exist.
 func (ms *MemberService) FindMemberBySlackUID(slackUID string) (*dinny.Member, error) {
-	m, err := ms.query.FindMemberBySlackUID(context.Background(), slackUID)
+	m, err := ms.query.FindMemberBySlackUID(context.Background(), gen.FindMemberBySlackUIDParams{SlackUid: slackUID, RotationID: ms.rotationID})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, dinny.ErrNotFound
@@ -58,18 +70,20 @@ func (ms *MemberService) FindMemberBySlackUID(slackUID string) (*dinny.Member, e
 	}
 	isLeader := m.Leader == 1
 	return &dinny.Member{
-		ID:          m.ID,
-		SlackUID:    m.SlackUid,
-		FullName:    m.FullName,
-		MealsEaten:  m.MealsEaten,
-		MealsCooked: m.MealsCooked,
-		Leader:      isLeader,
+		ID:             m.ID,
+		SlackUID:       m.SlackUid,
+		FullName:       m.FullName,
+		MealsEaten:     m.MealsEaten,
+		MealsCooked:    m.MealsCooked,
+		Leader:         isLeader,
+		RotationID:     m.RotationID,
+		CookingCredits: m.CookingCredits,
 	}, nil
 }
 
 // Retrieves a list of members.
 func (ms *MemberService) ListMembers() ([]*dinny.Member, error) {
-	mems, err := ms.query.ListMembers(context.Background())
+	mems, err := ms.query.ListMembers(context.Background(), ms.rotationID)
 	if err != nil {
 		return nil, fmt.Errorf("ListMembers: %w", err)
 	}
@@ -78,12 +92,14 @@ func (ms *MemberService) ListMembers() ([]*dinny.Member, error) {
 		m := &mems[ii]
 		isLeader := m.Leader == 1
 		members = append(members, &dinny.Member{
-			ID:          m.ID,
-			SlackUID:    m.SlackUid,
-			FullName:    m.FullName,
-			MealsEaten:  m.MealsEaten,
-			MealsCooked: m.MealsCooked,
-			Leader:      isLeader,
+			ID:             m.ID,
+			SlackUID:       m.SlackUid,
+			FullName:       m.FullName,
+			MealsEaten:     m.MealsEaten,
+			MealsCooked:    m.MealsCooked,
+			Leader:         isLeader,
+			RotationID:     m.RotationID,
+			CookingCredits: m.CookingCredits,
 		})
 	}
 	return members, nil
@@ -98,14 +114,17 @@ func (ms *MemberService) CreateMember(m *dinny.Member) error {
 		isLeader = 0
 	}
 	params := gen.CreateMemberParams{
-		SlackUid: m.SlackUID,
-		FullName: m.FullName,
-		Leader:   isLeader,
+		SlackUid:   m.SlackUID,
+		FullName:   m.FullName,
+		Leader:     isLeader,
+		RotationID: ms.rotationID,
 	}
-	_, err := ms.query.CreateMember(context.Background(), params)
+	mem, err := ms.query.CreateMember(context.Background(), params)
 	if err != nil {
 		return fmt.Errorf("CreateMember: %w", err)
 	}
+	m.ID = mem.ID
+	m.RotationID = mem.RotationID
 	return nil
 }
 
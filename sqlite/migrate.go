@@ -0,0 +1,251 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// embed the ordered set of migrations within the binary to evolve the schema at runtime.
+//
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// migrationsTable keeps track of which migrations have been applied to the database.
+const migrationsTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at TEXT NOT NULL DEFAULT (datetime('now'))
+);`
+
+// Migration represents a single versioned schema change.
+// Migrations are stored as <version>_<name>.up.sql and <version>_<name>.down.sql within the migrations directory.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus represents whether a migration has been applied to the database.
+type MigrationStatus struct {
+	Version   int64  `json:"version"`
+	Name      string `json:"name"`
+	Applied   bool   `json:"applied"`
+	AppliedAt string `json:"appliedAt,omitempty"`
+}
+
+// Migrations returns the embedded migrations ordered by version.
+func Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("Migrations fs.ReadDir: %w", err)
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		filename := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(filename, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(filename, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("Migrations: unexpected file %s", filename)
+		}
+		base := strings.TrimSuffix(filename, "."+direction+".sql")
+		versionStr, name, found := strings.Cut(base, "_")
+		if !found {
+			return nil, fmt.Errorf("Migrations: file %s must be named <version>_<name>.%s.sql", filename, direction)
+		}
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Migrations strconv.ParseInt %s: %w", filename, err)
+		}
+		buf, err := migrationFS.ReadFile(path.Join("migrations", filename))
+		if err != nil {
+			return nil, fmt.Errorf("Migrations ReadFile: %w", err)
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("Migrations: version %d used by both %s and %s", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(buf)
+		} else {
+			m.Down = string(buf)
+		}
+	}
+	var migrations []Migration
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("Migrations: version %d is missing an up migration", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(ii, jj int) bool {
+		return migrations[ii].Version < migrations[jj].Version
+	})
+	return migrations, nil
+}
+
+// appliedMigrations returns the applied_at time of every applied migration keyed by version.
+func appliedMigrations(db *sql.DB) (map[int64]string, error) {
+	if _, err := db.Exec(migrationsTable); err != nil {
+		return nil, fmt.Errorf("appliedMigrations create schema_migrations: %w", err)
+	}
+	rows, err := db.Query(`SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("appliedMigrations db.Query: %w", err)
+	}
+	defer rows.Close()
+	applied := make(map[int64]string)
+	for rows.Next() {
+		var version int64
+		var appliedAt string
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("appliedMigrations rows.Scan: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("appliedMigrations rows.Err: %w", err)
+	}
+	return applied, nil
+}
+
+// MigrateStatus reports which of the embedded migrations have been applied to the database.
+func MigrateStatus(db *sql.DB) ([]MigrationStatus, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, fmt.Errorf("MigrateStatus: %w", err)
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, fmt.Errorf("MigrateStatus: %w", err)
+	}
+	var statuses []MigrationStatus
+	for _, m := range migrations {
+		appliedAt, ok := applied[m.Version]
+		statuses = append(statuses, MigrationStatus{
+			Version:   m.Version,
+			Name:      m.Name,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+	return statuses, nil
+}
+
+// migrationConn returns a connection on which migrations can rebuild tables without cascading deletes to the rows referencing them.
+// The returned func re-enables foreign keys and releases the connection.
+func migrationConn(db *sql.DB) (*sql.Conn, func(), error) {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("migrationConn db.Conn: %w", err)
+	}
+	// The pragma is a no-op within a transaction, so it must be set beforehand.
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF;`); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("migrationConn foreign keys pragma: %w", err)
+	}
+	return conn, func() {
+		conn.ExecContext(ctx, `PRAGMA foreign_keys = ON;`)
+		conn.Close()
+	}, nil
+}
+
+// MigrateUp applies every pending migration in order. Each migration is applied within its own transaction.
+func MigrateUp(db *sql.DB) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return fmt.Errorf("MigrateUp: %w", err)
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return fmt.Errorf("MigrateUp: %w", err)
+	}
+	conn, release, err := migrationConn(db)
+	if err != nil {
+		return fmt.Errorf("MigrateUp: %w", err)
+	}
+	defer release()
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		tx, err := conn.BeginTx(context.Background(), nil)
+		if err != nil {
+			return fmt.Errorf("MigrateUp conn.BeginTx: %w", err)
+		}
+		if _, err := tx.Exec(m.Up); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("MigrateUp %d_%s: %w", m.Version, m.Name, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, m.Version, m.Name); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("MigrateUp record %d_%s: %w", m.Version, m.Name, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("MigrateUp tx.Commit: %w", err)
+		}
+	}
+	return nil
+}
+
+// MigrateDownTo reverts every applied migration with a version above the given version, newest first.
+// Each migration is reverted within its own transaction.
+func MigrateDownTo(db *sql.DB, version int64) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return fmt.Errorf("MigrateDownTo: %w", err)
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return fmt.Errorf("MigrateDownTo: %w", err)
+	}
+	conn, release, err := migrationConn(db)
+	if err != nil {
+		return fmt.Errorf("MigrateDownTo: %w", err)
+	}
+	defer release()
+	for ii := len(migrations) - 1; ii >= 0; ii-- {
+		m := migrations[ii]
+		if m.Version <= version {
+			break
+		}
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+		if m.Down == "" {
+			return fmt.Errorf("MigrateDownTo: %d_%s has no down migration", m.Version, m.Name)
+		}
+		tx, err := conn.BeginTx(context.Background(), nil)
+		if err != nil {
+			return fmt.Errorf("MigrateDownTo conn.BeginTx: %w", err)
+		}
+		if _, err := tx.Exec(m.Down); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("MigrateDownTo %d_%s: %w", m.Version, m.Name, err)
+		}
+		if _, err := tx.Exec(`DELETE FROM schema_migrations WHERE version = ?`, m.Version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("MigrateDownTo unrecord %d_%s: %w", m.Version, m.Name, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("MigrateDownTo tx.Commit: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestMigrate ensures migrations can be applied, reverted, and reapplied.
+func TestMigrate(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	countApplied := func() int {
+		statuses, err := MigrateStatus(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := 0
+		for _, status := range statuses {
+			if status.Applied {
+				n++
+			}
+		}
+		return n
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := countApplied(); got != len(migrations) {
+		t.Errorf("applied after Open = %d, want %d", got, len(migrations))
+	}
+
+	// Reapplying is a no-op.
+	if err := MigrateUp(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MigrateDownTo(db, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := countApplied(); got != 0 {
+		t.Errorf("applied after MigrateDownTo(0) = %d, want 0", got)
+	}
+
+	if err := MigrateUp(db); err != nil {
+		t.Fatal(err)
+	}
+	if got := countApplied(); got != len(migrations) {
+		t.Errorf("applied after MigrateUp = %d, want %d", got, len(migrations))
+	}
+}
+
+// TestMigrateData ensures the migrations which rebuild tables keep the rows of a database from before rotations and their
+// foreign keys.
+func TestMigrateData(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Go back to the schema before 0012_rotations, which rebuilds members and meals.
+	if err := MigrateDownTo(db, 11); err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`INSERT INTO members (id, slack_uid, full_name, meals_eaten, meals_cooked, leader) VALUES (3, 'U1', 'Ada', 2, 1, 1), (7, 'U2', 'Bob', 1, 0, 0);`,
+		`INSERT INTO meals (id, cook_slack_uid, year, month, day, status) VALUES (5, 'U1', 2023, 1, 2, 'cooked'), (9, 'U2', 2023, 1, 3, 'scheduled');`,
+		`INSERT INTO attendances (meal_id, member_id, source) VALUES (5, 3, 'slack'), (5, 7, 'slack'), (9, 3, 'slack');`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := MigrateUp(db); err != nil {
+		t.Fatal(err)
+	}
+
+	type member struct {
+		ID          int64
+		SlackUID    string
+		MealsEaten  int64
+		MealsCooked float64
+		RotationID  int64
+	}
+	rows, err := db.Query(`SELECT id, slack_uid, meals_eaten, meals_cooked, rotation_id FROM members ORDER BY id;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var members []member
+	for rows.Next() {
+		var m member
+		if err := rows.Scan(&m.ID, &m.SlackUID, &m.MealsEaten, &m.MealsCooked, &m.RotationID); err != nil {
+			t.Fatal(err)
+		}
+		members = append(members, m)
+	}
+	rows.Close()
+	wantMembers := []member{{3, "U1", 2, 1, 1}, {7, "U2", 1, 0, 1}}
+	if !reflect.DeepEqual(members, wantMembers) {
+		t.Errorf("members = %+v, want %+v", members, wantMembers)
+	}
+
+	rows, err = db.Query(`
+SELECT meals.id, meals.day, meals.status, members.slack_uid FROM attendances
+JOIN meals ON meals.id = attendances.meal_id
+JOIN members ON members.id = attendances.member_id
+ORDER BY meals.id, members.id;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attendances []string
+	for rows.Next() {
+		var mealID, day int64
+		var status, slackUID string
+		if err := rows.Scan(&mealID, &day, &status, &slackUID); err != nil {
+			t.Fatal(err)
+		}
+		attendances = append(attendances, fmt.Sprintf("%d/%d %s %s", mealID, day, status, slackUID))
+	}
+	rows.Close()
+	wantAttendances := []string{"5/2 cooked U1", "5/2 cooked U2", "9/3 scheduled U1"}
+	if !reflect.DeepEqual(attendances, wantAttendances) {
+		t.Errorf("attendances = %q, want %q", attendances, wantAttendances)
+	}
+
+	var cooks int
+	if err := db.QueryRow(`SELECT count(*) FROM meal_cooks;`).Scan(&cooks); err != nil {
+		t.Fatal(err)
+	}
+	if cooks != 2 {
+		t.Errorf("meal cooks = %d, want 2", cooks)
+	}
+
+	rows, err = db.Query(`PRAGMA foreign_key_check;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	if rows.Next() {
+		var table string
+		var rowID, parent, fkID interface{}
+		rows.Scan(&table, &rowID, &parent, &fkID)
+		t.Errorf("foreign key of %s row %v violated after MigrateUp", table, rowID)
+	}
+}
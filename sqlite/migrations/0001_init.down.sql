@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS attendances;
+DROP TABLE IF EXISTS meals;
+DROP TABLE IF EXISTS members;
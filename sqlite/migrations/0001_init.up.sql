@@ -21,3 +21,12 @@ CREATE TABLE IF NOT EXISTS meals (
     updated_at TEXT NOT NULL DEFAULT (datetime('now')),
     UNIQUE(year, month, day)
 );
+
+CREATE TABLE IF NOT EXISTS attendances (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    meal_id INTEGER NOT NULL REFERENCES meals(id) ON DELETE CASCADE,
+    member_id INTEGER NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    source TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    UNIQUE(meal_id, member_id)
+);
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS job_runs (
+    name TEXT PRIMARY KEY,
+    last_run_at TEXT NOT NULL
+);
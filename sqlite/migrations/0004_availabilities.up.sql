@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS availabilities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    member_id INTEGER NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    from_date TEXT NOT NULL,
+    to_date TEXT NOT NULL,
+    cannot_cook INTEGER NOT NULL DEFAULT 1,
+    not_eating INTEGER NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
@@ -0,0 +1,2 @@
+ALTER TABLE meals DROP COLUMN closed_at;
+ALTER TABLE meals DROP COLUMN status;
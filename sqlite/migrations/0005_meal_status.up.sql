@@ -0,0 +1,2 @@
+ALTER TABLE meals ADD COLUMN status TEXT NOT NULL DEFAULT 'scheduled';
+ALTER TABLE meals ADD COLUMN closed_at TEXT;
@@ -0,0 +1,2 @@
+ALTER TABLE meals DROP COLUMN tags;
+ALTER TABLE meals DROP COLUMN title;
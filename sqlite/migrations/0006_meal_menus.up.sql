@@ -0,0 +1,2 @@
+ALTER TABLE meals ADD COLUMN title TEXT NOT NULL DEFAULT '';
+ALTER TABLE meals ADD COLUMN tags TEXT NOT NULL DEFAULT '';
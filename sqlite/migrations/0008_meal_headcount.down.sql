@@ -0,0 +1 @@
+ALTER TABLE meals DROP COLUMN headcount_sent_at;
@@ -0,0 +1 @@
+ALTER TABLE meals ADD COLUMN headcount_sent_at TEXT;
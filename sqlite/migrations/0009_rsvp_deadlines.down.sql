@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS late_rsvps;
+ALTER TABLE meals DROP COLUMN rsvps_closed_at;
+ALTER TABLE meals DROP COLUMN rsvp_deadline;
@@ -0,0 +1,10 @@
+ALTER TABLE meals ADD COLUMN rsvp_deadline TEXT;
+ALTER TABLE meals ADD COLUMN rsvps_closed_at TEXT;
+
+CREATE TABLE IF NOT EXISTS late_rsvps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    meal_id INTEGER NOT NULL REFERENCES meals(id) ON DELETE CASCADE,
+    member_id INTEGER NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    eating INTEGER NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
@@ -0,0 +1,50 @@
+-- Only the default rotation fits the schema without rotations.
+DELETE FROM attendances WHERE meal_id IN (SELECT id FROM meals WHERE rotation_id != 1) OR member_id IN (SELECT id FROM members WHERE rotation_id != 1);
+DELETE FROM late_rsvps WHERE meal_id IN (SELECT id FROM meals WHERE rotation_id != 1) OR member_id IN (SELECT id FROM members WHERE rotation_id != 1);
+DELETE FROM guests WHERE meal_id IN (SELECT id FROM meals WHERE rotation_id != 1) OR member_id IN (SELECT id FROM members WHERE rotation_id != 1);
+DELETE FROM availabilities WHERE member_id IN (SELECT id FROM members WHERE rotation_id != 1);
+DELETE FROM dietary_profiles WHERE member_id IN (SELECT id FROM members WHERE rotation_id != 1);
+DELETE FROM tokens WHERE member_id IN (SELECT id FROM members WHERE rotation_id != 1);
+
+CREATE TABLE members_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slack_uid TEXT NOT NULL UNIQUE,
+    full_name TEXT NOT NULL,
+    meals_eaten INTEGER NOT NULL DEFAULT 0,
+    meals_cooked INTEGER NOT NULL DEFAULT 0,
+    leader INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+INSERT INTO members_old
+SELECT id, slack_uid, full_name, meals_eaten, meals_cooked, leader, created_at, updated_at FROM members
+WHERE rotation_id = 1;
+DROP TABLE members;
+ALTER TABLE members_old RENAME TO members;
+
+CREATE TABLE meals_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    cook_slack_uid TEXT NOT NULL,
+    year INTEGER NOT NULL,
+    month INTEGER NOT NULL,
+    day INTEGER NOT NULL,
+    description TEXT,
+    slack_message_id TEXT UNIQUE,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    status TEXT NOT NULL DEFAULT 'scheduled',
+    closed_at TEXT,
+    title TEXT NOT NULL DEFAULT '',
+    tags TEXT NOT NULL DEFAULT '',
+    headcount_sent_at TEXT,
+    rsvp_deadline TEXT,
+    rsvps_closed_at TEXT,
+    UNIQUE(year, month, day)
+);
+INSERT INTO meals_old
+SELECT id, cook_slack_uid, year, month, day, description, slack_message_id, created_at, updated_at, status, closed_at, title, tags, headcount_sent_at, rsvp_deadline, rsvps_closed_at FROM meals
+WHERE rotation_id = 1;
+DROP TABLE meals;
+ALTER TABLE meals_old RENAME TO meals;
+
+DROP TABLE IF EXISTS rotations;
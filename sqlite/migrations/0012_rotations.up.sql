@@ -0,0 +1,52 @@
+CREATE TABLE IF NOT EXISTS rotations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    slack_channel TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Everything which existed before belongs to the default rotation.
+INSERT INTO rotations (id, name) VALUES (1, 'default');
+
+-- Members and meals are rebuilt to make slack_uid and the meal date unique per rotation.
+CREATE TABLE members_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slack_uid TEXT NOT NULL,
+    full_name TEXT NOT NULL,
+    meals_eaten INTEGER NOT NULL DEFAULT 0,
+    meals_cooked INTEGER NOT NULL DEFAULT 0,
+    leader INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    rotation_id INTEGER NOT NULL DEFAULT 1 REFERENCES rotations(id) ON DELETE CASCADE,
+    UNIQUE(rotation_id, slack_uid)
+);
+INSERT INTO members_new SELECT *, 1 FROM members;
+DROP TABLE members;
+ALTER TABLE members_new RENAME TO members;
+
+CREATE TABLE meals_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    cook_slack_uid TEXT NOT NULL,
+    year INTEGER NOT NULL,
+    month INTEGER NOT NULL,
+    day INTEGER NOT NULL,
+    description TEXT,
+    slack_message_id TEXT,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    status TEXT NOT NULL DEFAULT 'scheduled',
+    closed_at TEXT,
+    title TEXT NOT NULL DEFAULT '',
+    tags TEXT NOT NULL DEFAULT '',
+    headcount_sent_at TEXT,
+    rsvp_deadline TEXT,
+    rsvps_closed_at TEXT,
+    rotation_id INTEGER NOT NULL DEFAULT 1 REFERENCES rotations(id) ON DELETE CASCADE,
+    UNIQUE(rotation_id, year, month, day),
+    UNIQUE(rotation_id, slack_message_id)
+);
+INSERT INTO meals_new SELECT *, 1 FROM meals;
+DROP TABLE meals;
+ALTER TABLE meals_new RENAME TO meals;
@@ -0,0 +1,4 @@
+ALTER TABLE members ADD COLUMN cooking_credits REAL NOT NULL DEFAULT 0;
+
+-- Meals cooked so far are worth one credit each.
+UPDATE members SET cooking_credits = meals_cooked;
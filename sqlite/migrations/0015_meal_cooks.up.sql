@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS meal_cooks (
+    meal_id INTEGER NOT NULL REFERENCES meals(id) ON DELETE CASCADE,
+    slack_uid TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (meal_id, slack_uid)
+);
+
+-- Every meal so far had a single cook, who stays the lead cook in meals.cook_slack_uid.
+INSERT INTO meal_cooks (meal_id, slack_uid, position)
+SELECT id, cook_slack_uid, 0 FROM meals WHERE cook_slack_uid != '';
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS seasons (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rotation_id INTEGER NOT NULL REFERENCES rotations(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    -- from_date is empty for the first season of a rotation, which started with the rotation.
+    from_date TEXT NOT NULL DEFAULT '',
+    to_date TEXT NOT NULL,
+    closed_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE TABLE IF NOT EXISTS season_standings (
+    season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    member_id INTEGER NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    meals_eaten INTEGER NOT NULL,
+    meals_cooked INTEGER NOT NULL,
+    cooking_credits REAL NOT NULL,
+    PRIMARY KEY (season_id, member_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE job_runs DROP COLUMN last_error;
+ALTER TABLE job_runs DROP COLUMN last_success_at;
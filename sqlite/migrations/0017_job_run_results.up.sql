@@ -0,0 +1,5 @@
+ALTER TABLE job_runs ADD COLUMN last_success_at TEXT;
+ALTER TABLE job_runs ADD COLUMN last_error TEXT NOT NULL DEFAULT '';
+
+-- Runs recorded so far were recorded before they ran, so assume they succeeded.
+UPDATE job_runs SET last_success_at = last_run_at;
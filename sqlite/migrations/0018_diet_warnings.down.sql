@@ -0,0 +1 @@
+DROP TABLE IF EXISTS diet_warnings;
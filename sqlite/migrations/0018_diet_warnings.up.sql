@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS diet_warnings (
+    meal_id INTEGER NOT NULL REFERENCES meals(id) ON DELETE CASCADE,
+    member_id INTEGER NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (meal_id, member_id)
+);
@@ -0,0 +1,29 @@
+-- Members and season standings are rebuilt so co-cooks can each be credited with a share of a meal cooked.
+CREATE TABLE members_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slack_uid TEXT NOT NULL,
+    full_name TEXT NOT NULL,
+    meals_eaten INTEGER NOT NULL DEFAULT 0,
+    meals_cooked REAL NOT NULL DEFAULT 0,
+    leader INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    rotation_id INTEGER NOT NULL DEFAULT 1 REFERENCES rotations(id) ON DELETE CASCADE,
+    cooking_credits REAL NOT NULL DEFAULT 0,
+    UNIQUE(rotation_id, slack_uid)
+);
+INSERT INTO members_new SELECT * FROM members;
+DROP TABLE members;
+ALTER TABLE members_new RENAME TO members;
+
+CREATE TABLE season_standings_new (
+    season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    member_id INTEGER NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    meals_eaten INTEGER NOT NULL,
+    meals_cooked REAL NOT NULL,
+    cooking_credits REAL NOT NULL,
+    PRIMARY KEY (season_id, member_id)
+);
+INSERT INTO season_standings_new SELECT * FROM season_standings;
+DROP TABLE season_standings;
+ALTER TABLE season_standings_new RENAME TO season_standings;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS headcount_deliveries (
+    meal_id INTEGER NOT NULL REFERENCES meals(id) ON DELETE CASCADE,
+    slack_uid TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (meal_id, slack_uid)
+);
+
+-- The cooks of meals whose headcount was already sent got it.
+INSERT OR IGNORE INTO headcount_deliveries (meal_id, slack_uid)
+SELECT meal_cooks.meal_id, meal_cooks.slack_uid FROM meal_cooks
+JOIN meals ON meals.id = meal_cooks.meal_id
+WHERE meals.headcount_sent_at IS NOT NULL;
+
+INSERT OR IGNORE INTO headcount_deliveries (meal_id, slack_uid)
+SELECT id, cook_slack_uid FROM meals
+WHERE headcount_sent_at IS NOT NULL AND cook_slack_uid != '';
@@ -1,20 +1,21 @@
 -- name: FindMemberByID :one
 SELECT * FROM members
-WHERE id = ? LIMIT 1;
+WHERE id = ? AND rotation_id = ? LIMIT 1;
 
 -- name: FindMemberBySlackUID :one
 SELECT * FROM members
-WHERE slack_uid = ? LIMIT 1;
+WHERE slack_uid = ? AND rotation_id = ? LIMIT 1;
 
 -- name: ListMembers :many
 SELECT * FROM members
+WHERE rotation_id = ?
 ORDER BY meals_cooked ASC, meals_eaten DESC;
 
 -- name: CreateMember :one
 INSERT INTO members (
-    slack_uid, full_name, leader
+    slack_uid, full_name, leader, rotation_id
 ) VALUES (
-    ?, ?, ?
+    ?, ?, ?, ?
 )
 RETURNING *;
 
@@ -39,21 +40,21 @@ WHERE id = ?;
 
 -- name: FindMealByID :one
 SELECT * FROM meals
-WHERE id = ? LIMIT 1;
+WHERE id = ? AND rotation_id = ? LIMIT 1;
 
 -- name: FindMealByDate :one
 SELECT * FROM meals
-WHERE year = ? AND month = ? AND day = ? LIMIT 1;
+WHERE year = ? AND month = ? AND day = ? AND rotation_id = ? LIMIT 1;
 
 -- name: FindMealBySlackMessageID :one
 SELECT * FROM meals
-WHERE slack_message_id = ? LIMIT 1;
+WHERE slack_message_id = ? AND rotation_id = ? LIMIT 1;
 
 -- name: CreateMeal :one
 INSERT INTO meals (
-    cook_slack_uid, year, month, day
+    cook_slack_uid, year, month, day, rotation_id
 ) VALUES (
-    ?, ?, ?, ?
+    ?, ?, ?, ?, ?
 )
 RETURNING *;
 
@@ -72,9 +73,417 @@ UPDATE meals
 set cook_slack_uid = ?, updated_at = datetime('now')
 WHERE id = ?;
 
+-- name: ListMealCooks :many
+SELECT slack_uid FROM meal_cooks
+WHERE meal_id = ?
+ORDER BY position ASC;
+
+-- name: CreateMealCook :exec
+INSERT INTO meal_cooks (
+    meal_id, slack_uid, position
+) VALUES (
+    ?, ?, ?
+);
+
+-- name: DeleteMealCooks :exec
+DELETE FROM meal_cooks
+WHERE meal_id = ?;
+
 -- name: DeleteMeal :exec
 DELETE FROM meals
 WHERE id = ?;
 
 -- name: CountMealsByDate :one
-SELECT count(*) FROM meals WHERE year = ? AND month = ? AND day = ?;
+SELECT count(*) FROM meals WHERE year = ? AND month = ? AND day = ? AND rotation_id = ?;
+
+-- name: IncrementMemberMealsEaten :exec
+UPDATE members
+set meals_eaten = meals_eaten + 1, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: DecrementMemberMealsEaten :execrows
+UPDATE members
+set meals_eaten = meals_eaten - 1, updated_at = datetime('now')
+WHERE id = ? AND meals_eaten > 0;
+
+-- name: ListMemberAttendanceCounts :many
+SELECT members.id, members.slack_uid, members.full_name, members.rotation_id, members.meals_eaten,
+    CAST((SELECT count(*) FROM attendances
+        WHERE attendances.member_id = members.id
+        AND attendances.created_at > COALESCE((SELECT max(seasons.closed_at) FROM seasons WHERE seasons.rotation_id = members.rotation_id), '')
+    ) AS INTEGER) AS attendances
+FROM members
+ORDER BY members.rotation_id, members.id;
+
+-- name: FindAttendance :one
+SELECT * FROM attendances
+WHERE meal_id = ? AND member_id = ? LIMIT 1;
+
+-- name: ListAttendancesByMeal :many
+SELECT * FROM attendances
+WHERE meal_id = ?
+ORDER BY created_at ASC, id ASC;
+
+-- name: ListAttendancesByMember :many
+SELECT * FROM attendances
+WHERE member_id = ?
+ORDER BY created_at ASC, id ASC;
+
+-- name: CreateAttendance :execrows
+INSERT INTO attendances (
+    meal_id, member_id, source
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (meal_id, member_id) DO NOTHING;
+
+-- name: DeleteAttendance :execrows
+DELETE FROM attendances
+WHERE meal_id = ? AND member_id = ?;
+
+-- name: FindJobRun :one
+SELECT * FROM job_runs
+WHERE name = ? LIMIT 1;
+
+-- name: UpsertJobRun :exec
+INSERT INTO job_runs (
+    name, last_run_at, last_success_at, last_error
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (name) DO UPDATE SET
+    last_run_at = excluded.last_run_at,
+    last_success_at = excluded.last_success_at,
+    last_error = excluded.last_error;
+
+-- name: FindTokenByHash :one
+SELECT * FROM tokens
+WHERE hash = ? AND revoked_at IS NULL LIMIT 1;
+
+-- name: ListTokens :many
+SELECT tokens.* FROM tokens
+JOIN members ON members.id = tokens.member_id
+WHERE members.rotation_id = ?
+ORDER BY tokens.id ASC;
+
+-- name: CreateToken :one
+INSERT INTO tokens (
+    member_id, name, scope, hash
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: RevokeToken :execrows
+UPDATE tokens
+set revoked_at = datetime('now')
+WHERE id = ? AND revoked_at IS NULL
+AND member_id IN (SELECT id FROM members WHERE rotation_id = ?);
+
+-- name: ListMeals :many
+SELECT * FROM meals
+WHERE (year * 10000 + month * 100 + day) BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date) AND rotation_id = sqlc.arg(rotation_id)
+ORDER BY year ASC, month ASC, day ASC;
+
+-- name: ListScheduledMealsBefore :many
+SELECT * FROM meals
+WHERE (year * 10000 + month * 100 + day) < sqlc.arg(before_date) AND status = sqlc.arg(status) AND rotation_id = sqlc.arg(rotation_id)
+ORDER BY year ASC, month ASC, day ASC;
+
+-- name: ListAvailabilities :many
+SELECT availabilities.* FROM availabilities
+JOIN members ON members.id = availabilities.member_id
+WHERE availabilities.from_date <= sqlc.arg(to_date) AND availabilities.to_date >= sqlc.arg(from_date) AND members.rotation_id = sqlc.arg(rotation_id)
+ORDER BY availabilities.from_date ASC, availabilities.id ASC;
+
+-- name: ListAvailabilitiesByMember :many
+SELECT * FROM availabilities
+WHERE member_id = ?
+ORDER BY from_date ASC, id ASC;
+
+-- name: CreateAvailability :one
+INSERT INTO availabilities (
+    member_id, from_date, to_date, cannot_cook, not_eating, note
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: DeleteAvailability :execrows
+DELETE FROM availabilities
+WHERE id = ?;
+
+-- name: CloseMeal :execrows
+UPDATE meals
+set status = ?, closed_at = datetime('now'), updated_at = datetime('now')
+WHERE id = ? AND status = 'scheduled';
+
+-- name: ReopenMeal :execrows
+UPDATE meals
+set status = 'scheduled', closed_at = NULL, slack_message_id = NULL, headcount_sent_at = NULL, rsvps_closed_at = NULL, updated_at = datetime('now')
+WHERE id = ? AND status = 'cancelled';
+
+-- name: CreditMemberMealCookedBySlackUID :execrows
+UPDATE members
+set meals_cooked = meals_cooked + ?, cooking_credits = cooking_credits + ?, updated_at = datetime('now')
+WHERE slack_uid = ? AND rotation_id = ?;
+
+-- name: AverageCookedMealHeadcount :one
+SELECT CAST(COALESCE(AVG(
+    (SELECT count(*) FROM attendances WHERE attendances.meal_id = meals.id) +
+    (SELECT COALESCE(SUM(guests.count), 0) FROM guests WHERE guests.meal_id = meals.id)
+), 0) AS REAL) AS average_headcount
+FROM meals
+WHERE rotation_id = ? AND status = ?;
+
+-- name: DeleteAttendancesByMeal :exec
+DELETE FROM attendances
+WHERE meal_id = ?;
+
+-- name: UpdateMealTitle :exec
+UPDATE meals
+set title = ?, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: UpdateMealTags :exec
+UPDATE meals
+set tags = ?, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: FindDietaryProfile :one
+SELECT * FROM dietary_profiles
+WHERE member_id = ? LIMIT 1;
+
+-- name: ListDietaryProfiles :many
+SELECT dietary_profiles.* FROM dietary_profiles
+JOIN members ON members.id = dietary_profiles.member_id
+WHERE members.rotation_id = ?
+ORDER BY dietary_profiles.member_id;
+
+-- name: UpsertDietaryProfile :exec
+INSERT INTO dietary_profiles (
+    member_id, restrictions, allergies
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (member_id) DO UPDATE
+SET restrictions = excluded.restrictions, allergies = excluded.allergies, updated_at = datetime('now');
+
+-- name: CreateDietWarning :exec
+INSERT OR IGNORE INTO diet_warnings (
+    meal_id, member_id
+) VALUES (
+    ?, ?
+);
+
+-- name: ListDietWarningsByMeal :many
+SELECT member_id FROM diet_warnings
+WHERE meal_id = ?
+ORDER BY member_id ASC;
+
+-- name: DeleteDietWarningsByMeal :exec
+DELETE FROM diet_warnings
+WHERE meal_id = ?;
+
+-- name: MarkMealHeadcountSent :execrows
+UPDATE meals
+set headcount_sent_at = datetime('now'), updated_at = datetime('now')
+WHERE id = ? AND headcount_sent_at IS NULL;
+
+-- name: CreateHeadcountDelivery :exec
+INSERT OR IGNORE INTO headcount_deliveries (
+    meal_id, slack_uid
+) VALUES (
+    ?, ?
+);
+
+-- name: ListHeadcountDeliveriesByMeal :many
+SELECT slack_uid FROM headcount_deliveries
+WHERE meal_id = ?
+ORDER BY slack_uid ASC;
+
+-- name: DeleteHeadcountDeliveriesByMeal :exec
+DELETE FROM headcount_deliveries
+WHERE meal_id = ?;
+
+-- name: UpdateMealRSVPDeadline :exec
+UPDATE meals
+set rsvp_deadline = ?, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: CloseMealRSVPs :execrows
+UPDATE meals
+set rsvps_closed_at = datetime('now'), updated_at = datetime('now')
+WHERE id = ? AND rsvps_closed_at IS NULL;
+
+-- name: CreateLateRSVP :one
+INSERT INTO late_rsvps (
+    meal_id, member_id, eating
+) VALUES (
+    ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListLateRSVPsByMeal :many
+SELECT * FROM late_rsvps
+WHERE meal_id = ?
+ORDER BY id ASC;
+
+-- name: DeleteLateRSVPsByMeal :exec
+DELETE FROM late_rsvps
+WHERE meal_id = ?;
+
+-- name: UpsertGuests :exec
+INSERT INTO guests (
+    meal_id, member_id, count
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (meal_id, member_id) DO UPDATE
+SET count = excluded.count, updated_at = datetime('now');
+
+-- name: DeleteGuests :exec
+DELETE FROM guests
+WHERE meal_id = ? AND member_id = ?;
+
+-- name: DeleteGuestsByMeal :exec
+DELETE FROM guests
+WHERE meal_id = ?;
+
+-- name: ListGuestsByMeal :many
+SELECT * FROM guests
+WHERE meal_id = ?
+ORDER BY member_id ASC;
+
+-- name: ListGuestsByMember :many
+SELECT * FROM guests
+WHERE member_id = ?
+ORDER BY meal_id ASC;
+
+-- name: CreateProcessedEvent :execrows
+INSERT INTO processed_events (
+    event_id
+) VALUES (
+    ?
+)
+ON CONFLICT (event_id) DO NOTHING;
+
+-- name: DeleteProcessedEvent :exec
+DELETE FROM processed_events
+WHERE event_id = ?;
+
+-- name: DeleteProcessedEventsBefore :execrows
+DELETE FROM processed_events
+WHERE processed_at < ?;
+
+-- name: FindRotationByID :one
+SELECT * FROM rotations
+WHERE id = ? LIMIT 1;
+
+-- name: FindRotationByName :one
+SELECT * FROM rotations
+WHERE name = ? LIMIT 1;
+
+-- name: ListRotations :many
+SELECT * FROM rotations
+ORDER BY id ASC;
+
+-- name: CreateRotation :one
+INSERT INTO rotations (
+    name, slack_channel
+) VALUES (
+    ?, ?
+)
+RETURNING *;
+
+-- name: UpdateRotationSlackChannel :exec
+UPDATE rotations
+set slack_channel = ?, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: CreateExpense :one
+INSERT INTO expenses (
+    meal_id, member_id, amount_cents, note
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListExpensesByMeal :many
+SELECT * FROM expenses
+WHERE meal_id = ?
+ORDER BY id ASC;
+
+-- name: ListExpenses :many
+SELECT expenses.* FROM expenses
+JOIN meals ON meals.id = expenses.meal_id
+WHERE meals.rotation_id = ?
+ORDER BY expenses.id ASC;
+
+-- name: FindExpenseByID :one
+SELECT expenses.* FROM expenses
+JOIN meals ON meals.id = expenses.meal_id
+WHERE expenses.id = ? AND meals.rotation_id = ? LIMIT 1;
+
+-- name: DeleteExpense :execrows
+DELETE FROM expenses
+WHERE id = ?;
+
+-- name: CreatePayment :one
+INSERT INTO payments (
+    from_member_id, to_member_id, amount_cents, note
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListPayments :many
+SELECT payments.* FROM payments
+JOIN members ON members.id = payments.from_member_id
+WHERE members.rotation_id = ?
+ORDER BY payments.id ASC;
+
+-- name: CreateSeason :one
+INSERT INTO seasons (
+    rotation_id, name, from_date, to_date
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: FindSeasonByID :one
+SELECT * FROM seasons
+WHERE id = ? AND rotation_id = ? LIMIT 1;
+
+-- name: FindLatestSeason :one
+SELECT * FROM seasons
+WHERE rotation_id = ?
+ORDER BY to_date DESC, id DESC LIMIT 1;
+
+-- name: ListSeasons :many
+SELECT * FROM seasons
+WHERE rotation_id = ?
+ORDER BY to_date ASC, id ASC;
+
+-- name: CreateSeasonStanding :exec
+INSERT INTO season_standings (
+    season_id, member_id, meals_eaten, meals_cooked, cooking_credits
+) VALUES (
+    ?, ?, ?, ?, ?
+);
+
+-- name: ListSeasonStandings :many
+SELECT * FROM season_standings
+WHERE season_id = ?
+ORDER BY member_id ASC;
+
+-- name: ListSeasonStandingsByRotation :many
+SELECT season_standings.* FROM season_standings
+JOIN seasons ON seasons.id = season_standings.season_id
+WHERE seasons.rotation_id = ?
+ORDER BY season_standings.season_id ASC, season_standings.member_id ASC;
+
+-- name: ResetMemberCounters :exec
+UPDATE members
+set meals_eaten = 0, meals_cooked = 0, cooking_credits = 0, updated_at = datetime('now')
+WHERE rotation_id = ?;
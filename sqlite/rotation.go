@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.RotationService = (*RotationService)(nil)
+
+// RotationService represents a service for managing rotations.
+type RotationService struct {
+	query *gen.Queries
+	db    *sql.DB
+}
+
+// NewRotationService returns a new instance of RotationService.
+func NewRotationService(query *gen.Queries, db *sql.DB) *RotationService {
+	return &RotationService{query, db}
+}
+
+// toDinnyRotation converts a gen.Rotation to a dinny.Rotation.
+func toDinnyRotation(r gen.Rotation) *dinny.Rotation {
+	return &dinny.Rotation{
+		ID:           r.ID,
+		Name:         r.Name,
+		SlackChannel: r.SlackChannel,
+	}
+}
+
+// FindRotationByID retrieves a rotation by ID.
+// Returns ErrNotFound if the rotation does not exist.
+func (rs *RotationService) FindRotationByID(id int64) (*dinny.Rotation, error) {
+	r, err := rs.query.FindRotationByID(context.Background(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, dinny.ErrNotFound
+		}
+		return nil, fmt.Errorf("FindRotationByID: %w", err)
+	}
+	return toDinnyRotation(r), nil
+}
+
+// FindRotationByName retrieves a rotation by name.
+// Returns ErrNotFound if the rotation does not exist.
+func (rs *RotationService) FindRotationByName(name string) (*dinny.Rotation, error) {
+	r, err := rs.query.FindRotationByName(context.Background(), name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, dinny.ErrNotFound
+		}
+		return nil, fmt.Errorf("FindRotationByName: %w", err)
+	}
+	return toDinnyRotation(r), nil
+}
+
+// ListRotations retrieves every rotation.
+func (rs *RotationService) ListRotations() ([]*dinny.Rotation, error) {
+	rots, err := rs.query.ListRotations(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("ListRotations: %w", err)
+	}
+	var rotations []*dinny.Rotation
+	for _, r := range rots {
+		rotations = append(rotations, toDinnyRotation(r))
+	}
+	return rotations, nil
+}
+
+// CreateRotation creates a new rotation. Sets the ID of r on success.
+func (rs *RotationService) CreateRotation(r *dinny.Rotation) error {
+	params := gen.CreateRotationParams{
+		Name:         r.Name,
+		SlackChannel: r.SlackChannel,
+	}
+	rot, err := rs.query.CreateRotation(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CreateRotation: %w", err)
+	}
+	r.ID = rot.ID
+	return nil
+}
+
+// UpdateRotation updates a rotation.
+func (rs *RotationService) UpdateRotation(id int64, upd dinny.RotationUpdate) error {
+	if upd.SlackChannel != nil {
+		params := gen.UpdateRotationSlackChannelParams{SlackChannel: *upd.SlackChannel, ID: id}
+		if err := rs.query.UpdateRotationSlackChannel(context.Background(), params); err != nil {
+			return fmt.Errorf("UpdateRotation UpdateRotationSlackChannel: %w", err)
+		}
+	}
+	return nil
+}
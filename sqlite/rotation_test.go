@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// TestRotationScoping ensures rotations can share Slack users and dates without seeing each other's members and meals.
+func TestRotationScoping(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	rotationService := NewRotationService(queries, db)
+
+	other := &dinny.Rotation{Name: "second-floor", SlackChannel: "C2"}
+	if err := rotationService.CreateRotation(other); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	var memberIDs []int64
+	for _, rotationID := range []int64{dinny.DefaultRotationID, other.ID} {
+		memberService := NewMemberService(queries, db).ForRotation(rotationID)
+		mealService := NewMealService(queries, db).ForRotation(rotationID)
+		m := &dinny.Member{SlackUID: "U1", FullName: "Jane Doe"}
+		if err := memberService.CreateMember(m); err != nil {
+			t.Fatalf("CreateMember in rotation %d: %v", rotationID, err)
+		}
+		memberIDs = append(memberIDs, m.ID)
+		if err := mealService.AssignCook(date, m.SlackUID); err != nil {
+			t.Fatalf("AssignCook in rotation %d: %v", rotationID, err)
+		}
+	}
+
+	memberService := NewMemberService(queries, db)
+	members, err := memberService.ListMembers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].ID != memberIDs[0] {
+		t.Errorf("ListMembers() = %v, want only member %d", members, memberIDs[0])
+	}
+	if _, err := memberService.FindMemberByID(memberIDs[1]); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("FindMemberByID() of another rotation's member err = %v, want ErrNotFound", err)
+	}
+
+	meal, err := NewMealService(queries, db).ForRotation(other.ID).FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meal.RotationID != other.ID {
+		t.Errorf("RotationID = %d, want %d", meal.RotationID, other.ID)
+	}
+	if _, err := NewMealService(queries, db).FindMealByID(meal.ID); !errors.Is(err, dinny.ErrNotFound) {
+		t.Errorf("FindMealByID() of another rotation's meal err = %v, want ErrNotFound", err)
+	}
+
+	channel := "C3"
+	if err := rotationService.UpdateRotation(other.ID, dinny.RotationUpdate{SlackChannel: &channel}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := rotationService.FindRotationByName(other.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.SlackChannel != channel {
+		t.Errorf("SlackChannel = %q, want %q", r.SlackChannel, channel)
+	}
+}
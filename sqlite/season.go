@@ -0,0 +1,253 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.SeasonService = (*SeasonService)(nil)
+
+// SeasonService represents a service for closing seasons and looking up the archived counters of past ones.
+type SeasonService struct {
+	query *gen.Queries
+	db    *sql.DB
+
+	// rotationID is the rotation the service is scoped to.
+	rotationID int64
+}
+
+// NewSeasonService returns a new instance of SeasonService scoped to the default rotation.
+func NewSeasonService(query *gen.Queries, db *sql.DB) *SeasonService {
+	return &SeasonService{query, db, dinny.DefaultRotationID}
+}
+
+// ForRotation returns a copy of the service scoped to the rotation with the given ID.
+func (ss *SeasonService) ForRotation(rotationID int64) *SeasonService {
+	scoped := *ss
+	scoped.rotationID = rotationID
+	return &scoped
+}
+
+// toDinnySeason converts a gen.Season to a dinny.Season.
+func toDinnySeason(s gen.Season) (*dinny.Season, error) {
+	season := &dinny.Season{
+		ID:         s.ID,
+		RotationID: s.RotationID,
+		Name:       s.Name,
+		ClosedAt:   parseTime(s.ClosedAt),
+	}
+	if s.FromDate != "" {
+		from, err := dinny.ParseDate(s.FromDate)
+		if err != nil {
+			return nil, fmt.Errorf("toDinnySeason from: %w", err)
+		}
+		season.From = from
+	}
+	to, err := dinny.ParseDate(s.ToDate)
+	if err != nil {
+		return nil, fmt.Errorf("toDinnySeason to: %w", err)
+	}
+	season.To = to
+	return season, nil
+}
+
+// toDinnyMembers converts gen.Members to dinny.Members.
+func toDinnyMembers(mems []gen.Member) []*dinny.Member {
+	members := make([]*dinny.Member, len(mems))
+	for ii, m := range mems {
+		members[ii] = &dinny.Member{
+			ID:             m.ID,
+			SlackUID:       m.SlackUid,
+			FullName:       m.FullName,
+			MealsEaten:     m.MealsEaten,
+			MealsCooked:    m.MealsCooked,
+			Leader:         m.Leader == 1,
+			RotationID:     m.RotationID,
+			CookingCredits: m.CookingCredits,
+		}
+	}
+	return members
+}
+
+// currentSeasonStart returns the day after the last season was closed, or the zero Date if none was.
+func (ss *SeasonService) currentSeasonStart(q *gen.Queries) (dinny.Date, error) {
+	s, err := q.FindLatestSeason(context.Background(), ss.rotationID)
+	if err == sql.ErrNoRows {
+		return dinny.Date{}, nil
+	} else if err != nil {
+		return dinny.Date{}, fmt.Errorf("currentSeasonStart FindLatestSeason: %w", err)
+	}
+	to, err := dinny.ParseDate(s.ToDate)
+	if err != nil {
+		return dinny.Date{}, fmt.Errorf("currentSeasonStart: %w", err)
+	}
+	return to.AddDays(1), nil
+}
+
+// CurrentSeasonStart returns the first day of the season in progress, the day after the last season was closed.
+// Returns the zero Date if no season has been closed yet.
+func (ss *SeasonService) CurrentSeasonStart() (dinny.Date, error) {
+	start, err := ss.currentSeasonStart(ss.query)
+	if err != nil {
+		return dinny.Date{}, fmt.Errorf("CurrentSeasonStart: %w", err)
+	}
+	return start, nil
+}
+
+// ListSeasons retrieves the closed seasons, oldest first, without their members.
+func (ss *SeasonService) ListSeasons() ([]*dinny.Season, error) {
+	rows, err := ss.query.ListSeasons(context.Background(), ss.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListSeasons: %w", err)
+	}
+	var seasons []*dinny.Season
+	for _, s := range rows {
+		season, err := toDinnySeason(s)
+		if err != nil {
+			return nil, fmt.Errorf("ListSeasons: %w", err)
+		}
+		seasons = append(seasons, season)
+	}
+	return seasons, nil
+}
+
+// FindSeasonByID retrieves a closed season along with its members.
+// Returns ErrNotFound if the season does not exist.
+func (ss *SeasonService) FindSeasonByID(id int64) (*dinny.Season, error) {
+	tx, err := ss.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("FindSeasonByID db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ss.query.WithTx(tx)
+	s, err := qtx.FindSeasonByID(context.Background(), gen.FindSeasonByIDParams{ID: id, RotationID: ss.rotationID})
+	if err == sql.ErrNoRows {
+		return nil, dinny.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("FindSeasonByID: %w", err)
+	}
+	season, err := toDinnySeason(s)
+	if err != nil {
+		return nil, fmt.Errorf("FindSeasonByID: %w", err)
+	}
+
+	mems, err := qtx.ListMembers(context.Background(), ss.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("FindSeasonByID ListMembers: %w", err)
+	}
+	members := make(map[int64]*dinny.Member, len(mems))
+	for _, m := range toDinnyMembers(mems) {
+		members[m.ID] = m
+	}
+	standings, err := qtx.ListSeasonStandings(context.Background(), id)
+	if err != nil {
+		return nil, fmt.Errorf("FindSeasonByID ListSeasonStandings: %w", err)
+	}
+	for _, st := range standings {
+		m, ok := members[st.MemberID]
+		if !ok {
+			continue
+		}
+		m.MealsEaten, m.MealsCooked, m.CookingCredits = st.MealsEaten, st.MealsCooked, st.CookingCredits
+		season.Members = append(season.Members, m)
+	}
+	return season, nil
+}
+
+// CloseSeason closes the season in progress on s.To under s.Name, archiving the counters of every member and resetting them.
+// Sets the ID, From and ClosedAt of s on success.
+func (ss *SeasonService) CloseSeason(s *dinny.Season) error {
+	tx, err := ss.db.Begin()
+	if err != nil {
+		return fmt.Errorf("CloseSeason db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ss.query.WithTx(tx)
+
+	from, err := ss.currentSeasonStart(qtx)
+	if err != nil {
+		return fmt.Errorf("CloseSeason: %w", err)
+	}
+	if from != (dinny.Date{}) && s.To.Before(from) {
+		return fmt.Errorf("CloseSeason: the season in progress started on %s, after %s", from, s.To)
+	}
+	params := gen.CreateSeasonParams{
+		RotationID: ss.rotationID,
+		Name:       s.Name,
+		ToDate:     s.To.String(),
+	}
+	if from != (dinny.Date{}) {
+		params.FromDate = from.String()
+	}
+	created, err := qtx.CreateSeason(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CloseSeason CreateSeason: %w", err)
+	}
+
+	mems, err := qtx.ListMembers(context.Background(), ss.rotationID)
+	if err != nil {
+		return fmt.Errorf("CloseSeason ListMembers: %w", err)
+	}
+	for _, m := range mems {
+		arg := gen.CreateSeasonStandingParams{
+			SeasonID:       created.ID,
+			MemberID:       m.ID,
+			MealsEaten:     m.MealsEaten,
+			MealsCooked:    m.MealsCooked,
+			CookingCredits: m.CookingCredits,
+		}
+		err := qtx.CreateSeasonStanding(context.Background(), arg)
+		if err != nil {
+			return fmt.Errorf("CloseSeason CreateSeasonStanding: %w", err)
+		}
+	}
+	err = qtx.ResetMemberCounters(context.Background(), ss.rotationID)
+	if err != nil {
+		return fmt.Errorf("CloseSeason ResetMemberCounters: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("CloseSeason tx.Commit: %w", err)
+	}
+	s.ID = created.ID
+	s.RotationID = created.RotationID
+	s.From = from
+	s.ClosedAt = parseTime(created.ClosedAt)
+	return nil
+}
+
+// ListAllTimeMembers retrieves the members with their counters summed over every season, the current one included.
+func (ss *SeasonService) ListAllTimeMembers() ([]*dinny.Member, error) {
+	tx, err := ss.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllTimeMembers db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ss.query.WithTx(tx)
+
+	mems, err := qtx.ListMembers(context.Background(), ss.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListAllTimeMembers ListMembers: %w", err)
+	}
+	members := toDinnyMembers(mems)
+	byID := make(map[int64]*dinny.Member, len(members))
+	for _, m := range members {
+		byID[m.ID] = m
+	}
+	standings, err := qtx.ListSeasonStandingsByRotation(context.Background(), ss.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListAllTimeMembers ListSeasonStandingsByRotation: %w", err)
+	}
+	for _, st := range standings {
+		if m, ok := byID[st.MemberID]; ok {
+			m.AddCounters(&dinny.Member{MealsEaten: st.MealsEaten, MealsCooked: st.MealsCooked, CookingCredits: st.CookingCredits})
+		}
+	}
+	return members, nil
+}
@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// TestCloseSeason ensures closing a season archives and resets the counters of the members, and all-time counters add the seasons up.
+func TestCloseSeason(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	seasonService := NewSeasonService(queries, db)
+
+	member := &dinny.Member{SlackUID: "U1", FullName: "U1"}
+	if err := memberService.CreateMember(member); err != nil {
+		t.Fatal(err)
+	}
+	eaten := int64(4)
+	if err := memberService.UpdateMember(member.ID, dinny.MemberUpdate{MealsEaten: &eaten}); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 3, Day: 1}
+	if err := mealService.AssignCook(date, "U1"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mealService.CompleteMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if start, err := seasonService.CurrentSeasonStart(); err != nil || start != (dinny.Date{}) {
+		t.Errorf("CurrentSeasonStart() = %s, %v, want the zero date", start, err)
+	}
+	winter := &dinny.Season{Name: "winter", To: dinny.Date{Year: 2023, Month: 3, Day: 31}}
+	if err := seasonService.CloseSeason(winter); err != nil {
+		t.Fatal(err)
+	}
+	if err := seasonService.CloseSeason(&dinny.Season{Name: "too early", To: winter.To}); err == nil {
+		t.Error("CloseSeason() ending before the season in progress started succeeded")
+	}
+	if start, err := seasonService.CurrentSeasonStart(); err != nil || start != winter.To.AddDays(1) {
+		t.Errorf("CurrentSeasonStart() = %s, %v, want %s", start, err, winter.To.AddDays(1))
+	}
+
+	live, err := memberService.FindMemberByID(member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live.MealsEaten != 0 || live.MealsCooked != 0 || live.CookingCredits != 0 {
+		t.Errorf("counters after closing = %d, %v, %v, want 0, 0, 0", live.MealsEaten, live.MealsCooked, live.CookingCredits)
+	}
+	archived, err := seasonService.FindSeasonByID(winter.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived.Members) != 1 || archived.Members[0].MealsEaten != 4 || archived.Members[0].MealsCooked != 1 || archived.From != (dinny.Date{}) {
+		t.Errorf("FindSeasonByID() = %+v, want U1 with 4 eaten and 1 cooked from the zero date", archived)
+	}
+
+	cooked := float64(2)
+	if err := memberService.UpdateMember(member.ID, dinny.MemberUpdate{MealsCooked: &cooked}); err != nil {
+		t.Fatal(err)
+	}
+	members, err := seasonService.ListAllTimeMembers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].MealsEaten != 4 || members[0].MealsCooked != 3 || members[0].CookingCredits != 1 {
+		t.Errorf("ListAllTimeMembers() = %+v, want 4 eaten, 3 cooked and 1 credit", members[0])
+	}
+}
+
+// TestCloseSeasonAttendances ensures RSVPs counted towards a closed season can't be removed and cancelling their meal
+// leaves the meals eaten of the new season alone.
+func TestCloseSeasonAttendances(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+	seasonService := NewSeasonService(queries, db)
+
+	member := &dinny.Member{SlackUID: "U1", FullName: "U1"}
+	if err := memberService.CreateMember(member); err != nil {
+		t.Fatal(err)
+	}
+	date := dinny.Date{Year: 2023, Month: 4, Day: 1}
+	if err := mealService.AssignCook(date, "U2"); err != nil {
+		t.Fatal(err)
+	}
+	meal, err := mealService.FindMealByDate(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &dinny.Attendance{MealID: meal.ID, MemberID: member.ID, Source: dinny.AttendanceSourceReaction}
+	if err := attendanceService.CreateAttendance(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := seasonService.CloseSeason(&dinny.Season{Name: "winter", To: date.AddDays(-1)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := attendanceService.DeleteAttendance(meal.ID, member.ID); !errors.Is(err, dinny.ErrMealClosed) {
+		t.Errorf("DeleteAttendance() counted in a closed season err = %v, want ErrMealClosed", err)
+	}
+	if err := mealService.CancelMeal(meal.ID); err != nil {
+		t.Fatal(err)
+	}
+	live, err := memberService.FindMemberByID(member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live.MealsEaten != 0 {
+		t.Errorf("MealsEaten = %d, want 0", live.MealsEaten)
+	}
+}
@@ -1,54 +1,75 @@
 package sqlite
 
 import (
-	"context"
 	"database/sql"
-	_ "embed"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
-// embed the sqlite schema within the binary to create the tables at runtime.
-//
-//go:embed schema.sql
-var Schema string
-
-// Open creates a connection to the sqlite database.
+// Open creates a connection to the sqlite database and applies any pending migrations.
 func Open(DSN string) (*sql.DB, error) {
+	db, err := Connect(DSN)
+	if err != nil {
+		return nil, fmt.Errorf("Open: %w", err)
+	}
+
+	// Bring the schema up to date.
+	if err := MigrateUp(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("Open: %w", err)
+	}
+
+	return db, nil
+}
+
+// Connect creates a connection to the sqlite database without touching the schema.
+func Connect(DSN string) (*sql.DB, error) {
 	// Ensure a DSN is set before attempting to open the database.
 	if DSN == "" {
-		return nil, fmt.Errorf("Open: dsn required")
+		return nil, fmt.Errorf("Connect: dsn required")
 	}
 
 	// Make the parent directory unless using an in-memory db.
 	if DSN != ":memory:" {
 		if err := os.MkdirAll(filepath.Dir(DSN), 0700); err != nil {
-			return nil, fmt.Errorf("Open os.MkdirAll: %w", err)
+			return nil, fmt.Errorf("Connect os.MkdirAll: %w", err)
 		}
 	}
 
 	// Connect to the database.
 	db, err := sql.Open("sqlite3", DSN)
 	if err != nil {
-		return nil, fmt.Errorf("Open sql.Open: %w", err)
+		return nil, fmt.Errorf("Connect sql.Open: %w", err)
 	}
 
 	// Enable WAL.
 	if _, err := db.Exec(`PRAGMA journal_mode = wal;`); err != nil {
-		return nil, fmt.Errorf("Open enable wal: %w", err)
+		return nil, fmt.Errorf("Connect enable wal: %w", err)
 	}
 
 	// Enable foreign key checks.
 	if _, err := db.Exec(`PRAGMA foreign_keys = ON;`); err != nil {
-		return nil, fmt.Errorf("Open foreign keys pragma: %w", err)
+		return nil, fmt.Errorf("Connect foreign keys pragma: %w", err)
 	}
 
-	// Create tables if they don't exist.
-	_, err = db.ExecContext(context.Background(), Schema)
+	return db, nil
+}
+
+// timeLayout represents the layout of sqlite's datetime('now').
+const timeLayout = "2006-01-02 15:04:05"
+
+// parseTime converts a sqlite datetime into a time.Time. Returns the zero time if the value can't be parsed.
+func parseTime(s string) time.Time {
+	t, err := time.ParseInLocation(timeLayout, s, time.UTC)
 	if err != nil {
-		return nil, fmt.Errorf("Open db.ExecContext: %w", err)
+		return time.Time{}
 	}
+	return t
+}
 
-	return db, nil
+// formatTime converts a time.Time into a sqlite datetime.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
 }
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.StatsService = (*StatsService)(nil)
+
+// StatsService represents a service for computing stats over the meal history.
+type StatsService struct {
+	query *gen.Queries
+	db    *sql.DB
+
+	// rotationID is the rotation the service is scoped to.
+	rotationID int64
+}
+
+// NewStatsService returns a new instance of StatsService scoped to the default rotation.
+func NewStatsService(query *gen.Queries, db *sql.DB) *StatsService {
+	return &StatsService{query, db, dinny.DefaultRotationID}
+}
+
+// ForRotation returns a copy of the service scoped to the rotation with the given ID.
+func (ss *StatsService) ForRotation(rotationID int64) *StatsService {
+	scoped := *ss
+	scoped.rotationID = rotationID
+	return &scoped
+}
+
+// Stats computes the stats of every member from the meals cooked between from and to, inclusive.
+func (ss *StatsService) Stats(from dinny.Date, to dinny.Date) (*dinny.Stats, error) {
+	tx, err := ss.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("Stats db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := ss.query.WithTx(tx)
+
+	mems, err := qtx.ListMembers(context.Background(), ss.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("Stats ListMembers: %w", err)
+	}
+	members := make([]*dinny.Member, len(mems))
+	memberIDs := make(map[string]int64, len(mems))
+	for ii, m := range mems {
+		members[ii] = &dinny.Member{ID: m.ID, SlackUID: m.SlackUid, FullName: m.FullName}
+		memberIDs[m.SlackUid] = m.ID
+	}
+
+	params := gen.ListMealsParams{
+		FromDate:   dateKey(from),
+		ToDate:     dateKey(to),
+		RotationID: ss.rotationID,
+	}
+	rows, err := qtx.ListMeals(context.Background(), params)
+	if err != nil {
+		return nil, fmt.Errorf("Stats ListMeals: %w", err)
+	}
+	var meals []dinny.CookedMeal
+	for _, m := range rows {
+		if m.Status != dinny.MealStatusCooked {
+			continue
+		}
+		meal := dinny.CookedMeal{Date: dinny.Date{Year: int(m.Year), Month: time.Month(m.Month), Day: int(m.Day)}}
+		cooks, err := qtx.ListMealCooks(context.Background(), m.ID)
+		if err != nil {
+			return nil, fmt.Errorf("Stats ListMealCooks: %w", err)
+		}
+		for _, cook := range cooks {
+			if id, ok := memberIDs[cook]; ok {
+				meal.Cooks = append(meal.Cooks, id)
+			}
+		}
+		atts, err := qtx.ListAttendancesByMeal(context.Background(), m.ID)
+		if err != nil {
+			return nil, fmt.Errorf("Stats ListAttendancesByMeal: %w", err)
+		}
+		for _, a := range atts {
+			meal.Eaters = append(meal.Eaters, a.MemberID)
+		}
+		gs, err := qtx.ListGuestsByMeal(context.Background(), m.ID)
+		if err != nil {
+			return nil, fmt.Errorf("Stats ListGuestsByMeal: %w", err)
+		}
+		for _, g := range gs {
+			meal.Guests += g.Count
+		}
+		meals = append(meals, meal)
+	}
+	return dinny.ComputeStats(from, to, members, meals), nil
+}
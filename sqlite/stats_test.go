@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// TestStats ensures only the meals cooked within the range count towards the stats, co-cooks included.
+func TestStats(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := gen.New(db)
+	memberService := NewMemberService(queries, db)
+	mealService := NewMealService(queries, db)
+	attendanceService := NewAttendanceService(queries, db)
+	statsService := NewStatsService(queries, db)
+
+	var members []*dinny.Member
+	for _, uid := range []string{"U1", "U2"} {
+		m := &dinny.Member{SlackUID: uid, FullName: uid}
+		if err := memberService.CreateMember(m); err != nil {
+			t.Fatal(err)
+		}
+		members = append(members, m)
+	}
+	from := dinny.Date{Year: 2023, Month: 1, Day: 2}
+	for ii, cancel := range []bool{false, true, false} {
+		date := from.AddDays(ii * 7)
+		if err := mealService.AssignCooks(date, []string{"U1", "U2"}); err != nil {
+			t.Fatal(err)
+		}
+		meal, err := mealService.FindMealByDate(date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := &dinny.Attendance{MealID: meal.ID, MemberID: members[1].ID, Source: dinny.AttendanceSourceManual}
+		if err := attendanceService.CreateAttendance(a); err != nil {
+			t.Fatal(err)
+		}
+		if cancel {
+			err = mealService.CancelMeal(meal.ID)
+		} else {
+			err = mealService.CompleteMeal(meal.ID)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The meal of the third week is out of range and the one of the second was cancelled.
+	stats, err := statsService.Stats(from, from.AddDays(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Meals != 1 {
+		t.Errorf("Meals = %d, want 1", stats.Meals)
+	}
+	want := map[string]int64{"U1": 0, "U2": 1}
+	for _, ms := range stats.Members {
+		if ms.MealsEaten != want[ms.SlackUID] || ms.MealsCooked != 0.5 {
+			t.Errorf("%s MealsEaten, MealsCooked = %d, %v, want %d, 0.5", ms.SlackUID, ms.MealsEaten, ms.MealsCooked, want[ms.SlackUID])
+		}
+	}
+}
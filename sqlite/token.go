@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ddritzenhoff/dinny"
+	"github.com/ddritzenhoff/dinny/sqlite/gen"
+)
+
+// Ensure service implements interface.
+var _ dinny.TokenService = (*TokenService)(nil)
+
+// TokenService represents a service for managing API tokens.
+type TokenService struct {
+	query *gen.Queries
+	db    *sql.DB
+
+	// rotationID is the rotation the service is scoped to.
+	rotationID int64
+}
+
+// NewTokenService returns a new instance of TokenService scoped to the default rotation.
+func NewTokenService(query *gen.Queries, db *sql.DB) *TokenService {
+	return &TokenService{query, db, dinny.DefaultRotationID}
+}
+
+// ForRotation returns a copy of the service scoped to the rotation with the given ID.
+func (ts *TokenService) ForRotation(rotationID int64) *TokenService {
+	scoped := *ts
+	scoped.rotationID = rotationID
+	return &scoped
+}
+
+// toDinnyToken converts a gen.Token to a dinny.Token.
+func toDinnyToken(t gen.Token) *dinny.Token {
+	token := &dinny.Token{
+		ID:        t.ID,
+		MemberID:  t.MemberID,
+		Name:      t.Name,
+		Scope:     t.Scope,
+		Hash:      t.Hash,
+		CreatedAt: parseTime(t.CreatedAt),
+	}
+	if t.RevokedAt.Valid {
+		revokedAt := parseTime(t.RevokedAt.String)
+		token.RevokedAt = &revokedAt
+	}
+	return token
+}
+
+// FindTokenByHash retrieves an unrevoked token by the hash of its secret.
+// Returns ErrNotFound if token does not exist or has been revoked.
+func (ts *TokenService) FindTokenByHash(hash string) (*dinny.Token, error) {
+	t, err := ts.query.FindTokenByHash(context.Background(), hash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, dinny.ErrNotFound
+		} else {
+			return nil, fmt.Errorf("FindTokenByHash: %w", err)
+		}
+	}
+	return toDinnyToken(t), nil
+}
+
+// ListTokens retrieves a list of every token, including revoked ones.
+func (ts *TokenService) ListTokens() ([]*dinny.Token, error) {
+	toks, err := ts.query.ListTokens(context.Background(), ts.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListTokens: %w", err)
+	}
+	var tokens []*dinny.Token
+	for _, t := range toks {
+		tokens = append(tokens, toDinnyToken(t))
+	}
+	return tokens, nil
+}
+
+// CreateToken creates a new token. Sets the ID of t on success.
+func (ts *TokenService) CreateToken(t *dinny.Token) error {
+	params := gen.CreateTokenParams{
+		MemberID: t.MemberID,
+		Name:     t.Name,
+		Scope:    t.Scope,
+		Hash:     t.Hash,
+	}
+	tok, err := ts.query.CreateToken(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("CreateToken: %w", err)
+	}
+	t.ID = tok.ID
+	t.CreatedAt = parseTime(tok.CreatedAt)
+	return nil
+}
+
+// RevokeToken revokes a token so it can no longer be used.
+// Returns ErrNotFound if token does not exist or has already been revoked.
+func (ts *TokenService) RevokeToken(id int64) error {
+	n, err := ts.query.RevokeToken(context.Background(), gen.RevokeTokenParams{ID: id, RotationID: ts.rotationID})
+	if err != nil {
+		return fmt.Errorf("RevokeToken: %w", err)
+	}
+	if n == 0 {
+		return dinny.ErrNotFound
+	}
+	return nil
+}
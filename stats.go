@@ -0,0 +1,165 @@
+package dinny
+
+import (
+	"sort"
+	"time"
+)
+
+// MaxCoDiners is the number of most frequent co-diners listed per member.
+const MaxCoDiners = 3
+
+// Stats represents the per-member metrics of the meals cooked between From and To, inclusive.
+// Only meals closed out as cooked count, so cancelled and upcoming meals are left out.
+type Stats struct {
+	From    Date           `json:"from"`
+	To      Date           `json:"to"`
+	Meals   int64          `json:"meals"`
+	Members []*MemberStats `json:"members"`
+}
+
+// MemberStats represents the metrics of a single member over a date range.
+type MemberStats struct {
+	MemberID    int64   `json:"memberID"`
+	SlackUID    string  `json:"slackUID"`
+	FullName    string  `json:"fullName"`
+	MealsEaten  int64   `json:"mealsEaten"`
+	MealsCooked float64 `json:"mealsCooked"`
+
+	// Ratio is the meals eaten to meals cooked ratio over the range, see Member.Ratio.
+	Ratio float32 `json:"ratio"`
+
+	// RatioTrend is the ratio so far at the end of each week of the range with a meal.
+	RatioTrend []RatioPoint `json:"ratioTrend"`
+
+	// LongestStreak is the most meals in a row the member ate. CurrentStreak are those up to the last meal of the range.
+	LongestStreak int64 `json:"longestStreak"`
+	CurrentStreak int64 `json:"currentStreak"`
+
+	// AverageHeadcount is the average number of eaters, guests included, of the meals the member cooked.
+	AverageHeadcount float64 `json:"averageHeadcount"`
+
+	// CoDiners are the members the member ate with the most, most frequent first.
+	CoDiners []CoDiner `json:"coDiners"`
+}
+
+// RatioPoint represents the ratio of a member at the end of the week starting on the Monday Week.
+type RatioPoint struct {
+	Week  Date    `json:"week"`
+	Ratio float32 `json:"ratio"`
+}
+
+// CoDiner represents a member who ate the same meals as another member.
+type CoDiner struct {
+	MemberID int64  `json:"memberID"`
+	SlackUID string `json:"slackUID"`
+	Meals    int64  `json:"meals"`
+}
+
+// CookedMeal represents a meal closed out as cooked along with the member IDs of its cooks and eaters, as used to compute stats.
+type CookedMeal struct {
+	Date   Date
+	Cooks  []int64
+	Eaters []int64
+	Guests int64
+}
+
+// weekOf returns the Monday starting the week of d.
+func weekOf(d Date) Date {
+	return d.AddDays(-((int(d.Time().Weekday()) + 6) % 7))
+}
+
+// ComputeStats computes the stats of the members from the meals cooked between from and to, which must be ordered by date.
+// Cooks and eaters who aren't among the members are ignored.
+func ComputeStats(from Date, to Date, members []*Member, meals []CookedMeal) *Stats {
+	stats := &Stats{From: from, To: to, Meals: int64(len(meals)), Members: make([]*MemberStats, len(members))}
+	byID := make(map[int64]*MemberStats, len(members))
+	for ii, m := range members {
+		stats.Members[ii] = &MemberStats{MemberID: m.ID, SlackUID: m.SlackUID, FullName: m.FullName, RatioTrend: []RatioPoint{}, CoDiners: []CoDiner{}}
+		byID[m.ID] = stats.Members[ii]
+	}
+	headcounts := make(map[int64]int64)
+	cooked := make(map[int64]int64)
+	together := make(map[int64]map[int64]int64)
+
+	for ii, meal := range meals {
+		headcount := int64(len(meal.Eaters)) + meal.Guests
+		// Co-cooks share the meal cooked, as they do when the meal is closed out.
+		for _, id := range meal.Cooks {
+			if ms, ok := byID[id]; ok {
+				ms.MealsCooked += 1 / float64(len(meal.Cooks))
+				cooked[id]++
+				headcounts[id] += headcount
+			}
+		}
+		ate := make(map[int64]bool, len(meal.Eaters))
+		for _, id := range meal.Eaters {
+			ate[id] = true
+			if _, ok := together[id]; !ok {
+				together[id] = make(map[int64]int64)
+			}
+			for _, other := range meal.Eaters {
+				if other != id {
+					together[id][other]++
+				}
+			}
+		}
+		for _, ms := range stats.Members {
+			if ate[ms.MemberID] {
+				ms.MealsEaten++
+				ms.CurrentStreak++
+				if ms.CurrentStreak > ms.LongestStreak {
+					ms.LongestStreak = ms.CurrentStreak
+				}
+			} else {
+				ms.CurrentStreak = 0
+			}
+		}
+
+		// Close the week after its last meal.
+		week := weekOf(meal.Date)
+		if ii == len(meals)-1 || weekOf(meals[ii+1].Date) != week {
+			for _, ms := range stats.Members {
+				ms.RatioTrend = append(ms.RatioTrend, RatioPoint{Week: week, Ratio: ms.ratio()})
+			}
+		}
+	}
+
+	for _, ms := range stats.Members {
+		ms.Ratio = ms.ratio()
+		if cooked[ms.MemberID] > 0 {
+			ms.AverageHeadcount = float64(headcounts[ms.MemberID]) / float64(cooked[ms.MemberID])
+		}
+		for id, n := range together[ms.MemberID] {
+			if other, ok := byID[id]; ok {
+				ms.CoDiners = append(ms.CoDiners, CoDiner{MemberID: id, SlackUID: other.SlackUID, Meals: n})
+			}
+		}
+		sort.Slice(ms.CoDiners, func(ii, jj int) bool {
+			if ms.CoDiners[ii].Meals != ms.CoDiners[jj].Meals {
+				return ms.CoDiners[ii].Meals > ms.CoDiners[jj].Meals
+			}
+			return ms.CoDiners[ii].SlackUID < ms.CoDiners[jj].SlackUID
+		})
+		if len(ms.CoDiners) > MaxCoDiners {
+			ms.CoDiners = ms.CoDiners[:MaxCoDiners]
+		}
+	}
+	return stats
+}
+
+// ratio calculates the meals eaten to meals cooked ratio so far.
+func (ms *MemberStats) ratio() float32 {
+	return (&Member{MealsEaten: ms.MealsEaten, MealsCooked: ms.MealsCooked}).Ratio()
+}
+
+// StatsService represents a service for computing stats over the meal history.
+type StatsService interface {
+	// Stats computes the stats of every member from the meals cooked between from and to, inclusive.
+	Stats(from Date, to Date) (*Stats, error)
+}
+
+// DefaultStatsRange returns the default range of stats ending today: the last four weeks.
+func DefaultStatsRange(now time.Time) (Date, Date) {
+	today := DateOf(now)
+	return today.AddDays(-27), today
+}
@@ -0,0 +1,52 @@
+package dinny
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestComputeStats ensures the counts, with co-cooks sharing a meal cooked, ratio trend, streaks, headcounts and co-diners are computed per member over the meals.
+func TestComputeStats(t *testing.T) {
+	members := []*Member{
+		{ID: 1, SlackUID: "U1"},
+		{ID: 2, SlackUID: "U2"},
+		{ID: 3, SlackUID: "U3"},
+	}
+	monday := Date{Year: 2023, Month: 1, Day: 2}
+	meals := []CookedMeal{
+		{Date: monday, Cooks: []int64{1}, Eaters: []int64{1, 2}, Guests: 1},
+		{Date: monday.AddDays(1), Cooks: []int64{2}, Eaters: []int64{1, 2, 3}},
+		{Date: monday.AddDays(7), Cooks: []int64{1, 3}, Eaters: []int64{2, 3}},
+	}
+	stats := ComputeStats(monday, monday.AddDays(13), members, meals)
+	if stats.Meals != 3 {
+		t.Errorf("Meals = %d, want 3", stats.Meals)
+	}
+
+	want := []MemberStats{
+		{
+			MemberID: 1, SlackUID: "U1", MealsEaten: 2, MealsCooked: 1.5, Ratio: 4.0 / 3,
+			RatioTrend:    []RatioPoint{{monday, 2}, {monday.AddDays(7), 4.0 / 3}},
+			LongestStreak: 2, CurrentStreak: 0, AverageHeadcount: 2.5,
+			CoDiners: []CoDiner{{2, "U2", 2}, {3, "U3", 1}},
+		},
+		{
+			MemberID: 2, SlackUID: "U2", MealsEaten: 3, MealsCooked: 1, Ratio: 3,
+			RatioTrend:    []RatioPoint{{monday, 2}, {monday.AddDays(7), 3}},
+			LongestStreak: 3, CurrentStreak: 3, AverageHeadcount: 3,
+			CoDiners: []CoDiner{{1, "U1", 2}, {3, "U3", 2}},
+		},
+		{
+			MemberID: 3, SlackUID: "U3", MealsEaten: 2, MealsCooked: 0.5, Ratio: 4,
+			RatioTrend:    []RatioPoint{{monday, math.MaxFloat32}, {monday.AddDays(7), 4}},
+			LongestStreak: 2, CurrentStreak: 2, AverageHeadcount: 2,
+			CoDiners: []CoDiner{{2, "U2", 2}, {1, "U1", 1}},
+		},
+	}
+	for ii, got := range stats.Members {
+		if !reflect.DeepEqual(*got, want[ii]) {
+			t.Errorf("Members[%d] = %+v, want %+v", ii, *got, want[ii])
+		}
+	}
+}
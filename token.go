@@ -0,0 +1,69 @@
+package dinny
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Token scopes. A write token may also be used wherever a read token is required.
+const (
+	TokenScopeRead  = "read"
+	TokenScopeWrite = "write"
+)
+
+// Token represents an API token used to authenticate against the /cmd routes.
+// Only the hash of the token's secret is stored.
+type Token struct {
+	ID        int64      `json:"id"`
+	MemberID  int64      `json:"memberID"`
+	Name      string     `json:"name"`
+	Scope     string     `json:"scope"`
+	Hash      string     `json:"-"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Allows reports whether the token grants the given scope.
+func (t *Token) Allows(scope string) bool {
+	return t.Scope == TokenScopeWrite || t.Scope == scope
+}
+
+// ValidTokenScope reports whether scope is a known token scope.
+func ValidTokenScope(scope string) bool {
+	return scope == TokenScopeRead || scope == TokenScopeWrite
+}
+
+// NewTokenSecret generates a random token secret to be handed to the token's owner.
+func NewTokenSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("NewTokenSecret rand.Read: %w", err)
+	}
+	return "dinny_" + hex.EncodeToString(buf), nil
+}
+
+// HashTokenSecret returns the hash of a token secret as it is stored.
+func HashTokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// TokenService represents a service for managing API tokens.
+type TokenService interface {
+	// FindTokenByHash retrieves an unrevoked token by the hash of its secret.
+	// Returns ErrNotFound if token does not exist or has been revoked.
+	FindTokenByHash(hash string) (*Token, error)
+
+	// ListTokens retrieves a list of every token, including revoked ones.
+	ListTokens() ([]*Token, error)
+
+	// CreateToken creates a new token. Sets the ID of t on success.
+	CreateToken(t *Token) error
+
+	// RevokeToken revokes a token so it can no longer be used.
+	// Returns ErrNotFound if token does not exist or has already been revoked.
+	RevokeToken(id int64) error
+}